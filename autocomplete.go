@@ -2,35 +2,47 @@ package main
 
 import (
 	"strings"
+
+	"tropical-fish/decoder"
 )
 
-// GetColorSuggestion returns the best autocomplete suggestion for a partial color input
+// GetColorSuggestion returns the best autocomplete suggestion for a partial
+// color input among the colors in palette, in its language
 // Returns empty string if no match or input is empty
-func GetColorSuggestion(input string, bandNum int) string {
+func GetColorSuggestion(input string, bandNum int, palette decoder.Palette) string {
 	if input == "" {
 		return ""
 	}
 
 	input = strings.ToLower(strings.TrimSpace(input))
 
-	// Get all valid colors
-	validColors := AllColorNames()
+	// An abbreviation only completes to its own color, so "bl" suggests Blue
+	// rather than Black and "bn" suggests nothing
+	if color, ok := palette.ParseColorAbbrev(input); ok {
+		name := palette.ColorNames()[color]
+		if (bandNum == 1 || bandNum == 2) && !decoder.GetColorInfo(color).ValidDigit ||
+			len(name) < len(input) || !strings.EqualFold(name[:len(input)], input) {
+			return ""
+		}
+		return name[len(input):]
+	}
 
-	// Filter based on band number (bands 1-2 can't use Gold/Silver)
-	if bandNum == 1 || bandNum == 2 {
-		filteredColors := []string{}
-		for _, color := range validColors {
-			if color != "Gold" && color != "Silver" {
-				filteredColors = append(filteredColors, color)
-			}
+	// A complete name needs no suggestion, even where a longer name starts
+	// with it, as French "Or" (Gold) and "Orange" do
+	for _, name := range palette.ColorNames() {
+		if strings.EqualFold(name, input) {
+			return ""
 		}
-		validColors = filteredColors
 	}
 
 	// Find first match that starts with the input
-	for _, color := range validColors {
-		colorLower := strings.ToLower(color)
-		if strings.HasPrefix(colorLower, input) {
+	for i, color := range palette.ColorNames() {
+		// Bands 1-2 can't use Gold/Silver
+		if (bandNum == 1 || bandNum == 2) && !decoder.GetColorInfo(decoder.Color(i)).ValidDigit {
+			continue
+		}
+
+		if len(color) >= len(input) && strings.EqualFold(color[:len(input)], input) {
 			// Return the remaining part of the color (the suggestion)
 			return color[len(input):]
 		}
@@ -46,3 +58,23 @@ func GetFullColorFromInput(input string, suggestion string) string {
 	}
 	return input + suggestion
 }
+
+// GetCapacitorTypeSuggestion returns the autocomplete suggestion for a partial
+// capacitor type name. Returns empty string if input is empty, a type letter,
+// or does not match a name
+func GetCapacitorTypeSuggestion(input string) string {
+	input = strings.ToLower(strings.TrimSpace(input))
+	if len(input) < 2 {
+		return ""
+	}
+
+	for _, code := range decoder.AllCapacitorTypes() {
+		for _, word := range decoder.CapacitorTypeNameWords(decoder.CapacitorType(code)) {
+			if strings.HasPrefix(strings.ToLower(word), input) {
+				return word[len(input):]
+			}
+		}
+	}
+
+	return ""
+}
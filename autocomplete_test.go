@@ -1,7 +1,10 @@
 package main
 
 import (
+	"strings"
 	"testing"
+
+	"tropical-fish/decoder"
 )
 
 // TestGetColorSuggestion tests the autocomplete suggestion function
@@ -103,10 +106,34 @@ func TestGetColorSuggestion(t *testing.T) {
 			expectedSuffix: "hite",
 		},
 		{
-			name:           "Black suggested for 'bl'",
+			name:           "Blue suggested for the 'bl' abbreviation",
 			input:          "bl",
 			bandNum:        1,
-			expectedSuffix: "ack", // Black comes before Blue alphabetically
+			expectedSuffix: "ue", // BL abbreviates Blue, so Black needs 'bla'
+		},
+		{
+			name:           "Black needs 'bla' to disambiguate from Blue",
+			input:          "bla",
+			bandNum:        1,
+			expectedSuffix: "ck",
+		},
+		{
+			name:           "Abbreviation 'bn' has no suggestion",
+			input:          "bn",
+			bandNum:        1,
+			expectedSuffix: "",
+		},
+		{
+			name:           "Abbreviation 'or' completes to Orange",
+			input:          "or",
+			bandNum:        1,
+			expectedSuffix: "ange",
+		},
+		{
+			name:           "Abbreviation 'si' not suggested for band 1",
+			input:          "si",
+			bandNum:        1,
+			expectedSuffix: "",
 		},
 		{
 			name:           "Blue needs 'blu' to disambiguate from Black",
@@ -118,7 +145,7 @@ func TestGetColorSuggestion(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := GetColorSuggestion(tt.input, tt.bandNum)
+			result := GetColorSuggestion(tt.input, tt.bandNum, decoder.Palette{})
 			if result != tt.expectedSuffix {
 				t.Errorf("GetColorSuggestion(%q, %d) = %q, want %q", tt.input, tt.bandNum, result, tt.expectedSuffix)
 			}
@@ -173,25 +200,93 @@ func TestGetFullColorFromInput(t *testing.T) {
 // TestAutocompleteBandValidation ensures suggestions respect band restrictions
 func TestAutocompleteBandValidation(t *testing.T) {
 	// Test that Gold/Silver are not suggested for bands 1-2
-	band1Suggestion := GetColorSuggestion("go", 1)
+	band1Suggestion := GetColorSuggestion("go", 1, decoder.Palette{})
 	if band1Suggestion == "ld" {
 		t.Error("Gold should not be suggested for band 1 (digit band)")
 	}
 
-	band2Suggestion := GetColorSuggestion("si", 2)
+	band2Suggestion := GetColorSuggestion("si", 2, decoder.Palette{})
 	if band2Suggestion == "lver" {
 		t.Error("Silver should not be suggested for band 2 (digit band)")
 	}
 
 	// Test that Gold/Silver are available for other bands
 	// Note: "g" will suggest "reen" (Green) first, need "go" for Gold
-	band3Suggestion := GetColorSuggestion("go", 3)
+	band3Suggestion := GetColorSuggestion("go", 3, decoder.Palette{})
 	if band3Suggestion != "ld" {
 		t.Errorf("Gold should be suggested for band 3 with 'go', got %q", band3Suggestion)
 	}
 
-	band4Suggestion := GetColorSuggestion("si", 4)
+	band4Suggestion := GetColorSuggestion("si", 4, decoder.Palette{})
 	if band4Suggestion != "lver" {
 		t.Errorf("Silver should be suggested for band 4 with 'si', got %q", band4Suggestion)
 	}
 }
+
+// TestGetCapacitorTypeSuggestion tests autocomplete for capacitor type names
+func TestGetCapacitorTypeSuggestion(t *testing.T) {
+	tests := []struct {
+		input          string
+		expectedSuffix string
+	}{
+		{"", ""},
+		{"m", ""}, // Single letters are type codes
+		{"mi", "ca"},
+		{"tan", "talum"},
+		{"Poly", "ester / Polystyrene"},
+		{"polys", "tyrene"},
+		{"ceramic", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := GetCapacitorTypeSuggestion(tt.input); got != tt.expectedSuffix {
+				t.Errorf("GetCapacitorTypeSuggestion(%q) = %q, want %q", tt.input, got, tt.expectedSuffix)
+			}
+		})
+	}
+}
+
+// TestInputIndicator tests the pre-enter check mark for a typed color
+func TestInputIndicator(t *testing.T) {
+	tests := []struct {
+		name      string
+		component decoder.ComponentType
+		band      int
+		input     string
+		expected  string
+	}{
+		{"Empty input", decoder.ComponentCapacitor, 1, "", ""},
+		{"Incomplete prefix", decoder.ComponentCapacitor, 1, "re", ""},
+		{"Valid digit", decoder.ComponentCapacitor, 1, "red", "✓"},
+		{"Gold as digit", decoder.ComponentCapacitor, 1, "Gold", "✗"},
+		{"Gold as multiplier", decoder.ComponentCapacitor, 3, "gold", "✓"},
+		{"Resistor tolerance", decoder.ComponentResistor, 4, "orange", "✗"},
+		{"Alternative spelling", decoder.ComponentResistor, 1, "gray", "✓"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := initialModel()
+			m.componentType = tt.component
+			m.capacitorReading.CapType = decoder.TypeK
+			m.currentBand = tt.band
+			m.input = tt.input
+
+			got := m.inputIndicator()
+			if tt.expected == "" && got != "" || !strings.Contains(got, tt.expected) {
+				t.Errorf("inputIndicator() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+// BenchmarkGetColorSuggestion measures autocomplete as a color is typed one
+// character at a time
+func BenchmarkGetColorSuggestion(b *testing.B) {
+	prefixes := []string{"v", "vi", "vio", "viol", "viole", "violet"}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		GetColorSuggestion(prefixes[i%len(prefixes)], 3, decoder.Palette{})
+	}
+}
@@ -0,0 +1,400 @@
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"tropical-fish/decoder"
+)
+
+// batchRow is one band spec read from a batch input CSV
+type batchRow struct {
+	componentType string // "resistor" or "capacitor" (or "r" / "c")
+	capType       string // Capacitor type letter or name
+	bands         string // Band colors separated by commas or spaces
+}
+
+// normalizeColumnName lowercases a header and strips spaces, underscores and dashes
+// so "Cap Type", "cap_type" and "capType" all match
+func normalizeColumnName(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	return strings.NewReplacer(" ", "", "_", "", "-", "").Replace(name)
+}
+
+// readBatchRows reads band specs from input with a header row containing
+// type and bands columns, plus an optional capType column. The field
+// separator (comma, semicolon, tab or pipe) is detected on each line.
+func readBatchRows(r io.Reader) ([]batchRow, error) {
+	return readDelimitedBatchRows(r, 0)
+}
+
+// readDelimitedBatchRows reads band specs using the given field separator,
+// or detecting it per line if delimiter is 0
+func readDelimitedBatchRows(r io.Reader, delimiter rune) ([]batchRow, error) {
+	var records [][]string
+	expected := delimiter
+	scanner := bufio.NewScanner(r)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		lineDelimiter := delimiter
+		if lineDelimiter == 0 {
+			detected, err := sniffDelimiter(line)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNum, err)
+			}
+			if expected == 0 {
+				// The header sets the separator for the rest of the input
+				expected = detected
+			} else if detected != expected && detected != ',' {
+				return nil, fmt.Errorf("line %d: fields separated by %s, but the header uses %s",
+					lineNum, delimiterName(detected), delimiterName(expected))
+			}
+			lineDelimiter = expected
+		}
+
+		reader := csv.NewReader(strings.NewReader(line))
+		reader.Comma = lineDelimiter
+		reader.FieldsPerRecord = -1
+		// Leading-space trimming would swallow empty tab-separated fields
+		reader.TrimLeadingSpace = lineDelimiter != '\t'
+		record, err := reader.Read()
+		if err != nil {
+			return nil, fmt.Errorf("line %d: failed to read %s-separated fields: %w", lineNum, delimiterName(lineDelimiter), err)
+		}
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read input: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("input is empty")
+	}
+
+	columns := map[string]int{}
+	for i, name := range records[0] {
+		columns[normalizeColumnName(name)] = i
+	}
+	typeCol, hasType := columns["type"]
+	bandsCol, hasBands := columns["bands"]
+	capTypeCol, hasCapType := columns["captype"]
+	if !hasType || !hasBands {
+		return nil, fmt.Errorf("input header must include type and bands columns")
+	}
+
+	field := func(record []string, col int) string {
+		if col < len(record) {
+			return strings.TrimSpace(record[col])
+		}
+		return ""
+	}
+
+	var rows []batchRow
+	for _, record := range records[1:] {
+		row := batchRow{
+			componentType: field(record, typeCol),
+			bands:         field(record, bandsCol),
+		}
+		if hasCapType {
+			row.capType = field(record, capTypeCol)
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// sniffDelimiter returns the field separator of a line. Tabs, pipes and
+// semicolons win over commas, since band lists are often comma-separated
+// within a field. Returns an error if a line mixes tabs and pipes.
+func sniffDelimiter(line string) (rune, error) {
+	counts := map[rune]int{}
+	inQuotes := false
+	for _, r := range line {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+		case !inQuotes && (r == '\t' || r == '|' || r == ';' || r == ','):
+			counts[r]++
+		}
+	}
+
+	if counts['\t'] > 0 && counts['|'] > 0 {
+		return 0, fmt.Errorf("mixed tab and pipe separators")
+	}
+	switch {
+	case counts['\t'] > 0:
+		return '\t', nil
+	case counts['|'] > 0:
+		return '|', nil
+	case counts[';'] > 0:
+		return ';', nil
+	default:
+		return ',', nil
+	}
+}
+
+// delimiterName names a field separator for error messages
+func delimiterName(delimiter rune) string {
+	switch delimiter {
+	case '\t':
+		return "tab"
+	case '|':
+		return "pipe"
+	case ';':
+		return "semicolon"
+	case ',':
+		return "comma"
+	default:
+		return fmt.Sprintf("'%c'", delimiter)
+	}
+}
+
+// decodeBatchRow decodes a single batch row using the same path as the
+// decode flags, reading band colors in palette
+func decodeBatchRow(row batchRow, palette decoder.Palette) (ComponentEntry, error) {
+	opts := cliOptions{capType: row.capType, bands: row.bands, palette: palette}
+	switch strings.ToLower(row.componentType) {
+	case "resistor", "r":
+		opts.resistor = true
+	case "capacitor", "c":
+		opts.capacitor = true
+	default:
+		return ComponentEntry{}, fmt.Errorf("invalid type '%s' (must be resistor or capacitor)", row.componentType)
+	}
+	if row.bands == "" {
+		return ComponentEntry{}, fmt.Errorf("no bands given")
+	}
+	return decodeFromFlags(opts)
+}
+
+// failedBatchRecord returns an export row for an input row that could not be
+// decoded, echoing the input so the row can be found and fixed
+func failedBatchRecord(row batchRow, timestamp string) []string {
+	record := make([]string, len(BuildCSVHeader()))
+	record[0] = timestamp
+	record[1] = row.componentType
+	record[2] = row.capType
+
+	bands := strings.FieldsFunc(row.bands, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t'
+	})
+	for i := 0; i < len(bands) && i < 6; i++ {
+		record[4+i] = bands[i]
+	}
+	return record
+}
+
+// WriteBatchResults decodes every row and writes a results CSV with an added
+// Error column. Bad rows are reported in that column rather than aborting.
+// Returns the number of rows written and how many of them failed.
+func WriteBatchResults(rows []batchRow, w io.Writer, palette decoder.Palette) (int, int, error) {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write(append(BuildCSVHeader(), "Error")); err != nil {
+		return 0, 0, fmt.Errorf("failed to write header: %w", err)
+	}
+
+	now := time.Now()
+	timestamp := now.Format(csvTimestampLayout)
+	failed := 0
+	for _, row := range rows {
+		var record []string
+		entry, err := decodeBatchRow(row, palette)
+		if err == nil {
+			record, _ = BuildCSVRecord(entry, now)
+			record = append(record, "")
+		} else {
+			failed++
+			record = append(failedBatchRecord(row, timestamp), err.Error())
+		}
+		if err := writer.Write(record); err != nil {
+			return 0, 0, fmt.Errorf("failed to write record: %w", err)
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return 0, 0, fmt.Errorf("failed to write results: %w", err)
+	}
+	return len(rows), failed, nil
+}
+
+// runBatch reads band specs from opts.in ("-" for stdin) and writes results
+// to opts.out (stdout if unset), returning the process exit code: 1 if any
+// row failed to decode or the output could not be written
+func runBatch(opts cliOptions, stdout, stderr io.Writer) int {
+	var in io.Reader = os.Stdin
+	if opts.in != "-" {
+		file, err := os.Open(opts.in)
+		if err != nil {
+			fmt.Fprintf(stderr, "Error: %v\n", err)
+			return 1
+		}
+		defer file.Close()
+		in = file
+	}
+
+	// .csv files are always comma-separated; anything else, including
+	// pasted spreadsheet data, is detected line by line
+	var delimiter rune
+	if strings.EqualFold(filepath.Ext(opts.in), ".csv") {
+		delimiter = ','
+	}
+	rows, err := readDelimitedBatchRows(in, delimiter)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	out := stdout
+	var file *os.File
+	if opts.out != "" && opts.out != "-" {
+		file, err = os.Create(opts.out)
+		if err != nil {
+			fmt.Fprintf(stderr, "Error: failed to create file: %v\n", err)
+			return 1
+		}
+		out = file
+	}
+
+	written, failed, err := WriteBatchResults(rows, out, opts.palette)
+	if file != nil {
+		// A failed close can lose buffered rows, so it fails the run too
+		if closeErr := file.Close(); err == nil && closeErr != nil {
+			err = fmt.Errorf("failed to write file: %v", closeErr)
+		}
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	fmt.Fprintf(stderr, "Processed %d row(s), %d with errors\n", written, failed)
+	if failed > 0 {
+		return 1
+	}
+	return 0
+}
+
+// ParseComponentLine decodes one compact batch line: "R,<bands>,<colors...>"
+// for a resistor or "C,<type>,<bands>,<colors...>" for a capacitor, e.g.
+// "R,4,brown,black,red,gold" or "C,K,5,red,violet,orange,brown,orange".
+// Fields may also be separated by semicolons, tabs or pipes, as with -in.
+// Band colors are read in palette.
+func ParseComponentLine(line string, palette decoder.Palette) (ComponentEntry, error) {
+	delimiter, err := sniffDelimiter(line)
+	if err != nil {
+		return ComponentEntry{}, err
+	}
+	fields := strings.Split(line, string(delimiter))
+	for i := range fields {
+		fields[i] = strings.TrimSpace(fields[i])
+	}
+
+	opts := cliOptions{palette: palette}
+	switch strings.ToUpper(fields[0]) {
+	case "R":
+		opts.resistor = true
+		fields = fields[1:]
+	case "C":
+		if len(fields) < 2 {
+			return ComponentEntry{}, fmt.Errorf("missing capacitor type")
+		}
+		opts.capacitor = true
+		opts.capType = fields[1]
+		fields = fields[2:]
+	default:
+		return ComponentEntry{}, fmt.Errorf("invalid component '%s' (must be R or C)", fields[0])
+	}
+
+	if len(fields) == 0 || fields[0] == "" {
+		return ComponentEntry{}, fmt.Errorf("missing band count")
+	}
+	bandCount, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return ComponentEntry{}, fmt.Errorf("invalid band count '%s'", fields[0])
+	}
+	colors := fields[1:]
+	if len(colors) != bandCount {
+		return ComponentEntry{}, fmt.Errorf("expected %d bands, got %d", bandCount, len(colors))
+	}
+
+	opts.bands = strings.Join(colors, ",")
+	return decodeFromFlags(opts)
+}
+
+// readComponentLines decodes every non-blank line of r with
+// ParseComponentLine, skipping # comments. Lines that fail are returned as
+// errors prefixed with their line number, after the entries that decoded.
+func readComponentLines(r io.Reader, palette decoder.Palette) ([]ComponentEntry, []error) {
+	var entries []ComponentEntry
+	var errs []error
+	scanner := bufio.NewScanner(r)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		entry, err := ParseComponentLine(line, palette)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("line %d: %w", lineNum, err))
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		errs = append(errs, fmt.Errorf("failed to read input: %w", err))
+	}
+	return entries, errs
+}
+
+// runComponentBatch decodes the compact lines in opts.batch ("-" for stdin)
+// and writes them in the CSV export format to opts.out (stdout if unset).
+// Every bad line is reported; the exit code is 1 if any failed.
+func runComponentBatch(opts cliOptions, stdout, stderr io.Writer) int {
+	var in io.Reader = os.Stdin
+	if opts.batch != "-" {
+		file, err := os.Open(opts.batch)
+		if err != nil {
+			fmt.Fprintf(stderr, "Error: %v\n", err)
+			return 1
+		}
+		defer file.Close()
+		in = file
+	}
+
+	entries, errs := readComponentLines(in, opts.palette)
+	for _, err := range errs {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+	}
+
+	if len(entries) > 0 {
+		var err error
+		if opts.out != "" && opts.out != "-" {
+			err = ExportToCSV(entries, opts.out)
+		} else {
+			err = writeHistoryCSV(stdout, entries, ExportOptions{})
+		}
+		if err != nil {
+			fmt.Fprintf(stderr, "Error: %v\n", err)
+			return 1
+		}
+	}
+
+	fmt.Fprintf(stderr, "Decoded %d line(s), %d with errors\n", len(entries), len(errs))
+	if len(errs) > 0 {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,257 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"tropical-fish/decoder"
+)
+
+// TestWriteBatchResults tests that batch decoding reports bad rows without aborting
+func TestWriteBatchResults(t *testing.T) {
+	input := `type,Cap Type,bands
+resistor,,"brown,black,red,gold"
+capacitor,K,red violet orange brown orange
+resistor,,gold black red gold
+inductor,,red red red
+`
+	rows, err := readBatchRows(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("readBatchRows() error = %v", err)
+	}
+
+	var out bytes.Buffer
+	written, failed, err := WriteBatchResults(rows, &out, decoder.Palette{})
+	if err != nil {
+		t.Fatalf("WriteBatchResults() error = %v", err)
+	}
+	if written != 4 || failed != 2 {
+		t.Errorf("WriteBatchResults() = %d written, %d failed, want 4, 2", written, failed)
+	}
+
+	records, err := csv.NewReader(&out).ReadAll()
+	if err != nil {
+		t.Fatalf("output is not valid CSV: %v", err)
+	}
+	errorCol := len(records[0]) - 1
+	if records[0][errorCol] != "Error" {
+		t.Errorf("last header column = %q, want %q", records[0][errorCol], "Error")
+	}
+
+	tests := []struct {
+		row       int
+		component string
+		hasError  bool
+	}{
+		{1, "Resistor", false},
+		{2, "Capacitor", false},
+		{3, "resistor", true},
+		{4, "inductor", true},
+	}
+	for _, tt := range tests {
+		record := records[tt.row]
+		if record[1] != tt.component {
+			t.Errorf("row %d component = %q, want %q", tt.row, record[1], tt.component)
+		}
+		if (record[errorCol] != "") != tt.hasError {
+			t.Errorf("row %d error = %q, hasError = %v", tt.row, record[errorCol], tt.hasError)
+		}
+	}
+}
+
+// TestReadBatchRowsMissingColumns tests that the input header is checked
+func TestReadBatchRowsMissingColumns(t *testing.T) {
+	if _, err := readBatchRows(strings.NewReader("type,colors\nresistor,red\n")); err == nil {
+		t.Error("readBatchRows() without bands column error = nil, want error")
+	}
+}
+
+// TestReadBatchRowsDelimiters tests that tab-, pipe- and semicolon-separated input is detected
+func TestReadBatchRowsDelimiters(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []batchRow
+		wantErr string
+	}{
+		{
+			name:  "tab",
+			input: "type\tcapType\tbands\nresistor\t\tbrown,black,red,gold\ncapacitor\tK\tred violet orange\n",
+			want: []batchRow{
+				{componentType: "resistor", bands: "brown,black,red,gold"},
+				{componentType: "capacitor", capType: "K", bands: "red violet orange"},
+			},
+		},
+		{
+			name:  "pipe",
+			input: "type | bands\nresistor | brown,black,red,gold\n\n",
+			want:  []batchRow{{componentType: "resistor", bands: "brown,black,red,gold"}},
+		},
+		{
+			name:  "semicolon",
+			input: "type;capType;bands\nresistor;;brown,black,red,gold\n",
+			want:  []batchRow{{componentType: "resistor", bands: "brown,black,red,gold"}},
+		},
+		{
+			name:  "quoted pipe is not a separator",
+			input: "type,bands\nresistor,\"brown|black|red\"\n",
+			want:  []batchRow{{componentType: "resistor", bands: "brown|black|red"}},
+		},
+		{
+			name:    "mixed within a line",
+			input:   "type\tbands|capType\n",
+			wantErr: "line 1: mixed tab and pipe",
+		},
+		{
+			name:    "line differs from header",
+			input:   "type\tbands\nresistor|brown black red\n",
+			wantErr: "line 2: fields separated by pipe, but the header uses tab",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := readBatchRows(strings.NewReader(tt.input))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("readBatchRows() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("readBatchRows() error = %v", err)
+			}
+			if len(rows) != len(tt.want) {
+				t.Fatalf("readBatchRows() = %d rows, want %d", len(rows), len(tt.want))
+			}
+			for i := range rows {
+				if rows[i] != tt.want[i] {
+					t.Errorf("row %d = %+v, want %+v", i, rows[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+// TestReadDelimitedBatchRowsCSV tests that a fixed comma separator keeps tabs in fields
+func TestReadDelimitedBatchRowsCSV(t *testing.T) {
+	rows, err := readDelimitedBatchRows(strings.NewReader("type,bands\nresistor,brown\tblack\tred\n"), ',')
+	if err != nil {
+		t.Fatalf("readDelimitedBatchRows() error = %v", err)
+	}
+	if len(rows) != 1 || rows[0].bands != "brown\tblack\tred" {
+		t.Errorf("readDelimitedBatchRows() = %+v, want bands with tabs kept", rows)
+	}
+}
+
+// TestParseComponentLine tests the compact R/C batch line format
+func TestParseComponentLine(t *testing.T) {
+	tests := []struct {
+		line    string
+		wantPF  float64
+		wantOhm float64
+		wantErr string
+	}{
+		{"R,4,brown,black,red,gold", 0, 1000, ""},
+		{"r, 5, brown, black, black, brown, brown", 0, 1000, ""},
+		{"C,K,5,red,violet,orange,brown,orange", 27000, 0, ""},
+		{"c,mica,3,red,violet,orange", 27000, 0, ""},
+		{"R;4;brown;black;red;gold", 0, 1000, ""},
+		{"C\tK\t3\tred\tviolet\torange", 27000, 0, ""},
+		{"R|4|brown|black|red|gold", 0, 1000, ""},
+		{"R,4,brown,black,red", 0, 0, "expected 4 bands, got 3"},
+		{"R,four,brown", 0, 0, "invalid band count"},
+		{"C,K", 0, 0, "missing band count"},
+		{"C,Z,3,red,violet,orange", 0, 0, "--type"},
+		{"X,4,brown,black,red,gold", 0, 0, "must be R or C"},
+		{"R,4,gold,black,red,gold", 0, 0, "Band 1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			entry, err := ParseComponentLine(tt.line, decoder.Palette{})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ParseComponentLine() error = %v, want mention of %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseComponentLine() error = %v", err)
+			}
+			switch {
+			case tt.wantOhm > 0 && (entry.ResistorResult == nil || entry.ResistorResult.ResistanceOhms != tt.wantOhm):
+				t.Errorf("ParseComponentLine() = %+v, want %v Ω", entry, tt.wantOhm)
+			case tt.wantPF > 0 && (entry.CapacitorResult == nil || entry.CapacitorResult.CapacitancePF != tt.wantPF):
+				t.Errorf("ParseComponentLine() = %+v, want %v pF", entry, tt.wantPF)
+			}
+		})
+	}
+}
+
+// TestRunComponentBatch tests that good lines are exported and bad ones are
+// reported by line number
+func TestRunComponentBatch(t *testing.T) {
+	input := "# inventory\nR,4,brown,black,red,gold\n\nR,4,brown,black,red\nC,K,5,red,violet,orange,brown,orange\n"
+	path := filepath.Join(t.TempDir(), "parts.txt")
+	if err := os.WriteFile(path, []byte(input), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr strings.Builder
+	if code := runComponentBatch(cliOptions{batch: path}, &stdout, &stderr); code != 1 {
+		t.Errorf("runComponentBatch() = %d, want 1", code)
+	}
+	if !strings.Contains(stderr.String(), "line 4: expected 4 bands, got 3") {
+		t.Errorf("stderr = %q, want the failing line number", stderr.String())
+	}
+
+	// The output is an export file that imports back
+	outPath := filepath.Join(t.TempDir(), "results.csv")
+	if err := os.WriteFile(outPath, []byte(stdout.String()), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	entries, err := ImportFromCSV(outPath)
+	if err != nil {
+		t.Fatalf("ImportFromCSV() error = %v", err)
+	}
+	if len(entries) != 2 {
+		t.Errorf("exported %d entries, want 2", len(entries))
+	}
+}
+
+// TestRunBatchExitCode tests that -in exits 1 when a row fails and 0 when
+// every row decodes, with the results written to -out either way
+func TestRunBatchExitCode(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  int
+	}{
+		{"all rows decode", "type,bands\nresistor,\"brown,black,red,gold\"\n", 0},
+		{"a row fails", "type,bands\nresistor,\"brown,black,red,gold\"\nresistor,\"gold,black,red,gold\"\n", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			in := filepath.Join(dir, "parts.csv")
+			if err := os.WriteFile(in, []byte(tt.input), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			out := filepath.Join(dir, "results.csv")
+
+			var stdout, stderr strings.Builder
+			if code := runBatch(cliOptions{in: in, out: out}, &stdout, &stderr); code != tt.want {
+				t.Errorf("runBatch() = %d, want %d (stderr %q)", code, tt.want, stderr.String())
+			}
+			if data, err := os.ReadFile(out); err != nil || !strings.Contains(string(data), "Brown") {
+				t.Errorf("results file = %q, %v, want the decoded rows", data, err)
+			}
+		})
+	}
+}
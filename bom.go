@@ -0,0 +1,142 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"tropical-fish/decoder"
+)
+
+// bomHeader holds the BOM columns, matching common distributor BOM uploads
+var bomHeader = []string{"Value", "Tolerance", "Voltage/Power", "Package", "Quantity"}
+
+// BOMRow returns a BOM row for an entry: value, tolerance, voltage or power
+// rating, package and quantity. Returns nil for an entry without a result.
+func BOMRow(entry ComponentEntry) []string {
+	quantity := max(entry.Quantity, 1)
+
+	switch {
+	case entry.ComponentType == decoder.ComponentCapacitor && entry.CapacitorResult != nil:
+		result := entry.CapacitorResult
+		voltage := ""
+		if result.VoltageValid {
+			voltage = compactNumber(result.VoltageRating) + "V"
+		}
+		return []string{
+			compactNumber(result.CapacitanceValue) + result.CapacitanceUnit,
+			compactTolerance(result),
+			voltage,
+			entry.Package,
+			strconv.Itoa(quantity),
+		}
+
+	case entry.ComponentType == decoder.ComponentResistor && entry.ResistorResult != nil:
+		result := entry.ResistorResult
+		// A jumper has no tolerance to buy to
+		if result.Jumper {
+			return []string{"0Ω", "", "", entry.Package, strconv.Itoa(quantity)}
+		}
+		// Power rating isn't color coded, so it is left for the buyer
+		return []string{
+			compactNumber(result.ResistanceValue) + result.ResistanceUnit,
+			compactResistorTolerance(result),
+			"",
+			entry.Package,
+			strconv.Itoa(quantity),
+		}
+	}
+
+	return nil
+}
+
+// BOMRowText returns a BOM row as tab-separated text, which spreadsheets
+// split into cells when pasted
+func BOMRowText(entry ComponentEntry) string {
+	return strings.Join(BOMRow(entry), "\t")
+}
+
+// aggregateBOMRows returns one BOM row per distinct part, in order of first
+// appearance, with the quantities of identical parts summed
+func aggregateBOMRows(history []ComponentEntry) [][]string {
+	var rows [][]string
+	index := map[string]int{}
+
+	for _, entry := range history {
+		row := BOMRow(entry)
+		if row == nil {
+			continue
+		}
+		key := strings.Join(row[:4], "\x00")
+		quantity := max(entry.Quantity, 1)
+
+		if i, ok := index[key]; ok {
+			total, _ := strconv.Atoi(rows[i][4])
+			rows[i][4] = strconv.Itoa(total + quantity)
+			continue
+		}
+		index[key] = len(rows)
+		rows = append(rows, row)
+	}
+
+	return rows
+}
+
+// ExportBOM writes the history to a BOM CSV, one row per distinct part
+func ExportBOM(history []ComponentEntry, filename string) error {
+	rows := aggregateBOMRows(history)
+	if len(rows) == 0 {
+		return fmt.Errorf("no component data to export")
+	}
+
+	file, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	if err := writer.Write(bomHeader); err != nil {
+		return fmt.Errorf("failed to write header: %w", err)
+	}
+	if err := writer.WriteAll(rows); err != nil {
+		return fmt.Errorf("failed to write record: %w", err)
+	}
+
+	return nil
+}
+
+// packageQuantityPattern matches a trailing quantity such as "x4" or "× 10"
+var packageQuantityPattern = regexp.MustCompile(`(?i)^(?:(.*\S)\s+)?[x×]\s*(\d+)$`)
+
+// ParsePackageQuantity parses BOM details typed as "<package> x<qty>",
+// e.g. "0805 x4", "TO-92", or "x10". The quantity defaults to 1.
+func ParsePackageQuantity(input string) (string, int, error) {
+	s := strings.TrimSpace(input)
+
+	match := packageQuantityPattern.FindStringSubmatch(s)
+	if match == nil {
+		return s, 1, nil
+	}
+
+	quantity, err := strconv.Atoi(match[2])
+	if err != nil || quantity < 1 {
+		return "", 0, fmt.Errorf("quantity must be at least 1")
+	}
+	return match[1], quantity, nil
+}
+
+// formatPackageQuantity is the inverse of ParsePackageQuantity, used to
+// pre-fill the BOM input
+func formatPackageQuantity(pkg string, quantity int) string {
+	if quantity <= 1 {
+		return pkg
+	}
+	if pkg == "" {
+		return fmt.Sprintf("x%d", quantity)
+	}
+	return fmt.Sprintf("%s x%d", pkg, quantity)
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"tropical-fish/decoder"
+)
+
+// TestBOMRow tests BOM columns for capacitors and resistors
+func TestBOMRow(t *testing.T) {
+	capacitor := mustDecode(t, cliOptions{capacitor: true, capType: "K", bands: "red,violet,orange,brown,orange"}, "")
+	capacitor.Package = "radial"
+	resistor := mustDecode(t, cliOptions{resistor: true, bands: "yellow,violet,red,gold"}, "")
+	resistor.Package = "0805"
+	resistor.Quantity = 4
+
+	tests := []struct {
+		name     string
+		entry    ComponentEntry
+		expected []string
+	}{
+		{"Capacitor with voltage", capacitor, []string{"27nF", "±1%", "400V", "radial", "1"}},
+		{"Resistor with quantity", resistor, []string{"4.7kΩ", "±5%", "", "0805", "4"}},
+		{"No result", ComponentEntry{ComponentType: decoder.ComponentResistor}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := BOMRow(tt.entry); !slices.Equal(got, tt.expected) {
+				t.Errorf("BOMRow() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestParsePackageQuantity tests the package and quantity input
+func TestParsePackageQuantity(t *testing.T) {
+	tests := []struct {
+		input       string
+		expectedPkg string
+		expectedQty int
+		expectErr   bool
+	}{
+		{"0805 x4", "0805", 4, false},
+		{"TO-92 × 10", "TO-92", 10, false},
+		{"x3", "", 3, false},
+		{"0805", "0805", 1, false},
+		{"", "", 1, false},
+		{"axial x0", "", 0, true},
+	}
+
+	for _, tt := range tests {
+		pkg, qty, err := ParsePackageQuantity(tt.input)
+		if (err != nil) != tt.expectErr {
+			t.Errorf("ParsePackageQuantity(%q) error = %v, expectErr %v", tt.input, err, tt.expectErr)
+			continue
+		}
+		if !tt.expectErr && (pkg != tt.expectedPkg || qty != tt.expectedQty) {
+			t.Errorf("ParsePackageQuantity(%q) = %q, %d, want %q, %d", tt.input, pkg, qty, tt.expectedPkg, tt.expectedQty)
+		}
+		if !tt.expectErr {
+			if again, _, _ := ParsePackageQuantity(formatPackageQuantity(pkg, qty)); again != pkg {
+				t.Errorf("formatPackageQuantity(%q, %d) does not round-trip", pkg, qty)
+			}
+		}
+	}
+}
+
+// TestExportBOM tests that identical parts are merged with summed quantities
+func TestExportBOM(t *testing.T) {
+	first := mustDecode(t, cliOptions{resistor: true, bands: "brown,black,red,gold"}, "R1")
+	second := mustDecode(t, cliOptions{resistor: true, bands: "brown,black,red,gold"}, "R2")
+	second.Quantity = 3
+	other := mustDecode(t, cliOptions{resistor: true, bands: "brown,black,red,gold"}, "R3")
+	other.Package = "0603"
+	history := []ComponentEntry{first, second, other}
+
+	path := filepath.Join(t.TempDir(), "bom.csv")
+	if err := ExportBOM(history, path); err != nil {
+		t.Fatalf("ExportBOM() error = %v", err)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := [][]string{
+		bomHeader,
+		{"1kΩ", "±5%", "", "", "4"},
+		{"1kΩ", "±5%", "", "0603", "1"},
+	}
+	if !slices.EqualFunc(records, expected, slices.Equal[[]string]) {
+		t.Errorf("BOM = %q, want %q", records, expected)
+	}
+
+	if err := ExportBOM(nil, path); err == nil {
+		t.Error("ExportBOM(nil) expected error")
+	}
+}
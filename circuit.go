@@ -0,0 +1,147 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"tropical-fish/decoder"
+)
+
+// RCTimeConstant returns the time constant τ = R·C in seconds
+// Returns 0 if either value is not positive
+func RCTimeConstant(ohms, farads float64) float64 {
+	if ohms <= 0 || farads <= 0 {
+		return 0
+	}
+	return ohms * farads
+}
+
+// RCCutoffFrequency returns the -3 dB cutoff frequency f = 1/(2πRC) in Hz of
+// a first-order RC filter
+// Returns 0 if either value is not positive
+func RCCutoffFrequency(ohms, farads float64) float64 {
+	tau := RCTimeConstant(ohms, farads)
+	if tau == 0 {
+		return 0
+	}
+	return 1 / (2 * math.Pi * tau)
+}
+
+// LatestRCPair returns the resistance in Ω and capacitance in pF of the most
+// recently decoded resistor and capacitor in history
+// Returns false unless history holds at least one of each
+func LatestRCPair(history []ComponentEntry) (ohms, pF float64, ok bool) {
+	for i := len(history) - 1; i >= 0 && (ohms == 0 || pF == 0); i-- {
+		entry := history[i]
+		switch {
+		case ohms == 0 && entry.ComponentType == decoder.ComponentResistor && entry.ResistorResult != nil:
+			ohms = entry.ResistorResult.ResistanceOhms
+		case pF == 0 && entry.ComponentType == decoder.ComponentCapacitor && entry.CapacitorResult != nil:
+			pF = entry.CapacitorResult.CapacitancePF
+		}
+	}
+	return ohms, pF, ohms > 0 && pF > 0
+}
+
+// FormatTimeConstant formats a time in seconds with auto-scaled units (ns, µs,
+// ms, s) to four significant figures, e.g. "4.7 ms"
+func FormatTimeConstant(seconds float64) string {
+	switch {
+	case seconds >= 1:
+		return formatSignificant(seconds) + " s"
+	case seconds >= 1e-3:
+		return formatSignificant(seconds*1e3) + " ms"
+	case seconds >= 1e-6:
+		return formatSignificant(seconds*1e6) + " µs"
+	default:
+		return formatSignificant(seconds*1e9) + " ns"
+	}
+}
+
+// FormatCutoffFrequency formats a computed frequency with auto-scaled units
+// (Hz, kHz, MHz) to four significant figures, e.g. "159.2 Hz". Unlike
+// FormatFrequency it rounds away the digits of an irrational result.
+func FormatCutoffFrequency(hz float64) string {
+	switch {
+	case hz >= 1e6:
+		return formatSignificant(hz/1e6) + " MHz"
+	case hz >= 1e3:
+		return formatSignificant(hz/1e3) + " kHz"
+	default:
+		return formatSignificant(hz) + " Hz"
+	}
+}
+
+// formatSignificant formats a value to four significant figures without
+// trailing zeros or an exponent
+func formatSignificant(v float64) string {
+	rounded, _ := strconv.ParseFloat(strconv.FormatFloat(v, 'g', 4, 64), 64)
+	return strconv.FormatFloat(rounded, 'f', -1, 64)
+}
+
+// VoltageDivider returns the output voltage of a divider with R1 on top and
+// R2 at the bottom, Vout = Vin·R2/(R1+R2), and the current through it. With
+// R2 = 0 Ω the output is 0 V; with both at 0 Ω the input is shorted and both
+// results are 0.
+func VoltageDivider(r1, r2, vin float64) (vout, current float64) {
+	total := r1 + r2
+	if total <= 0 {
+		return 0, 0
+	}
+	return vin * r2 / total, vin / total
+}
+
+// ParseDividerInput parses the divider screen input: the list numbers (from
+// 1) of R1 and R2 among the given resistances and the input voltage, e.g.
+// "1 2 12V", returning R1 and R2 in Ω and the voltage
+func ParseDividerInput(input string, resistances []float64) (r1, r2, vin float64, err error) {
+	fields := strings.Fields(input)
+	// Join a unit typed apart from the voltage, as in "12 V"
+	if len(fields) == 4 && strings.EqualFold(fields[3], "V") {
+		fields = append(fields[:2], fields[2]+fields[3])
+	}
+	if len(fields) != 3 {
+		return 0, 0, 0, fmt.Errorf("enter R1, R2 and the input voltage, e.g. 1 2 12V")
+	}
+
+	pick := func(field, name string) (float64, error) {
+		n, err := strconv.Atoi(field)
+		if err != nil || n < 1 || n > len(resistances) {
+			return 0, fmt.Errorf("%s must be a resistor number from 1 to %d", name, len(resistances))
+		}
+		return resistances[n-1], nil
+	}
+	if r1, err = pick(fields[0], "R1"); err != nil {
+		return 0, 0, 0, err
+	}
+	if r2, err = pick(fields[1], "R2"); err != nil {
+		return 0, 0, 0, err
+	}
+	if vin, err = decoder.ParseSIValue(fields[2], "V"); err != nil {
+		return 0, 0, 0, err
+	}
+	return r1, r2, vin, nil
+}
+
+// FormatVolts formats a voltage to four significant figures, e.g. "3.3 V"
+func FormatVolts(volts float64) string {
+	return formatSignificant(volts) + " V"
+}
+
+// FormatCurrent formats a current with auto-scaled units (A, mA, µA, nA) to
+// four significant figures; a tiny current from large resistances stays in
+// nA, e.g. "0.012 nA"
+func FormatCurrent(amps float64) string {
+	switch magnitude := math.Abs(amps); {
+	case magnitude >= 1:
+		return formatSignificant(amps) + " A"
+	case magnitude >= 1e-3:
+		return formatSignificant(amps*1e3) + " mA"
+	case magnitude >= 1e-6:
+		return formatSignificant(amps*1e6) + " µA"
+	default:
+		return formatSignificant(amps*1e9) + " nA"
+	}
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"testing"
+
+	"tropical-fish/decoder"
+)
+
+// TestRCFilter tests the time constant and cutoff frequency with their
+// formatting
+func TestRCFilter(t *testing.T) {
+	tests := []struct {
+		name       string
+		ohms       float64
+		pF         float64
+		wantTau    string
+		wantCutoff string
+	}{
+		{"10k and 100n", 10000, 100000, "1 ms", "159.2 Hz"},
+		{"1k and 1n", 1000, 1000, "1 µs", "159.2 kHz"},
+		{"47 and 10p", 47, 10, "0.47 ns", "338.6 MHz"},
+		{"1M and 10µ", 1e6, 1e7, "10 s", "0.01592 Hz"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			farads := tt.pF * 1e-12
+			if got := FormatTimeConstant(RCTimeConstant(tt.ohms, farads)); got != tt.wantTau {
+				t.Errorf("τ = %q, want %q", got, tt.wantTau)
+			}
+			if got := FormatCutoffFrequency(RCCutoffFrequency(tt.ohms, farads)); got != tt.wantCutoff {
+				t.Errorf("cutoff = %q, want %q", got, tt.wantCutoff)
+			}
+		})
+	}
+
+	if got := RCCutoffFrequency(0, 1e-9); got != 0 {
+		t.Errorf("RCCutoffFrequency(0, 1n) = %v, want 0", got)
+	}
+}
+
+// TestLatestRCPair tests that the newest resistor and capacitor are paired
+func TestLatestRCPair(t *testing.T) {
+	history := []ComponentEntry{
+		mustDecode(t, cliOptions{resistor: true, bands: "brown,black,red,gold"}, ""),
+		mustDecode(t, cliOptions{capType: "K", bands: "brown,black,orange"}, ""),
+		mustDecode(t, cliOptions{resistor: true, bands: "brown,black,orange,gold"}, ""),
+	}
+
+	if _, _, ok := LatestRCPair(history[:1]); ok {
+		t.Errorf("LatestRCPair() with only a resistor ok = true, want false")
+	}
+	ohms, pF, ok := LatestRCPair(history)
+	if !ok || ohms != 10000 || pF != 10000 {
+		t.Errorf("LatestRCPair() = %v Ω, %v pF, %v, want 10 kΩ, 10 nF", ohms, pF, ok)
+	}
+}
+
+// TestVoltageDivider tests the output voltage and current, including R2 = 0
+// and resistances large enough to leave only nanoamps
+func TestVoltageDivider(t *testing.T) {
+	tests := []struct {
+		name        string
+		r1, r2, vin float64
+		wantVout    string
+		wantCurrent string
+	}{
+		{"equal halves", 10000, 10000, 12, "6 V", "600 µA"},
+		{"3.3 from 5", 1000, 2000, 5, "3.333 V", "1.667 mA"},
+		{"R2 shorted", 1000, 0, 5, "0 V", "5 mA"},
+		{"both shorted", 0, 0, 5, "0 V", "0 nA"},
+		{"gigaohms", 1e9, 1e9, 1, "0.5 V", "0.5 nA"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vout, current := VoltageDivider(tt.r1, tt.r2, tt.vin)
+			if got := FormatVolts(vout); got != tt.wantVout {
+				t.Errorf("Vout = %q, want %q", got, tt.wantVout)
+			}
+			if got := FormatCurrent(current); got != tt.wantCurrent {
+				t.Errorf("current = %q, want %q", got, tt.wantCurrent)
+			}
+		})
+	}
+}
+
+// TestParseDividerInput tests picking R1 and R2 by number with a voltage
+func TestParseDividerInput(t *testing.T) {
+	resistances := []float64{1000, 2200, 4700}
+	tests := []struct {
+		input                   string
+		wantR1, wantR2, wantVin float64
+		wantErr                 bool
+	}{
+		{"1 2 12V", 1000, 2200, 12, false},
+		{"3 1 3.3 v", 4700, 1000, 3.3, false},
+		{"1 2 500mV", 1000, 2200, 0.5, false},
+		{"1 4 5V", 0, 0, 0, true},
+		{"1 2", 0, 0, 0, true},
+		{"a 2 5V", 0, 0, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			r1, r2, vin, err := ParseDividerInput(tt.input, resistances)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseDividerInput(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if r1 != tt.wantR1 || r2 != tt.wantR2 || !decoder.ApproxEqual(vin, tt.wantVin) {
+				t.Errorf("ParseDividerInput(%q) = %v, %v, %v, want %v, %v, %v", tt.input, r1, r2, vin, tt.wantR1, tt.wantR2, tt.wantVin)
+			}
+		})
+	}
+}
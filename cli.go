@@ -0,0 +1,217 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	"tropical-fish/decoder"
+)
+
+// cliOptions holds the command-line flags
+type cliOptions struct {
+	resistor    bool   // Decode a resistor from --bands
+	capacitor   bool   // Decode a capacitor from --type and --bands
+	capType     string // Capacitor type letter or name
+	bands       string // Comma-separated band colors
+	print       bool   // Print the rendered results box instead of plain text
+	compact     bool   // Use the one-line result instead of the results box
+	noColor     bool   // Disable ANSI colors
+	noAltScreen bool   // Run the TUI inline instead of in the alternate screen
+	noMouse     bool   // Leave the mouse to the terminal for text selection
+	lang        string // Color name language (en, de, fr, es)
+	extended    bool   // Accept the extended resistor colors (Pink)
+	in          string // Batch input CSV of band specs
+	out         string // Batch results CSV (stdout if empty)
+	batch       string // Compact component lines to decode ("-" for stdin)
+	chart       string // Write the reference chart to this file and exit
+
+	palette decoder.Palette // Color set and name language, from lang and extended
+
+	importFile       string // CSV export to load into history at startup
+	importDedup      bool   // Skip imported entries already in history
+	importMergeNotes bool   // Merge notes of imported entries that differ only by note
+
+	historyLimit int    // Maximum history entries kept in the TUI (0 = unlimited)
+	configPath   string // Config file to load instead of the default location
+
+	selfCheck   bool // Verify the reference tables and exit (hidden)
+	showVersion bool // Print the build info and exit
+}
+
+// hiddenFlags are accepted but left out of the usage message
+var hiddenFlags = map[string]bool{
+	"selfcheck": true,
+}
+
+// decodeRequested reports whether any decode flag was given
+func (o cliOptions) decodeRequested() bool {
+	return o.resistor || o.capacitor || o.bands != ""
+}
+
+// parseCLIFlags parses command-line arguments into cliOptions
+func parseCLIFlags(args []string, stderr io.Writer) (cliOptions, error) {
+	var opts cliOptions
+
+	fs := flag.NewFlagSet("tropical-fish", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.BoolVar(&opts.resistor, "resistor", false, "decode a resistor from --bands")
+	fs.BoolVar(&opts.capacitor, "capacitor", false, "decode a capacitor from --type and --bands")
+	fs.StringVar(&opts.capType, "type", "", "capacitor type (J, K, L, M, N or a name such as mica)")
+	fs.StringVar(&opts.bands, "bands", "", "comma-separated band colors, e.g. brown,black,red,gold")
+	fs.BoolVar(&opts.print, "print", false, "print the rendered results box instead of plain text when decoding from flags")
+	fs.BoolVar(&opts.noColor, "no-color", false, "disable colored output")
+	fs.BoolVar(&opts.noAltScreen, "no-altscreen", false, "run the TUI inline instead of in the alternate screen (automatic when stdout is not a terminal)")
+	fs.BoolVar(&opts.noMouse, "no-mouse", false, "don't capture the mouse, leaving clicks to the terminal for selecting text")
+	fs.BoolVar(&opts.compact, "compact", false, "show results on a single line (with -print, or as the TUI default)")
+	fs.StringVar(&opts.lang, "lang", "en", "language for color names: en, de, fr, or es (English names are always accepted)")
+	fs.BoolVar(&opts.extended, "extended-colors", false, "accept the extended resistor color set: Pink as a ×0.001 multiplier")
+	fs.StringVar(&opts.in, "in", "", "decode every row of a CSV (or tab/pipe-separated file, - for stdin) with type, capType and bands columns")
+	fs.StringVar(&opts.batch, "batch", "", "decode lines such as R,4,brown,black,red,gold or C,K,5,red,violet,orange,brown,orange from a file (- for stdin)")
+	fs.StringVar(&opts.out, "out", "", "write -in or -batch results to this CSV file instead of stdout")
+	fs.StringVar(&opts.chart, "chart", "", "write the color code reference chart to this file (.csv, .html, or text) and exit")
+	fs.StringVar(&opts.importFile, "import", "", "load a previously exported CSV into history at startup")
+	fs.BoolVar(&opts.importDedup, "import-dedup", true, "skip imported entries with the same bands, value and note")
+	fs.BoolVar(&opts.importMergeNotes, "import-merge-notes", false, "merge notes into existing entries with the same bands and value")
+	fs.IntVar(&opts.historyLimit, "history-limit", 0, "keep at most N decoded components in history, dropping the oldest (0 = unlimited)")
+	fs.StringVar(&opts.configPath, "config", "", "config file with key bindings (default ~/.config/tropical-fish/config.json)")
+
+	fs.BoolVar(&opts.showVersion, "version", false, "print the version and build info and exit")
+	fs.BoolVar(&opts.selfCheck, "selfcheck", false, "verify the reference tables and exit")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage of %s:\n", fs.Name())
+		fs.VisitAll(func(f *flag.Flag) {
+			if hiddenFlags[f.Name] {
+				return
+			}
+			fmt.Fprintf(fs.Output(), "  -%s\n    \t%s\n", f.Name, f.Usage)
+		})
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return opts, err
+	}
+
+	palette, err := decoder.NewPalette(opts.lang, opts.extended)
+	if err != nil {
+		return opts, err
+	}
+	opts.palette = palette
+	if opts.historyLimit < 0 {
+		return opts, fmt.Errorf("--history-limit must not be negative")
+	}
+	if opts.out != "" && opts.in == "" && opts.batch == "" {
+		return opts, fmt.Errorf("-out requires -in or -batch")
+	}
+	if opts.batch != "" && (opts.in != "" || opts.chart != "" || opts.decodeRequested() || opts.print) {
+		return opts, fmt.Errorf("-batch cannot be combined with -in, -chart or decode flags")
+	}
+	if opts.chart != "" && (opts.in != "" || opts.decodeRequested() || opts.print) {
+		return opts, fmt.Errorf("-chart cannot be combined with -in or decode flags")
+	}
+	if opts.in != "" && (opts.decodeRequested() || opts.print) {
+		return opts, fmt.Errorf("-in cannot be combined with single-component decode flags")
+	}
+	if opts.resistor && opts.capacitor {
+		return opts, fmt.Errorf("--resistor and --capacitor are mutually exclusive")
+	}
+	if opts.decodeRequested() && !opts.resistor && !opts.capacitor {
+		return opts, fmt.Errorf("--bands requires --resistor or --capacitor")
+	}
+	if opts.print && !opts.decodeRequested() {
+		return opts, fmt.Errorf("-print requires --resistor or --capacitor with --bands")
+	}
+
+	return opts, nil
+}
+
+// decodeFromFlags decodes the component described by the CLI flags
+func decodeFromFlags(opts cliOptions) (ComponentEntry, error) {
+	colors, err := opts.palette.ParseBandSequence(opts.bands)
+	if err != nil {
+		return ComponentEntry{}, err
+	}
+
+	if opts.resistor {
+		reading, err := decoder.ResistorReadingFromColors(colors)
+		if err != nil {
+			return ComponentEntry{}, err
+		}
+		if err := decoder.ValidateResistorReading(&reading); err != nil {
+			return ComponentEntry{}, err
+		}
+		result, err := decoder.CalculateResistor(reading)
+		if err != nil {
+			return ComponentEntry{}, err
+		}
+		return ComponentEntry{ComponentType: decoder.ComponentResistor, ResistorResult: result}, nil
+	}
+
+	capType, ok := decoder.ParseCapacitorType(opts.capType)
+	if !ok {
+		return ComponentEntry{}, errors.New("invalid or missing --type (must be J, K, L, M, N or a type name)")
+	}
+	reading, err := decoder.CapacitorReadingFromColors(capType, colors)
+	if err != nil {
+		return ComponentEntry{}, err
+	}
+	if err := decoder.ValidateReading(&reading); err != nil {
+		return ComponentEntry{}, err
+	}
+	result, err := decoder.Calculate(reading)
+	if err != nil {
+		return ComponentEntry{}, err
+	}
+	return ComponentEntry{ComponentType: decoder.ComponentCapacitor, CapacitorResult: result}, nil
+}
+
+// runDecode decodes the component from the CLI flags, prints the result as
+// plain text (or the results box with -print, one line with -compact), and
+// returns the process exit code
+func runDecode(opts cliOptions, stdout, stderr io.Writer) int {
+	entry, err := decodeFromFlags(opts)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	switch {
+	case opts.compact:
+		fmt.Fprintln(stdout, RenderCompactResult(entry))
+	case opts.print:
+		fmt.Fprint(stdout, RenderResultsBox(entry.CapacitorResult, entry.ResistorResult, ResultsView{}))
+	default:
+		fmt.Fprint(stdout, RenderPlainResult(entry, opts.palette))
+	}
+	return 0
+}
+
+// runSelfCheck verifies the reference tables, prints a pass/fail summary, and
+// returns the process exit code
+func runSelfCheck(stdout io.Writer) int {
+	errs := decoder.VerifyTables()
+	if len(errs) == 0 {
+		fmt.Fprintln(stdout, "✓ Self-check passed: all reference tables are consistent")
+		return 0
+	}
+
+	for _, err := range errs {
+		fmt.Fprintf(stdout, "✗ %v\n", err)
+	}
+	fmt.Fprintf(stdout, "Self-check failed: %d problem(s)\n", len(errs))
+	return 1
+}
+
+// runChartExport writes the reference chart to opts.chart in the format given
+// by the file extension and returns the process exit code
+func runChartExport(opts cliOptions, stdout, stderr io.Writer) int {
+	path := opts.chart
+	format := ReferenceFormatFromPath(path)
+	if err := ExportReferenceChart(format, path, opts.palette); err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	fmt.Fprintf(stdout, "✓ Wrote %s reference chart to %s\n", format, path)
+	return 0
+}
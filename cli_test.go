@@ -0,0 +1,170 @@
+package main
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestParseCLIFlags tests flag combinations for non-interactive decoding
+func TestParseCLIFlags(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      []string
+		shouldErr bool
+		decode    bool
+	}{
+		{"No flags starts TUI", nil, false, false},
+		{"Resistor print", []string{"-print", "--resistor", "--bands", "brown,black,red,gold"}, false, true},
+		{"Capacitor print", []string{"-print", "--capacitor", "--type", "K", "--bands", "red,violet,orange"}, false, true},
+		{"Both component flags", []string{"-print", "--resistor", "--capacitor", "--bands", "red"}, true, false},
+		{"Bands without component", []string{"-print", "--bands", "red"}, true, false},
+		{"Print without decode", []string{"-print"}, true, false},
+		{"Resistor plain text", []string{"--resistor", "--bands", "brown,black,red,gold"}, false, true},
+		{"Capacitor plain text", []string{"--capacitor", "--type", "K", "--bands", "red,violet,orange"}, false, true},
+		{"Batch in and out", []string{"-in", "parts.csv", "-out", "results.csv"}, false, false},
+		{"Out without in", []string{"-out", "results.csv"}, true, false},
+		{"Compact batch", []string{"-batch", "parts.txt", "-out", "results.csv"}, false, false},
+		{"Compact batch with in", []string{"-batch", "-", "-in", "parts.csv"}, true, false},
+		{"Compact batch with decode flags", []string{"-batch", "-", "--resistor", "--bands", "red"}, true, true},
+		{"Batch with decode flags", []string{"-in", "parts.csv", "-print", "--resistor", "--bands", "red"}, true, true},
+		{"History limit", []string{"-history-limit", "50"}, false, false},
+		{"Negative history limit", []string{"-history-limit", "-1"}, true, false},
+		{"No alt screen", []string{"-no-altscreen"}, false, false},
+		{"Version", []string{"-version"}, false, false},
+		{"Reference chart", []string{"-chart", "chart.html"}, false, false},
+		{"Chart with batch", []string{"-chart", "chart.html", "-in", "parts.csv"}, true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, err := parseCLIFlags(tt.args, io.Discard)
+			if (err != nil) != tt.shouldErr {
+				t.Fatalf("parseCLIFlags() error = %v, shouldErr = %v", err, tt.shouldErr)
+			}
+			if err == nil && opts.decodeRequested() != tt.decode {
+				t.Errorf("decodeRequested() = %v, want %v", opts.decodeRequested(), tt.decode)
+			}
+		})
+	}
+}
+
+// TestDecodeFromFlags tests decoding components described by flags
+func TestDecodeFromFlags(t *testing.T) {
+	entry, err := decodeFromFlags(cliOptions{resistor: true, bands: "brown,black,red,gold"})
+	if err != nil {
+		t.Fatalf("decodeFromFlags() error = %v", err)
+	}
+	if entry.ResistorResult.ResistanceOhms != 1000 {
+		t.Errorf("ResistanceOhms = %v, want 1000", entry.ResistorResult.ResistanceOhms)
+	}
+
+	entry, err = decodeFromFlags(cliOptions{capacitor: true, capType: "mica", bands: "red violet orange brown orange"})
+	if err != nil {
+		t.Fatalf("decodeFromFlags() error = %v", err)
+	}
+	if entry.CapacitorResult.CapacitancePF != 27000 {
+		t.Errorf("CapacitancePF = %v, want 27000", entry.CapacitorResult.CapacitancePF)
+	}
+
+	if _, err := decodeFromFlags(cliOptions{resistor: true, bands: "gold,black,red,gold"}); err == nil {
+		t.Error("decodeFromFlags() with Gold first digit error = nil, want error")
+	}
+}
+
+// TestRunDecode tests the output formats and exit codes of flag decoding
+func TestRunDecode(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     cliOptions
+		wantCode int
+		want     string // Expected in stdout, or in stderr on failure
+	}{
+		{"Plain text", cliOptions{resistor: true, bands: "brown,black,red,gold"}, 0, "Resistance: 1.000 kΩ\n"},
+		{"Compact", cliOptions{resistor: true, bands: "brown,black,red,gold", compact: true}, 0, "R 1kΩ ±5%"},
+		{"Results box", cliOptions{resistor: true, bands: "brown,black,red,gold", print: true}, 0, "RESISTANCE VALUE:"},
+		{"Invalid band", cliOptions{resistor: true, bands: "gold,black,red,gold"}, 1, "Error: "},
+		{"Missing type", cliOptions{capacitor: true, bands: "red,violet,orange"}, 1, "--type"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr strings.Builder
+			code := runDecode(tt.opts, &stdout, &stderr)
+			if code != tt.wantCode {
+				t.Fatalf("runDecode() = %d, want %d (stderr %q)", code, tt.wantCode, stderr.String())
+			}
+			output := stdout.String()
+			if tt.wantCode != 0 {
+				output = stderr.String()
+			}
+			if !strings.Contains(output, tt.want) {
+				t.Errorf("output = %q, want it to contain %q", output, tt.want)
+			}
+		})
+	}
+}
+
+// TestRunDecodeFourBandCapacitor tests that 4-band capacitors decode from
+// flags with no voltage, as the voltage band is the fifth
+func TestRunDecodeFourBandCapacitor(t *testing.T) {
+	for _, capType := range []string{"K", "M"} {
+		t.Run("Type "+capType, func(t *testing.T) {
+			var stdout, stderr strings.Builder
+			opts := cliOptions{capacitor: true, capType: capType, bands: "red,violet,orange,brown"}
+			if code := runDecode(opts, &stdout, &stderr); code != 0 {
+				t.Fatalf("runDecode() = %d, want 0 (stderr %q)", code, stderr.String())
+			}
+			output := stdout.String()
+			if !strings.Contains(output, "Capacitance: 27.00 nF\n") || !strings.Contains(output, "Tolerance: ±1%\n") {
+				t.Errorf("output = %q, want 27 nF ±1%%", output)
+			}
+			if strings.Contains(output, "Voltage") {
+				t.Errorf("output = %q, want no voltage for 4 bands", output)
+			}
+		})
+	}
+}
+
+// TestUseAltScreen tests the alternate screen is skipped when disabled or not on a terminal
+func TestUseAltScreen(t *testing.T) {
+	file, err := os.Create(filepath.Join(t.TempDir(), "out.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	if useAltScreen(false, file) {
+		t.Error("useAltScreen() = true for a regular file, want false")
+	}
+
+	// /dev/null is a character device, like a terminal
+	devNull, err := os.Open(os.DevNull)
+	if err != nil {
+		t.Skip("no null device")
+	}
+	defer devNull.Close()
+	if !useAltScreen(false, devNull) {
+		t.Error("useAltScreen() = false for a character device, want true")
+	}
+	if useAltScreen(true, devNull) {
+		t.Error("useAltScreen() = true with -no-altscreen, want false")
+	}
+}
+
+// TestBuildInfo tests that the version output includes the ldflags values
+func TestBuildInfo(t *testing.T) {
+	oldVersion, oldDate := version, buildDate
+	t.Cleanup(func() { version, buildDate = oldVersion, oldDate })
+	version, buildDate = "v1.2.0", "2025-01-31"
+
+	info := CurrentBuildInfo()
+	if info.Version != "v1.2.0" || info.BuildDate != "2025-01-31" || info.GoVersion == "" {
+		t.Errorf("CurrentBuildInfo() = %+v", info)
+	}
+	if got := info.String(); !strings.Contains(got, "tropical-fish v1.2.0") {
+		t.Errorf("String() = %q, want it to contain the version", got)
+	}
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// clipboardCommands are the system clipboard tools tried in order
+var clipboardCommands = [][]string{
+	{"pbcopy"},
+	{"wl-copy"},
+	{"xclip", "-selection", "clipboard"},
+	{"xsel", "--clipboard", "--input"},
+	{"clip.exe"},
+}
+
+// errNoClipboard is returned when no clipboard tool is installed
+var errNoClipboard = errors.New("no clipboard available (install pbcopy, wl-copy, xclip or xsel)")
+
+// clipboardResultMsg reports the outcome of a clipboard copy
+type clipboardResultMsg struct {
+	err   error
+	what  string // What was copied, e.g. "BOM row"
+	osc52 string // OSC 52 sequence to write instead, when no tool worked
+}
+
+// copyToClipboard copies text with the first clipboard tool that succeeds,
+// moving on when one is installed but fails (e.g. xclip without a display)
+// Returns errNoClipboard when none is installed, or every tool's error
+func copyToClipboard(text string) error {
+	var errs []error
+	for _, args := range clipboardCommands {
+		path, err := exec.LookPath(args[0])
+		if err != nil {
+			continue
+		}
+		cmd := exec.Command(path, args[1:]...)
+		cmd.Stdin = strings.NewReader(text)
+		if err := cmd.Run(); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", args[0], err))
+			continue
+		}
+		return nil
+	}
+
+	if len(errs) == 0 {
+		return errNoClipboard
+	}
+	return errors.Join(errs...)
+}
+
+// osc52Sequence returns the OSC 52 terminal escape that sets the system
+// clipboard to text, which also works over SSH
+func osc52Sequence(text string) string {
+	return "\x1b]52;c;" + base64.StdEncoding.EncodeToString([]byte(text)) + "\x07"
+}
+
+// copyCmd copies text to the clipboard off the UI goroutine. Without a
+// working tool it falls back to OSC 52 on a terminal, leaving the write to
+// the renderer so it goes through the program's output.
+func copyCmd(text, what string) tea.Cmd {
+	return func() tea.Msg {
+		msg := clipboardResultMsg{what: what}
+		if err := copyToClipboard(text); err != nil {
+			if isTerminal(os.Stdout) {
+				msg.osc52 = osc52Sequence(text)
+			} else {
+				msg.err = err
+			}
+		}
+		return msg
+	}
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"errors"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+// TestCopyToClipboardTriesEachTool tests that a failing tool falls through
+// to the next one and that the errors are reported when none works
+func TestCopyToClipboardTriesEachTool(t *testing.T) {
+	for _, tool := range []string{"true", "false"} {
+		if _, err := exec.LookPath(tool); err != nil {
+			t.Skipf("%s not found", tool)
+		}
+	}
+	saved := clipboardCommands
+	defer func() { clipboardCommands = saved }()
+
+	clipboardCommands = [][]string{{"tropical-fish-no-such-tool"}, {"false"}, {"true"}}
+	if err := copyToClipboard("4.7kΩ"); err != nil {
+		t.Errorf("copyToClipboard() after a failing tool error = %v, want nil", err)
+	}
+
+	clipboardCommands = [][]string{{"false"}}
+	if err := copyToClipboard("4.7kΩ"); err == nil || !strings.Contains(err.Error(), "false") {
+		t.Errorf("copyToClipboard() with only a failing tool error = %v, want its error", err)
+	}
+
+	clipboardCommands = [][]string{{"tropical-fish-no-such-tool"}}
+	if err := copyToClipboard("4.7kΩ"); !errors.Is(err, errNoClipboard) {
+		t.Errorf("copyToClipboard() with no tool error = %v, want errNoClipboard", err)
+	}
+}
+
+// TestClipboardOSC52 tests that the OSC 52 fallback goes out with the next
+// frame, isn't reported as a confirmed copy and is cleared by the next key
+func TestClipboardOSC52(t *testing.T) {
+	m := initialModel()
+	m.screen = screenResults
+	sequence := osc52Sequence("hi")
+	if sequence != "\x1b]52;c;aGk=\x07" {
+		t.Errorf("osc52Sequence(hi) = %q", sequence)
+	}
+
+	updated, _ := m.Update(clipboardResultMsg{what: "result", osc52: sequence})
+	m = updated.(model)
+	if !strings.HasPrefix(m.View(), sequence) {
+		t.Error("View() does not lead with the OSC 52 sequence")
+	}
+	if strings.Contains(m.successMsg, "Copied") {
+		t.Errorf("successMsg = %q, want no claim that the copy worked", m.successMsg)
+	}
+
+	m = pressKeys(m, "u")
+	if strings.Contains(m.View(), sequence) {
+		t.Error("View() still has the OSC 52 sequence after the next key")
+	}
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// Config holds user settings read from the config file
+type Config struct {
+	Keymap       map[string][]string `json:"keymap"` // Action name → keys, e.g. "export": ["s"]
+	Plausibility PlausibilityConfig  `json:"plausibility"`
+	Preferences  Preferences         `json:"preferences"` // Saved on quit
+}
+
+// Preferences are the last session's choices, restored at startup. Zero or
+// out-of-range values keep the built-in defaults.
+type Preferences struct {
+	ComponentType  string `json:"component_type"`  // "capacitor" or "resistor"
+	CapacitorBands int    `json:"capacitor_bands"` // 3-5, default 5
+	ResistorBands  int    `json:"resistor_bands"`  // 4-6, default 4
+	ExportDir      string `json:"export_dir"`      // File picker start, default the home directory
+}
+
+// PlausibilityConfig adjusts the advisory checks on decoded values
+type PlausibilityConfig struct {
+	Enabled *bool                 `json:"enabled"` // Defaults to true
+	Ranges  map[string][2]float64 `json:"ranges"`  // Type letter (pF) or "resistor" (Ω) → [min, max]
+}
+
+// DefaultConfigPath returns the config file location, e.g.
+// ~/.config/tropical-fish/config.json on Linux
+func DefaultConfigPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "tropical-fish", "config.json"), nil
+}
+
+// LoadConfig reads a JSON config file
+func LoadConfig(path string) (Config, error) {
+	var cfg Config
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cfg, err
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// LoadPreferences reads the preferences section of a config file
+// A missing or unreadable file gives empty preferences, i.e. the defaults
+func LoadPreferences(path string) Preferences {
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		return Preferences{}
+	}
+	return cfg.Preferences
+}
+
+// SavePreferences writes the preferences section of a config file, keeping
+// its other sections as they are. A file that isn't valid JSON is left alone
+// rather than overwritten.
+func SavePreferences(path string, prefs Preferences) error {
+	sections := map[string]json.RawMessage{}
+	data, err := os.ReadFile(path)
+	if err == nil {
+		if err := json.Unmarshal(data, &sections); err != nil {
+			return fmt.Errorf("invalid config file %s: %w", path, err)
+		}
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	encoded, err := json.Marshal(prefs)
+	if err != nil {
+		return err
+	}
+	sections["preferences"] = encoded
+
+	data, err = json.MarshalIndent(sections, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
@@ -0,0 +1,508 @@
+package decoder
+
+import (
+	"fmt"
+	"math"
+	"slices"
+)
+
+// CapacitorReading represents the parsed bands from user input.
+//
+// Band roles by count:
+//
+//	3 bands: digit, digit, multiplier (no tolerance band, ±20% implied)
+//	4 bands: digit, digit, multiplier, tolerance
+//	5 bands: digit, digit, multiplier, tolerance, voltage
+//
+// On Type K (mica) parts the fifth band is also the temperature coefficient;
+// tantalum, film and electrolytic types only mark a voltage there.
+type CapacitorReading struct {
+	Band1     Color         // First digit
+	Band2     Color         // Second digit
+	Band3     Color         // Multiplier
+	Band4     Color         // Tolerance (unused for 3 bands)
+	Band5     Color         // Voltage rating and Type K temp coefficient (5 bands only)
+	BandCount int           // 3, 4, or 5 bands
+	CapType   CapacitorType // J, K, L, M, or N
+}
+
+// CapacitorReadingFromColors builds a reading from bands listed in order
+func CapacitorReadingFromColors(capType CapacitorType, colors []Color) (CapacitorReading, error) {
+	if err := ValidateBandCount(len(colors)); err != nil {
+		return CapacitorReading{}, err
+	}
+
+	reading := CapacitorReading{
+		Band1:     colors[0],
+		Band2:     colors[1],
+		Band3:     colors[2],
+		BandCount: len(colors),
+		CapType:   capType,
+	}
+	if len(colors) >= 4 {
+		reading.Band4 = colors[3]
+	}
+	if len(colors) == 5 {
+		reading.Band5 = colors[4]
+	}
+	return reading, nil
+}
+
+// Equal reports whether two readings decode the same part: same type, band
+// count and colors. Bands beyond the band count are ignored.
+func (r CapacitorReading) Equal(other CapacitorReading) bool {
+	if r.BandCount != other.BandCount || r.CapType != other.CapType ||
+		r.Band1 != other.Band1 || r.Band2 != other.Band2 || r.Band3 != other.Band3 {
+		return false
+	}
+	if r.BandCount >= 4 && r.Band4 != other.Band4 {
+		return false
+	}
+	// Only 5-band readings have a voltage band
+	if r.BandCount == 5 && r.Band5 != other.Band5 {
+		return false
+	}
+	return true
+}
+
+// Colors returns the bands of the reading in order, up to its band count
+func (r CapacitorReading) Colors() []Color {
+	colors := []Color{r.Band1, r.Band2, r.Band3, r.Band4, r.Band5}
+	return colors[:min(max(r.BandCount, 0), len(colors))]
+}
+
+// ApproxEqual reports whether two computed values are equal to within
+// floating point noise
+func ApproxEqual(a, b float64) bool {
+	if a == b {
+		return true
+	}
+	return math.Abs(a-b) <= 1e-9*math.Max(math.Abs(a), math.Abs(b))
+}
+
+// ReverseReading returns the reading with its bands in the opposite order,
+// for a capacitor that was read from the wrong end
+func ReverseReading(reading CapacitorReading) (CapacitorReading, error) {
+	colors := []Color{reading.Band1, reading.Band2, reading.Band3, reading.Band4, reading.Band5}
+	if reading.BandCount < 1 || reading.BandCount > len(colors) {
+		return reading, fmt.Errorf("invalid band count: %d", reading.BandCount)
+	}
+	colors = colors[:reading.BandCount]
+	slices.Reverse(colors)
+	return CapacitorReadingFromColors(reading.CapType, colors)
+}
+
+// CapacitorBandsFromValue returns a reading whose first three bands encode a
+// capacitance in pF, see BandsFromCapacitance. Band 4 of a 4- or 5-band
+// reading is set to the implied ±20% and band 5 of a 5-band reading to the
+// type's first voltage code (Black where the type has one), since tolerance
+// and voltage are not part of the value.
+// Returns an error if the value needs three significant figures or is outside
+// the multiplier range
+func CapacitorBandsFromValue(pF float64, capType CapacitorType, bandCount int) (CapacitorReading, error) {
+	if err := ValidateCapacitorType(capType); err != nil {
+		return CapacitorReading{}, err
+	}
+	if err := ValidateBandCount(bandCount); err != nil {
+		return CapacitorReading{}, err
+	}
+	bands, err := BandsFromCapacitance(pF)
+	if err != nil {
+		return CapacitorReading{}, err
+	}
+
+	reading := CapacitorReading{
+		Band1:     bands[0],
+		Band2:     bands[1],
+		Band3:     bands[2],
+		BandCount: bandCount,
+		CapType:   capType,
+	}
+	if bandCount >= 4 {
+		reading.Band4 = ColorBlack // ±20%, as implied for 3 bands
+	}
+	if bandCount == 5 {
+		// Black is not a voltage code on every type, e.g. Type M
+		voltages := ValidVoltageColorsForType(capType)
+		reading.Band5 = voltages[0]
+		if slices.Contains(voltages, ColorBlack) {
+			reading.Band5 = ColorBlack
+		}
+	}
+	return reading, nil
+}
+
+// CalculationResult contains all calculated values
+type CalculationResult struct {
+	// Capacitance
+	CapacitancePF    float64 // Raw value in picofarads
+	CapacitanceValue float64 // Scaled value
+	CapacitanceUnit  string  // pF, nF, µF, or mF
+
+	// Tolerance
+	ToleranceType       string  // "percentage" or "absolute"
+	TolerancePercent    float64 // For percentage-based
+	ToleranceAbsolutePF float64 // For absolute (small caps)
+	ToleranceSymmetric  bool    // True for symmetric tolerance
+	ToleranceHigh       float64 // +% for asymmetric (e.g., Grey)
+	ToleranceLow        float64 // -% for asymmetric
+	ToleranceImplied    bool    // No tolerance band (3-band), ±20% assumed
+
+	// Range (min/max values)
+	MinValue float64
+	MaxValue float64
+	MinUnit  string
+	MaxUnit  string
+
+	// Voltage
+	VoltageRating float64
+	VoltageValid  bool
+
+	// Temperature Coefficient
+	TempCoefficient int
+	TempCoeffValid  bool
+
+	// Preferred value check (see preferredValueNote)
+	Plausible        bool   // Near a standard E24 value
+	PlausibilityNote string // Why the value looks like a misread, if not plausible
+
+	// Original reading
+	Reading CapacitorReading
+}
+
+// Calculate performs all calculations for a capacitor reading
+func Calculate(reading CapacitorReading) (*CalculationResult, error) {
+	result := &CalculationResult{
+		Reading: reading,
+	}
+
+	// Step 1: Calculate capacitance in pF
+	// Formula: (Band1 × 10 + Band2) × Multiplier
+	info1 := GetColorInfo(reading.Band1)
+	info2 := GetColorInfo(reading.Band2)
+	info3 := GetColorInfo(reading.Band3)
+
+	if !info1.ValidDigit || !info2.ValidDigit {
+		return nil, fmt.Errorf("invalid digit colors for bands 1 or 2")
+	}
+
+	if !info3.ValidMult {
+		return nil, fmt.Errorf("invalid multiplier color for band 3")
+	}
+
+	baseValue := float64(info1.Digit*10 + info2.Digit)
+	result.CapacitancePF = baseValue * info3.Multiplier
+
+	// Step 2: Auto-scale units (pF → nF → µF → mF)
+	result.CapacitanceValue, result.CapacitanceUnit = ScaleCapacitance(result.CapacitancePF)
+
+	// Step 3: Calculate tolerance
+	if err := calculateTolerance(result); err != nil {
+		return nil, err
+	}
+
+	// Step 4: Get voltage rating (5-band only)
+	// Black is a meaningful voltage code for some types, so always look it up
+	// and rely on the validity flag
+	if reading.BandCount == 5 {
+		voltage, valid := GetVoltageRatingFractional(reading.CapType, reading.Band5)
+		result.VoltageRating = voltage
+		result.VoltageValid = valid
+	}
+
+	// Step 5: Get temperature coefficient (5-band types that mark one)
+	if reading.BandCount == 5 && HasTempCoeffBand(reading.CapType) {
+		coeff, valid := GetTempCoefficient(reading.Band5)
+		result.TempCoefficient = coeff
+		result.TempCoeffValid = valid
+	}
+
+	result.PlausibilityNote = preferredValueNote(result.CapacitancePF, false, FormatCapacitanceValue)
+	result.Plausible = result.PlausibilityNote == ""
+
+	return result, nil
+}
+
+// ScaleCapacitance converts pF to the most appropriate unit
+// Returns value and unit as separate values
+func ScaleCapacitance(pF float64) (float64, string) {
+	// Conversion factors
+	const (
+		pFToNF = 1000.0       // 1 nF = 1000 pF
+		pFToUF = 1000000.0    // 1 µF = 1,000,000 pF
+		pFToMF = 1000000000.0 // 1 mF = 1,000,000,000 pF
+	)
+
+	// Try mF (millifarads)
+	if pF >= pFToMF {
+		return pF / pFToMF, "mF"
+	}
+
+	// Try µF (microfarads)
+	if pF >= pFToUF {
+		return pF / pFToUF, "µF"
+	}
+
+	// Try nF (nanofarads)
+	if pF >= pFToNF {
+		return pF / pFToNF, "nF"
+	}
+
+	// Use pF (picofarads)
+	return pF, "pF"
+}
+
+// impliedTolerance is the tolerance of a 3-band capacitor, which has no
+// tolerance band
+var impliedTolerance = ToleranceInfo{PercentHigh: 20, PercentLow: 20, Symmetric: true}
+
+// readingTolerance returns the tolerance of a reading: the implied ±20% for
+// 3 bands, otherwise that of the band 4 color
+func readingTolerance(reading CapacitorReading) (ToleranceInfo, bool) {
+	if reading.BandCount == 3 {
+		return impliedTolerance, true
+	}
+	return GetToleranceInfo(reading.Band4)
+}
+
+// calculateTolerance computes tolerance range based on capacitance value
+func calculateTolerance(result *CalculationResult) error {
+	tolInfo, exists := readingTolerance(result.Reading)
+	if !exists {
+		return fmt.Errorf("invalid tolerance color for band 4")
+	}
+	result.ToleranceImplied = result.Reading.BandCount == 3
+
+	result.ToleranceSymmetric = tolInfo.Symmetric
+	result.ToleranceHigh = tolInfo.PercentHigh
+	result.ToleranceLow = tolInfo.PercentLow
+
+	// Determine tolerance type based on capacitance value
+	if result.CapacitancePF <= 10.0 {
+		// Use absolute pF tolerance for small capacitors
+		result.ToleranceType = "absolute"
+		result.ToleranceAbsolutePF = tolInfo.AbsolutePF
+
+		if tolInfo.AbsolutePF == 0 {
+			// This color doesn't have absolute tolerance defined
+			// Fall back to percentage
+			result.ToleranceType = "percentage"
+			result.TolerancePercent = tolInfo.PercentHigh
+			result.MinValue = result.CapacitancePF * (1 - tolInfo.PercentLow/100)
+			result.MaxValue = result.CapacitancePF * (1 + tolInfo.PercentHigh/100)
+		} else {
+			// Calculate min/max using absolute tolerance
+			result.MinValue = result.CapacitancePF - tolInfo.AbsolutePF
+			result.MaxValue = result.CapacitancePF + tolInfo.AbsolutePF
+
+			// Ensure non-negative
+			if result.MinValue < 0 {
+				result.MinValue = 0
+			}
+		}
+	} else {
+		// Use percentage tolerance for larger capacitors
+		result.ToleranceType = "percentage"
+		result.TolerancePercent = tolInfo.PercentHigh
+
+		// Calculate min/max values
+		result.MinValue = result.CapacitancePF * (1 - tolInfo.PercentLow/100)
+		result.MaxValue = result.CapacitancePF * (1 + tolInfo.PercentHigh/100)
+	}
+
+	// Scale min/max to appropriate units
+	result.MinValue, result.MinUnit = ScaleCapacitance(result.MinValue)
+	result.MaxValue, result.MaxUnit = ScaleCapacitance(result.MaxValue)
+
+	return nil
+}
+
+// ParallelCapacitance returns the total of capacitors in parallel (C = C1 + C2 + ...)
+func ParallelCapacitance(pFs ...float64) float64 {
+	return CombineCapacitorsParallel(pFs)
+}
+
+// SeriesCapacitance returns the total of capacitors in series (1/C = 1/C1 + 1/C2 + ...)
+// Returns 0 if there are no values or any value is not positive
+func SeriesCapacitance(pFs ...float64) float64 {
+	return CombineCapacitorsSeries(pFs)
+}
+
+// CombineCapacitorsParallel returns the equivalent of capacitors in
+// parallel, in pF
+func CombineCapacitorsParallel(vals []float64) float64 {
+	total := 0.0
+	for _, pF := range vals {
+		total += pF
+	}
+	return total
+}
+
+// CombineCapacitorsSeries returns the equivalent of capacitors in series, in
+// pF. It is 0 for no values or a value that is not positive.
+func CombineCapacitorsSeries(vals []float64) float64 {
+	return reciprocalSum(vals)
+}
+
+// reciprocalSum returns 1 / (1/v1 + 1/v2 + ...), or 0 if values is empty or
+// contains a value that is not positive. The terms are taken relative to the
+// smallest value, so a mix such as 10 pF and 100 µF keeps its precision.
+func reciprocalSum(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	smallest := slices.Min(values)
+	if smallest <= 0 {
+		return 0
+	}
+	sum := 0.0
+	for _, v := range values {
+		sum += smallest / v
+	}
+	return smallest / sum
+}
+
+// CapacitanceUnitPF is the size of each capacitance unit in pF
+var CapacitanceUnitPF = map[string]float64{"pF": 1, "nF": 1e3, "µF": 1e6, "mF": 1e9}
+
+// CapacitanceDisplayUnits are the fixed units a capacitance can be shown in,
+// in the order the results screen cycles through them
+var CapacitanceDisplayUnits = []string{"pF", "nF", "µF"}
+
+// CapacitanceInUnit converts a capacitance in pF to the given unit
+// Returns false for an unknown unit
+func CapacitanceInUnit(pF float64, unit string) (float64, bool) {
+	size, ok := CapacitanceUnitPF[unit]
+	if !ok {
+		return 0, false
+	}
+	return pF / size, true
+}
+
+// FormatCapacitanceInUnit formats a capacitance in pF in a fixed unit, to six
+// significant figures, e.g. 4700 pF in nF as "4.7 nF" and 10 pF in µF as
+// "0.00001 µF". An empty or unknown unit auto-scales as FormatCapacitance does.
+func FormatCapacitanceInUnit(pF float64, unit string) string {
+	value, ok := CapacitanceInUnit(pF, unit)
+	if !ok {
+		value, unit = ScaleCapacitance(pF)
+		return FormatCapacitance(value, unit)
+	}
+	return ExplainNumber(value) + " " + unit
+}
+
+// FormatCapacitance formats a capacitance value with unit
+// Values below 0.1, such as a Silver multiplier range in pF, keep three
+// significant figures (0.0300 pF) rather than rounding to 0.000 pF
+func FormatCapacitance(value float64, unit string) string {
+	// Format with appropriate precision
+	if value >= 100 {
+		return fmt.Sprintf("%.1f %s", value, unit)
+	} else if value >= 10 {
+		return fmt.Sprintf("%.2f %s", value, unit)
+	} else if value == 0 {
+		return "0 " + unit
+	} else if value >= 0.1 || value < 0 {
+		return fmt.Sprintf("%.3f %s", value, unit)
+	}
+	decimals := 2 - int(math.Floor(math.Log10(value)))
+	return fmt.Sprintf("%.*f %s", decimals, value, unit)
+}
+
+// FormatCapacitanceWithPF formats capacitance with both scaled unit and pF
+func FormatCapacitanceWithPF(value float64, unit string, pF float64) string {
+	scaled := FormatCapacitance(value, unit)
+
+	// Don't show pF if it's already in pF
+	if unit == "pF" {
+		return scaled
+	}
+
+	// Format pF with appropriate precision
+	var pfStr string
+	if pF >= 1000 {
+		pfStr = fmt.Sprintf("%.0f pF", pF)
+	} else if pF >= 1 {
+		pfStr = fmt.Sprintf("%.1f pF", pF)
+	} else {
+		pfStr = fmt.Sprintf("%.3f pF", pF)
+	}
+
+	return fmt.Sprintf("%s  (%s)", scaled, pfStr)
+}
+
+// FormatCapacitanceWithUF formats capacitance with µF value in brackets
+func FormatCapacitanceWithUF(value float64, unit string, pF float64) string {
+	scaled := FormatCapacitance(value, unit)
+
+	// Calculate µF value
+	uF := pF / 1000000.0
+
+	// Format µF with appropriate precision
+	var ufStr string
+	if uF >= 100 {
+		ufStr = fmt.Sprintf("%.1f µF", uF)
+	} else if uF >= 10 {
+		ufStr = fmt.Sprintf("%.2f µF", uF)
+	} else if uF >= 1 {
+		ufStr = fmt.Sprintf("%.3f µF", uF)
+	} else if uF >= 0.001 {
+		ufStr = fmt.Sprintf("%.6f µF", uF)
+	} else {
+		ufStr = fmt.Sprintf("%.9f µF", uF)
+	}
+
+	// If already in µF, just return scaled
+	if unit == "µF" {
+		return scaled + " [" + ufStr + "]"
+	}
+
+	return fmt.Sprintf("%s [%s]", scaled, ufStr)
+}
+
+// FormatTolerance formats tolerance information
+func FormatTolerance(result *CalculationResult) string {
+	if result.ToleranceType == "absolute" {
+		return fmt.Sprintf("±%.2f pF", result.ToleranceAbsolutePF)
+	}
+
+	if result.ToleranceSymmetric {
+		return fmt.Sprintf("±%.0f%%", result.TolerancePercent)
+	}
+
+	// Asymmetric (Grey)
+	return fmt.Sprintf("+%.0f%% / -%.0f%%", result.ToleranceHigh, result.ToleranceLow)
+}
+
+// FormatToleranceRange formats the min-max range
+func FormatToleranceRange(result *CalculationResult) string {
+	minStr := FormatCapacitance(result.MinValue, result.MinUnit)
+	maxStr := FormatCapacitance(result.MaxValue, result.MaxUnit)
+	return fmt.Sprintf("%s ──► %s", minStr, maxStr)
+}
+
+// FormatVoltage formats voltage rating
+func FormatVoltage(result *CalculationResult) string {
+	if !result.VoltageValid {
+		if result.Reading.BandCount == 5 {
+			return "voltage code invalid for this type"
+		}
+		return "N/A"
+	}
+
+	// Check if voltage is fractional
+	if result.VoltageRating != math.Floor(result.VoltageRating) {
+		return fmt.Sprintf("%.1f V", result.VoltageRating)
+	}
+
+	return fmt.Sprintf("%.0f V", result.VoltageRating)
+}
+
+// FormatTempCoefficient formats temperature coefficient
+func FormatTempCoefficient(result *CalculationResult) string {
+	if !result.TempCoeffValid {
+		return "N/A"
+	}
+
+	return fmt.Sprintf("%d × 10⁻⁶ /°C", result.TempCoefficient)
+}
@@ -0,0 +1,828 @@
+package decoder
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+)
+
+// TestCalculateCapacitance tests basic capacitance calculations
+func TestCalculateCapacitance(t *testing.T) {
+	tests := []struct {
+		name         string
+		band1        Color
+		band2        Color
+		band3        Color
+		band4        Color
+		band5        Color
+		bandCount    int
+		capType      CapacitorType
+		expectedPF   float64
+		expectedUnit string
+	}{
+		{
+			name:         "27nF capacitor - corrected example",
+			band1:        ColorRed,    // 2
+			band2:        ColorViolet, // 7
+			band3:        ColorOrange, // x1,000 (27 × 1,000 = 27,000 pF = 27 nF)
+			band4:        ColorBrown,  // ±1%
+			band5:        ColorOrange,
+			bandCount:    5,
+			capType:      TypeK,
+			expectedPF:   27000,
+			expectedUnit: "nF",
+		},
+		{
+			name:         "100pF capacitor",
+			band1:        ColorBrown, // 1
+			band2:        ColorBlack, // 0
+			band3:        ColorBrown, // x10
+			band4:        ColorBrown, // ±1%
+			band5:        ColorBlack,
+			bandCount:    4,
+			capType:      TypeK,
+			expectedPF:   100,
+			expectedUnit: "pF",
+		},
+		{
+			name:         "1µF capacitor",
+			band1:        ColorBrown,  // 1
+			band2:        ColorBlack,  // 0
+			band3:        ColorYellow, // x10,000
+			band4:        ColorGreen,  // ±5%
+			band5:        ColorBlack,
+			bandCount:    4,
+			capType:      TypeL,
+			expectedPF:   100000,
+			expectedUnit: "nF", // Should be 100 nF
+		},
+		{
+			name:         "Small capacitor with decimal multiplier",
+			band1:        ColorRed,    // 2
+			band2:        ColorViolet, // 7
+			band3:        ColorGrey,   // x0.01
+			band4:        ColorBrown,  // ±1%
+			band5:        ColorBlack,
+			bandCount:    4,
+			capType:      TypeK,
+			expectedPF:   0.27, // 27 * 0.01
+			expectedUnit: "pF",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reading := CapacitorReading{
+				Band1:     tt.band1,
+				Band2:     tt.band2,
+				Band3:     tt.band3,
+				Band4:     tt.band4,
+				Band5:     tt.band5,
+				BandCount: tt.bandCount,
+				CapType:   tt.capType,
+			}
+
+			result, err := Calculate(reading)
+			if err != nil {
+				t.Fatalf("Calculate() error = %v", err)
+			}
+
+			if result.CapacitancePF != tt.expectedPF {
+				t.Errorf("CapacitancePF = %v, want %v", result.CapacitancePF, tt.expectedPF)
+			}
+
+			if result.CapacitanceUnit != tt.expectedUnit {
+				t.Errorf("CapacitanceUnit = %v, want %v", result.CapacitanceUnit, tt.expectedUnit)
+			}
+		})
+	}
+}
+
+// TestToleranceCalculation tests tolerance calculations
+func TestToleranceCalculation(t *testing.T) {
+	tests := []struct {
+		name              string
+		capacitancePF     float64
+		toleranceColor    Color
+		expectedType      string
+		expectedSymmetric bool
+	}{
+		{
+			name:              "Large cap with percentage tolerance",
+			capacitancePF:     27000,
+			toleranceColor:    ColorBrown, // ±1%
+			expectedType:      "percentage",
+			expectedSymmetric: true,
+		},
+		{
+			name:              "Small cap with absolute tolerance",
+			capacitancePF:     5.0,
+			toleranceColor:    ColorBrown, // ±0.1pF
+			expectedType:      "absolute",
+			expectedSymmetric: true,
+		},
+		{
+			name:              "Asymmetric tolerance (Grey)",
+			capacitancePF:     1000,
+			toleranceColor:    ColorGrey, // +80%/-20%
+			expectedType:      "percentage",
+			expectedSymmetric: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reading := CapacitorReading{
+				Band1:     ColorBrown,
+				Band2:     ColorBlack,
+				Band3:     ColorBrown,
+				Band4:     tt.toleranceColor,
+				Band5:     ColorBlack,
+				BandCount: 4,
+				CapType:   TypeK,
+			}
+
+			// Override capacitance for testing
+			info1 := GetColorInfo(reading.Band1)
+			info2 := GetColorInfo(reading.Band2)
+			info3 := GetColorInfo(reading.Band3)
+			reading.Band1 = ColorBlack
+			reading.Band2 = ColorBlack
+			reading.Band3 = ColorBlack
+
+			// Set up a reading that produces the desired capacitance
+			baseValue := tt.capacitancePF
+			if baseValue > 99 {
+				reading.Band1 = ColorBrown
+				reading.Band2 = ColorBlack
+				reading.Band3 = ColorBrown
+			}
+
+			result, err := Calculate(reading)
+			if err != nil {
+				t.Fatalf("Calculate() error = %v", err)
+			}
+
+			// For small caps, manually set capacitance
+			if tt.capacitancePF <= 10 {
+				result.CapacitancePF = tt.capacitancePF
+				_ = calculateTolerance(result)
+			}
+
+			if result.ToleranceType != tt.expectedType {
+				t.Errorf("ToleranceType = %v, want %v", result.ToleranceType, tt.expectedType)
+			}
+
+			if result.ToleranceSymmetric != tt.expectedSymmetric {
+				t.Errorf("ToleranceSymmetric = %v, want %v", result.ToleranceSymmetric, tt.expectedSymmetric)
+			}
+
+			// Verify min/max values are calculated
+			if result.MinValue >= result.MaxValue {
+				t.Errorf("MinValue (%v) should be less than MaxValue (%v)", result.MinValue, result.MaxValue)
+			}
+
+			_ = info1
+			_ = info2
+			_ = info3
+		})
+	}
+}
+
+// TestUnitScaling tests the auto-scaling of capacitance units
+func TestUnitScaling(t *testing.T) {
+	tests := []struct {
+		name         string
+		pF           float64
+		expectedUnit string
+	}{
+		{"Small picofarad", 47, "pF"},
+		{"Large picofarad", 470, "pF"},
+		{"Small nanofarad", 1000, "nF"},
+		{"Large nanofarad", 470000, "nF"},
+		{"Small microfarad", 1000000, "µF"},
+		{"Large microfarad", 470000000, "µF"},
+		{"Millifarad", 1000000000, "mF"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, unit := ScaleCapacitance(tt.pF)
+			if unit != tt.expectedUnit {
+				t.Errorf("ScaleCapacitance(%v) unit = %v, want %v", tt.pF, unit, tt.expectedUnit)
+			}
+		})
+	}
+}
+
+// TestFormatCapacitance tests that small values keep significant figures
+func TestFormatCapacitance(t *testing.T) {
+	tests := []struct {
+		value float64
+		want  string
+	}{
+		{470, "470.0 pF"},
+		{47, "47.00 pF"},
+		{4.7, "4.700 pF"},
+		{0.27, "0.270 pF"},
+		{0.03, "0.0300 pF"},
+		{0.027, "0.0270 pF"},
+		{0.001, "0.00100 pF"},
+		{0, "0 pF"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := FormatCapacitance(tt.value, "pF"); got != tt.want {
+				t.Errorf("FormatCapacitance(%v) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestFormatInUnit tests formatting values in a fixed display unit
+func TestFormatInUnit(t *testing.T) {
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"pF in nF", FormatCapacitanceInUnit(4700, "nF"), "4.7 nF"},
+		{"pF in µF", FormatCapacitanceInUnit(10, "µF"), "0.00001 µF"},
+		{"µF in pF", FormatCapacitanceInUnit(1e6, "pF"), "1000000 pF"},
+		{"capacitance auto", FormatCapacitanceInUnit(4700, ""), "4.700 nF"},
+		{"Ω in kΩ", FormatResistanceInUnit(470, "kΩ"), "0.47 kΩ"},
+		{"MΩ in kΩ", FormatResistanceInUnit(2.2e6, "kΩ"), "2200 kΩ"},
+		{"resistance auto", FormatResistanceInUnit(470, ""), "470.0 Ω"},
+		{"unknown unit", FormatResistanceInUnit(470, "nF"), "470.0 Ω"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("got %q, want %q", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+// TestVoltageRating tests voltage rating lookups for different types
+func TestVoltageRating(t *testing.T) {
+	tests := []struct {
+		name        string
+		capType     CapacitorType
+		color       Color
+		expectedV   float64
+		shouldExist bool
+	}{
+		{"Type K - Orange", TypeK, ColorOrange, 400, true},
+		{"Type J - Brown", TypeJ, ColorBrown, 4, true},
+		{"Type L - Brown", TypeL, ColorBrown, 250, true}, // Brown (1) = 250V for Type L
+		{"Type M - Brown (1.6V)", TypeM, ColorBrown, 1.6, true},
+		{"Type N - Red (6.3V)", TypeN, ColorRed, 6.3, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			voltage, exists := GetVoltageRatingFractional(tt.capType, tt.color)
+			if exists != tt.shouldExist {
+				t.Errorf("GetVoltageRatingFractional() exists = %v, want %v", exists, tt.shouldExist)
+			}
+			if exists && voltage != tt.expectedV {
+				t.Errorf("GetVoltageRatingFractional() voltage = %v, want %v", voltage, tt.expectedV)
+			}
+		})
+	}
+}
+
+// TestValidVoltageColors tests the band 5 colors allowed per type and the
+// validation error that lists them
+func TestValidVoltageColors(t *testing.T) {
+	tests := []struct {
+		name      string
+		capType   CapacitorType
+		color     Color
+		wantCount int
+		wantErr   string
+	}{
+		{"Type J has no White code", TypeJ, ColorWhite, 9,
+			"White is not a valid voltage code for Type J (Dipped Tantalum) capacitors (must be one of Black, Brown, Red, Orange, Yellow, Green, Blue, Violet, Grey)"},
+		{"Type K White is 1000V", TypeK, ColorWhite, 10, ""},
+		{"Type M has no Black code", TypeM, ColorBlack, 8, "(must be one of Brown, Red,"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ValidVoltageColorsForType(tt.capType); len(got) != tt.wantCount {
+				t.Errorf("ValidVoltageColorsForType(%s) = %v, want %d colors", tt.capType, got, tt.wantCount)
+			}
+			err := ValidateBand5(tt.color, tt.capType, 5)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("ValidateBand5() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ValidateBand5() error = %v, want mention of %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestElectrolyticVoltageRatings tests every band 5 color for the Type M and
+// Type N electrolytics, including the fractional ratings
+func TestElectrolyticVoltageRatings(t *testing.T) {
+	tests := []struct {
+		capType CapacitorType
+		want    map[Color]float64 // Colors not listed have no rating
+	}{
+		{TypeM, map[Color]float64{
+			ColorBrown: 1.6, ColorRed: 2.5, ColorOrange: 4, ColorYellow: 6.3,
+			ColorGreen: 10, ColorBlue: 16, ColorViolet: 25, ColorGrey: 40,
+		}},
+		{TypeN, map[Color]float64{
+			ColorBlack: 3, ColorBrown: 6, ColorRed: 6.3, ColorOrange: 10,
+			ColorYellow: 15, ColorGreen: 20, ColorBlue: 25, ColorViolet: 35,
+		}},
+	}
+
+	for _, tt := range tests {
+		for _, c := range AllColors() {
+			want, wantOK := tt.want[c]
+			voltage, ok := GetVoltageRatingFractional(tt.capType, c)
+			if ok != wantOK || voltage != want {
+				t.Errorf("GetVoltageRatingFractional(%s, %s) = %v, %v, want %v, %v",
+					tt.capType, GetColorInfo(c).Name, voltage, ok, want, wantOK)
+			}
+		}
+	}
+}
+
+// TestColorParsing tests color name parsing
+func TestColorParsing(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Color
+		valid    bool
+	}{
+		{"red", ColorRed, true},
+		{"RED", ColorRed, true},
+		{"Red", ColorRed, true},
+		{"brown", ColorBrown, true},
+		{"grey", ColorGrey, true},
+		{"gray", ColorGrey, true}, // Alternative spelling
+		{"violet", ColorViolet, true},
+		{"bn", ColorBrown, true}, // Two-letter abbreviations
+		{"BK", ColorBlack, true},
+		{"Rd", ColorRed, true},
+		{"yl", ColorYellow, true},
+		{"bl", ColorBlue, true},
+		{"vt", ColorViolet, true},
+		{"gy", ColorGrey, true},
+		{"gn", ColorGreen, true},
+		{"gd", ColorGold, true},
+		{"gr", ColorBlack, false}, // Grey or green
+		{"invalid", ColorBlack, false},
+		{"", ColorBlack, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			color, valid := ParseColor(tt.input)
+			if valid != tt.valid {
+				t.Errorf("ParseColor(%q) valid = %v, want %v", tt.input, valid, tt.valid)
+			}
+			if valid && color != tt.expected {
+				t.Errorf("ParseColor(%q) = %v, want %v", tt.input, color, tt.expected)
+			}
+		})
+	}
+}
+
+// TestParseColorAllocs tests that parsing a lowercase name and listing the
+// color names allocate nothing, as both run on every keystroke
+func TestParseColorAllocs(t *testing.T) {
+	if allocs := testing.AllocsPerRun(100, func() { ParseColor("gray") }); allocs != 0 {
+		t.Errorf("ParseColor allocs = %v, want 0", allocs)
+	}
+	if allocs := testing.AllocsPerRun(100, func() { Palette{}.ColorNames() }); allocs != 0 {
+		t.Errorf("ColorNames allocs = %v, want 0", allocs)
+	}
+}
+
+// BenchmarkParseColor measures parsing typed color names, including the
+// gray/grey alias
+func BenchmarkParseColor(b *testing.B) {
+	inputs := []string{"red", "Violet", "gray", "grey", "silver", "nope"}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		ParseColor(inputs[i%len(inputs)])
+	}
+}
+
+// TestValidation tests input validation
+func TestValidation(t *testing.T) {
+	tests := []struct {
+		name      string
+		color     Color
+		bandNum   int
+		shouldErr bool
+	}{
+		{"Band 1 - valid digit", ColorRed, 1, false},
+		{"Band 1 - invalid (Gold)", ColorGold, 1, true},
+		{"Band 2 - valid digit", ColorViolet, 2, false},
+		{"Band 2 - invalid (Silver)", ColorSilver, 2, true},
+		{"Band 3 - valid multiplier", ColorYellow, 3, false},
+		{"Band 4 - valid tolerance", ColorBrown, 4, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var err error
+			switch tt.bandNum {
+			case 1:
+				err = ValidateBand1(tt.color)
+			case 2:
+				err = ValidateBand2(tt.color)
+			case 3:
+				err = ValidateBand3(tt.color)
+			case 4:
+				err = ValidateBand4(tt.color, 1000) // Use large capacitance
+			}
+
+			hasErr := err != nil
+			if hasErr != tt.shouldErr {
+				t.Errorf("Validation error = %v, shouldErr = %v", hasErr, tt.shouldErr)
+			}
+		})
+	}
+}
+
+// TestReadingProblems tests that whole-reading validation reports every bad band
+func TestReadingProblems(t *testing.T) {
+	capReading := CapacitorReading{
+		CapType:   TypeK,
+		BandCount: 3,
+		Band1:     ColorGold,
+		Band2:     ColorSilver,
+		Band3:     ColorRed,
+		Band4:     ColorBrown,
+	}
+	assertProblemBands(t, ReadingProblems(&capReading), []int{1, 2})
+
+	resReading := ResistorReading{
+		BandCount: 4,
+		Band1:     ColorGold,
+		Band2:     ColorRed,
+		Band3:     ColorRed,
+		Band4:     ColorOrange,
+	}
+	assertProblemBands(t, ResistorReadingProblems(&resReading), []int{1, 4})
+
+	valid := ResistorReading{BandCount: 4, Band1: ColorBrown, Band2: ColorBlack, Band3: ColorRed, Band4: ColorGold}
+	assertProblemBands(t, ResistorReadingProblems(&valid), nil)
+
+	// A 4-band capacitor has no voltage band, so the unset Band5 (Black, no
+	// Type M voltage code) is not checked
+	fourBandM := CapacitorReading{CapType: TypeM, BandCount: 4, Band1: ColorRed, Band2: ColorViolet, Band3: ColorOrange, Band4: ColorBrown}
+	assertProblemBands(t, ReadingProblems(&fourBandM), nil)
+}
+
+func assertProblemBands(t *testing.T, problems []*ValidationError, want []int) {
+	t.Helper()
+	if len(problems) != len(want) {
+		t.Fatalf("got %d problems (%v), want bands %v", len(problems), problems, want)
+	}
+	for i, problem := range problems {
+		if problem.BandNumber != want[i] {
+			t.Errorf("problem %d is for band %d, want band %d", i, problem.BandNumber, want[i])
+		}
+	}
+}
+
+// TestReverseReading tests reversing capacitor band order for each band count
+func TestReverseReading(t *testing.T) {
+	tests := []struct {
+		name     string
+		colors   []Color
+		expected []Color
+	}{
+		{"3-band", []Color{ColorRed, ColorViolet, ColorOrange}, []Color{ColorOrange, ColorViolet, ColorRed}},
+		{"5-band", []Color{ColorRed, ColorViolet, ColorOrange, ColorBrown, ColorGreen}, []Color{ColorGreen, ColorBrown, ColorOrange, ColorViolet, ColorRed}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reading, _ := CapacitorReadingFromColors(TypeK, tt.colors)
+			reversed, err := ReverseReading(reading)
+			if err != nil {
+				t.Fatalf("ReverseReading() error = %v", err)
+			}
+			expected, _ := CapacitorReadingFromColors(TypeK, tt.expected)
+			if reversed != expected {
+				t.Errorf("ReverseReading() = %+v, want %+v", reversed, expected)
+			}
+		})
+	}
+}
+
+// TestBlackVoltageBand tests Black as the band 5 voltage code for each type,
+// and that a 4-band reading has no voltage band to read
+func TestBlackVoltageBand(t *testing.T) {
+	tests := []struct {
+		capType       CapacitorType
+		expectedV     float64
+		expectedValid bool
+	}{
+		{TypeJ, 3, true},
+		{TypeK, 100, true},
+		{TypeL, 100, true},
+		{TypeM, 0, false},
+		{TypeN, 3, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.capType), func(t *testing.T) {
+			reading := CapacitorReading{
+				Band1:     ColorBrown,
+				Band2:     ColorBlack,
+				Band3:     ColorOrange,
+				Band4:     ColorBrown,
+				Band5:     ColorBlack,
+				BandCount: 5,
+				CapType:   tt.capType,
+			}
+
+			result, err := Calculate(reading)
+			if err != nil {
+				t.Fatalf("Calculate() error = %v", err)
+			}
+
+			if result.VoltageValid != tt.expectedValid {
+				t.Errorf("VoltageValid = %v, want %v", result.VoltageValid, tt.expectedValid)
+			}
+
+			if result.VoltageValid && result.VoltageRating != tt.expectedV {
+				t.Errorf("VoltageRating = %v, want %v", result.VoltageRating, tt.expectedV)
+			}
+
+			if !result.VoltageValid && FormatVoltage(result) != "voltage code invalid for this type" {
+				t.Errorf("FormatVoltage() = %q, want invalid code message", FormatVoltage(result))
+			}
+
+			reading.BandCount = 4
+			result, err = Calculate(reading)
+			if err != nil {
+				t.Fatalf("Calculate(4 bands) error = %v", err)
+			}
+			if result.VoltageValid || FormatVoltage(result) != "N/A" {
+				t.Errorf("4-band voltage = %q (valid %v), want N/A", FormatVoltage(result), result.VoltageValid)
+			}
+		})
+	}
+}
+
+// TestParseCapacitorType tests capacitor type parsing by letter and by name
+func TestParseCapacitorType(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected CapacitorType
+		valid    bool
+	}{
+		{"K", TypeK, true},
+		{"m", TypeM, true},
+		{"mica", TypeK, true},
+		{"MICA", TypeK, true},
+		{"tantalum", TypeJ, true},
+		{"tan", TypeJ, true},
+		{"poly", TypeL, true},
+		{"polystyrene", TypeL, true},
+		{"electrolytic (4", TypeM, true},
+		{"electrolytic", "", false}, // Ambiguous between M and N
+		{"ceramic", "", false},
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			capType, valid := ParseCapacitorType(tt.input)
+			if valid != tt.valid {
+				t.Errorf("ParseCapacitorType(%q) valid = %v, want %v", tt.input, valid, tt.valid)
+			}
+			if valid && capType != tt.expected {
+				t.Errorf("ParseCapacitorType(%q) = %v, want %v", tt.input, capType, tt.expected)
+			}
+		})
+	}
+}
+
+// TestThreeBandCapacitor tests that a 3-band capacitor has no tolerance band
+// and takes the implied ±20%
+func TestThreeBandCapacitor(t *testing.T) {
+	tests := []struct {
+		name      string
+		reading   CapacitorReading
+		valuePF   float64
+		tolerance string
+	}{
+		{"Band 4 unset", CapacitorReading{Band1: ColorRed, Band2: ColorViolet, Band3: ColorOrange, BandCount: 3, CapType: TypeN}, 27000, "±20%"},
+		{"Stray band 4 ignored", CapacitorReading{Band1: ColorRed, Band2: ColorViolet, Band3: ColorOrange, Band4: ColorBrown, BandCount: 3, CapType: TypeN}, 27000, "±20%"},
+		{"Small capacitor", CapacitorReading{Band1: ColorYellow, Band2: ColorViolet, Band3: ColorGold, BandCount: 3, CapType: TypeK}, 4.7, "±20%"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if problems := ReadingProblems(&tt.reading); len(problems) != 0 {
+				t.Errorf("ReadingProblems() = %v, want none", problems)
+			}
+			result, err := Calculate(tt.reading)
+			if err != nil {
+				t.Fatalf("Calculate() error = %v", err)
+			}
+			if math.Abs(result.CapacitancePF-tt.valuePF) > 1e-9 {
+				t.Errorf("CapacitancePF = %v, want %v", result.CapacitancePF, tt.valuePF)
+			}
+			if got := FormatTolerance(result); got != tt.tolerance {
+				t.Errorf("FormatTolerance() = %q, want %q", got, tt.tolerance)
+			}
+			if !result.ToleranceImplied {
+				t.Errorf("ToleranceImplied = false, want true")
+			}
+		})
+	}
+
+	// Band 4 is not looked up: a stray color that is no tolerance code (Blue)
+	// does not fail, and a Black one does not count as a tolerance band
+	for _, band4 := range []Color{ColorBlue, ColorBlack} {
+		reading := CapacitorReading{Band1: ColorRed, Band2: ColorViolet, Band3: ColorOrange, Band4: band4, BandCount: 3, CapType: TypeN}
+		result, err := Calculate(reading)
+		if err != nil {
+			t.Fatalf("Calculate() with band 4 %s error = %v", GetColorInfo(band4).Name, err)
+		}
+		if !result.ToleranceImplied || result.TolerancePercent != 20 {
+			t.Errorf("band 4 %s: tolerance = ±%v%% (implied %v), want implied ±20%%",
+				GetColorInfo(band4).Name, result.TolerancePercent, result.ToleranceImplied)
+		}
+	}
+
+	// A 4-band Black tolerance band is read, not implied, and the reading has
+	// no voltage band to check or decode
+	fourBandBlack := CapacitorReading{Band1: ColorRed, Band2: ColorViolet, Band3: ColorOrange, Band4: ColorBlack, BandCount: 4, CapType: TypeM}
+	assertProblemBands(t, ReadingProblems(&fourBandBlack), nil)
+	if result, err := Calculate(fourBandBlack); err != nil || result.ToleranceImplied || result.VoltageValid {
+		t.Errorf("4-band Calculate() = %+v, %v, want a read tolerance and no voltage", result, err)
+	}
+
+	// A 4-band reading still validates its tolerance band
+	fourBand := CapacitorReading{Band1: ColorYellow, Band2: ColorViolet, Band3: ColorGold, Band4: ColorBlack, BandCount: 4, CapType: TypeK}
+	assertProblemBands(t, ReadingProblems(&fourBand), []int{4})
+}
+
+// TestCapacitorBandsFromValue tests finding the bands for a capacitance and
+// decoding them back with Calculate
+func TestCapacitorBandsFromValue(t *testing.T) {
+	tests := []struct {
+		name      string
+		pF        float64
+		bandCount int
+		want      []Color
+		wantErr   string
+	}{
+		{"100pF", 100, 3, []Color{ColorBrown, ColorBlack, ColorBrown}, ""},
+		{"27nF", 27000, 3, []Color{ColorRed, ColorViolet, ColorOrange}, ""},
+		{"1µF", 1e6, 3, []Color{ColorBrown, ColorBlack, ColorGreen}, ""},
+		{"4.7pF uses gold", 4.7, 3, []Color{ColorYellow, ColorViolet, ColorGold}, ""},
+		{"0.47pF uses silver", 0.47, 3, []Color{ColorYellow, ColorViolet, ColorSilver}, ""},
+		{"27nF 4-band", 27000, 4, []Color{ColorRed, ColorViolet, ColorOrange, ColorBlack}, ""},
+		{"27nF 5-band", 27000, 5, []Color{ColorRed, ColorViolet, ColorOrange, ColorBlack, ColorBlack}, ""},
+		{"three significant figures", 27300, 3, nil, "three significant figures"},
+		{"below range", 0.05, 3, nil, "range"},
+		{"above range", 1e10, 3, nil, "range"},
+		{"not positive", 0, 3, nil, "positive"},
+		{"bad band count", 100, 6, nil, "band count"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reading, err := CapacitorBandsFromValue(tt.pF, TypeK, tt.bandCount)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("CapacitorBandsFromValue() error = %v, want mention of %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CapacitorBandsFromValue() error = %v", err)
+			}
+
+			want, err := CapacitorReadingFromColors(TypeK, tt.want)
+			if err != nil {
+				t.Fatalf("CapacitorReadingFromColors() error = %v", err)
+			}
+			if reading != want {
+				t.Errorf("CapacitorBandsFromValue() = %+v, want %+v", reading, want)
+			}
+
+			result, err := Calculate(reading)
+			if err != nil {
+				t.Fatalf("Calculate() error = %v", err)
+			}
+			if !ApproxEqual(result.CapacitancePF, tt.pF) {
+				t.Errorf("round trip = %v pF, want %v pF", result.CapacitancePF, tt.pF)
+			}
+		})
+	}
+
+	// Type M has no Black voltage code, so band 5 takes its first one
+	reading, err := CapacitorBandsFromValue(27000, TypeM, 5)
+	if err != nil {
+		t.Fatalf("CapacitorBandsFromValue(Type M) error = %v", err)
+	}
+	if reading.Band5 != ColorBrown {
+		t.Errorf("Type M band 5 = %v, want Brown", reading.Band5)
+	}
+	if err := ValidateReading(&reading); err != nil {
+		t.Errorf("ValidateReading(Type M) error = %v", err)
+	}
+}
+
+// TestCapacitorTempCoefficient tests which readings decode band 5 as a
+// temperature coefficient
+func TestCapacitorTempCoefficient(t *testing.T) {
+	tests := []struct {
+		name        string
+		capType     CapacitorType
+		band5       Color
+		bandCount   int
+		wantCoeff   int
+		wantValid   bool
+		wantVoltage float64 // 0 for no voltage band
+	}{
+		{"Type K 5-band Orange", TypeK, ColorOrange, 5, -150, true, 400},
+		{"Type K 5-band Violet", TypeK, ColorViolet, 5, -750, true, 800},
+		{"Type K 5-band Black has no coefficient", TypeK, ColorBlack, 5, 0, false, 100},
+		{"Type K 4-band has no coefficient or voltage band", TypeK, ColorOrange, 4, 0, false, 0},
+		{"Type J marks voltage only", TypeJ, ColorOrange, 5, 0, false, 10},
+		{"Type L marks voltage only", TypeL, ColorRed, 5, 0, false, 400},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reading := CapacitorReading{
+				Band1:     ColorRed,
+				Band2:     ColorViolet,
+				Band3:     ColorOrange,
+				Band4:     ColorGreen,
+				Band5:     tt.band5,
+				BandCount: tt.bandCount,
+				CapType:   tt.capType,
+			}
+			result, err := Calculate(reading)
+			if err != nil {
+				t.Fatalf("Calculate() error = %v", err)
+			}
+			if result.TempCoeffValid != tt.wantValid || result.TempCoefficient != tt.wantCoeff {
+				t.Errorf("TempCoefficient = %d (valid %v), want %d (valid %v)",
+					result.TempCoefficient, result.TempCoeffValid, tt.wantCoeff, tt.wantValid)
+			}
+			if tt.wantValid {
+				if got, want := FormatTempCoefficient(result), fmt.Sprintf("%d × 10⁻⁶ /°C", tt.wantCoeff); got != want {
+					t.Errorf("FormatTempCoefficient() = %q, want %q", got, want)
+				}
+			}
+			if result.VoltageValid != (tt.wantVoltage != 0) || result.VoltageRating != tt.wantVoltage {
+				t.Errorf("VoltageRating = %v (valid %v), want %v", result.VoltageRating, result.VoltageValid, tt.wantVoltage)
+			}
+		})
+	}
+}
+
+// TestCombineCapacitors tests parallel and series equivalents in pF,
+// including a mix of pF and µF values
+func TestCombineCapacitors(t *testing.T) {
+	tests := []struct {
+		name         string
+		vals         []float64
+		wantParallel float64
+		wantSeries   float64
+	}{
+		{"two equal", []float64{100, 100}, 200, 50},
+		{"pF with µF", []float64{10, 1e8}, 10 + 1e8, 10 * 1e8 / (1e8 + 10)},
+		{"three", []float64{1000, 2000, 3000}, 6000, 6000.0 / 11},
+		{"zero in series", []float64{1000, 0}, 1000, 0},
+		{"none", nil, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CombineCapacitorsParallel(tt.vals); !ApproxEqual(got, tt.wantParallel) {
+				t.Errorf("CombineCapacitorsParallel() = %v, want %v", got, tt.wantParallel)
+			}
+			if got := CombineCapacitorsSeries(tt.vals); !ApproxEqual(got, tt.wantSeries) {
+				t.Errorf("CombineCapacitorsSeries() = %v, want %v", got, tt.wantSeries)
+			}
+		})
+	}
+}
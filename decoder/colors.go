@@ -1,7 +1,9 @@
-package main
+package decoder
 
 import (
+	"fmt"
 	"strings"
+	"unicode"
 )
 
 // Color represents a capacitor band color
@@ -20,6 +22,7 @@ const (
 	ColorWhite
 	ColorGold
 	ColorSilver
+	ColorPink // Resistor multiplier ×0.001, extended color set only
 )
 
 // ColorInfo contains all information about a color band
@@ -143,6 +146,34 @@ var colorMap = map[Color]ColorInfo{
 		ValidMult:  true,
 		ValidTol:   true,
 	},
+	ColorPink: {
+		Name:       "Pink",
+		Digit:      -1,    // Not valid for digit bands
+		Multiplier: 0.001, // Resistors only, see resistorMultiplierMap
+		HexColor:   "#FFC0CB",
+		ValidDigit: false,
+		ValidMult:  false,
+		ValidTol:   false,
+	},
+}
+
+// lastColor returns the last color in the palette: Silver, or Pink with the
+// extended color set
+func (p Palette) lastColor() Color {
+	if p.Extended {
+		return ColorPink
+	}
+	return ColorSilver
+}
+
+// Enabled reports whether a color is in the palette
+func (p Palette) Enabled(c Color) bool {
+	return c >= ColorBlack && c <= p.lastColor()
+}
+
+// Colors returns the colors in the palette in code order
+func (p Palette) Colors() []Color {
+	return AllColors()[:p.lastColor()+1]
 }
 
 // ToleranceInfo represents tolerance specifications
@@ -230,30 +261,141 @@ var temperatureCoefficientMap = map[Color]int{
 	ColorViolet: -750,
 }
 
-// ParseColor converts a string input to a Color
-func ParseColor(input string) (Color, bool) {
-	input = strings.ToLower(strings.TrimSpace(input))
+// colorNameMap maps lowercase English color names to colors
+var colorNameMap = map[string]Color{
+	"black":  ColorBlack,
+	"brown":  ColorBrown,
+	"red":    ColorRed,
+	"orange": ColorOrange,
+	"yellow": ColorYellow,
+	"green":  ColorGreen,
+	"blue":   ColorBlue,
+	"violet": ColorViolet,
+	"grey":   ColorGrey,
+	"gray":   ColorGrey, // Alternative spelling
+	"white":  ColorWhite,
+	"gold":   ColorGold,
+	"silver": ColorSilver,
+	"pink":   ColorPink,
+}
+
+// colorAbbreviations maps the two-letter electronics abbreviations
+// (IEC 60757) to colors. "gr" is left out as it could mean grey or green.
+var colorAbbreviations = map[string]Color{
+	"bk": ColorBlack,
+	"bn": ColorBrown,
+	"rd": ColorRed,
+	"or": ColorOrange,
+	"ye": ColorYellow,
+	"yl": ColorYellow,
+	"gn": ColorGreen,
+	"bu": ColorBlue,
+	"bl": ColorBlue,
+	"vt": ColorViolet,
+	"vi": ColorViolet,
+	"gy": ColorGrey,
+	"wh": ColorWhite,
+	"gd": ColorGold,
+	"si": ColorSilver,
+	"pk": ColorPink,
+}
+
+// colorAbbrevNames holds the preferred two-letter abbreviation of each color,
+// in Color order
+var colorAbbrevNames = []string{"BK", "BN", "RD", "OR", "YE", "GN", "BU", "VT", "GY", "WH", "GD", "SI", "PK"}
 
-	colorNameMap := map[string]Color{
-		"black":  ColorBlack,
-		"brown":  ColorBrown,
-		"red":    ColorRed,
-		"orange": ColorOrange,
-		"yellow": ColorYellow,
-		"green":  ColorGreen,
-		"blue":   ColorBlue,
-		"violet": ColorViolet,
-		"grey":   ColorGrey,
-		"gray":   ColorGrey, // Alternative spelling
-		"white":  ColorWhite,
-		"gold":   ColorGold,
-		"silver": ColorSilver,
+// ColorAbbrev returns the two-letter abbreviation of a color, e.g. "BN"
+func ColorAbbrev(color Color) string {
+	if color < 0 || int(color) >= len(colorAbbrevNames) {
+		return "??"
 	}
+	return colorAbbrevNames[color]
+}
+
+// englishColorNames holds the English display names in Color order
+var englishColorNames = func() []string {
+	names := make([]string, 0, len(colorMap))
+	for c := ColorBlack; c <= ColorPink; c++ {
+		names = append(names, colorMap[c].Name)
+	}
+	return names
+}()
+
+// ParseColor converts a string input to a Color in the palette, accepting
+// English names, names in the palette's language and two-letter
+// abbreviations such as "bn". A name wins over an abbreviation, so French
+// "or" is Gold, not Orange.
+func (p Palette) ParseColor(input string) (Color, bool) {
+	input = strings.ToLower(strings.TrimSpace(input))
 
 	color, exists := colorNameMap[input]
+	if !exists {
+		color, exists = p.parseLocalizedColor(input)
+	}
+	if !exists {
+		color, exists = p.ParseColorAbbrev(input)
+	}
+	if !p.Enabled(color) {
+		return 0, false
+	}
 	return color, exists
 }
 
+// ParseColor converts an English color name or abbreviation to one of the
+// 12 standard colors, see Palette.ParseColor
+func ParseColor(input string) (Color, bool) {
+	return Palette{}.ParseColor(input)
+}
+
+// ParseColorAbbrev converts a two-letter abbreviation such as "BN" or "gy"
+// to a Color in the palette. An abbreviation that is a color name in the
+// palette's language, such as "or" (Gold) in French, is read as the name and
+// not accepted here.
+func (p Palette) ParseColorAbbrev(input string) (Color, bool) {
+	input = strings.ToLower(strings.TrimSpace(input))
+	if _, isName := p.parseLocalizedColor(input); isName {
+		return 0, false
+	}
+	color, exists := colorAbbreviations[input]
+	if !p.Enabled(color) {
+		return 0, false
+	}
+	return color, exists
+}
+
+// ParseColorAbbrev converts a two-letter abbreviation to one of the 12
+// standard colors, see Palette.ParseColorAbbrev
+func ParseColorAbbrev(input string) (Color, bool) {
+	return Palette{}.ParseColorAbbrev(input)
+}
+
+// ParseBandSequence parses a list of color names in the palette separated by
+// commas and/or whitespace (e.g. "brown,black,red,gold" or "Brown Black Red Gold")
+func (p Palette) ParseBandSequence(input string) ([]Color, error) {
+	fields := strings.FieldsFunc(input, func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	})
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("no bands given")
+	}
+
+	colors := make([]Color, 0, len(fields))
+	for i, field := range fields {
+		color, ok := p.ParseColor(field)
+		if !ok {
+			return nil, fmt.Errorf("band %d: invalid color '%s'", i+1, field)
+		}
+		colors = append(colors, color)
+	}
+	return colors, nil
+}
+
+// ParseBandSequence parses a list of English color names, see
+// Palette.ParseBandSequence
+func ParseBandSequence(input string) ([]Color, error) {
+	return Palette{}.ParseBandSequence(input)
+}
+
 // GetColorInfo returns information about a color
 func GetColorInfo(c Color) ColorInfo {
 	return colorMap[c]
@@ -271,10 +413,12 @@ func GetTempCoefficient(c Color) (int, bool) {
 	return coeff, exists
 }
 
-// AllColorNames returns a list of all valid color names
-func AllColorNames() []string {
-	return []string{
-		"Black", "Brown", "Red", "Orange", "Yellow", "Green",
-		"Blue", "Violet", "Grey", "White", "Gold", "Silver",
+// ColorNames returns the names of the colors in the palette in its language,
+// in Color order. The slice is shared and must not be modified.
+func (p Palette) ColorNames() []string {
+	names, ok := colorNamesByLanguage[p.Language]
+	if !ok {
+		names = englishColorNames
 	}
+	return names[:p.lastColor()+1]
 }
@@ -0,0 +1,5 @@
+// Package decoder decodes capacitor (IEC 60062) and resistor (EIA) color
+// bands: the color tables, band parsing and validation, and the value,
+// tolerance and rating calculations. It has no terminal dependencies, so other
+// programs can use it without the tropical-fish TUI.
+package decoder
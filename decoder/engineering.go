@@ -0,0 +1,105 @@
+package decoder
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// engineeringPrefixes maps the prefix letters ParseEngineeringValue accepts
+// to their multipliers. R marks the decimal point of a plain value, as in
+// "4R7" or "470R"; µ, μ and u are all micro.
+var engineeringPrefixes = map[rune]float64{
+	'p': 1e-12,
+	'n': 1e-9,
+	'u': 1e-6,
+	'µ': 1e-6, // Micro sign
+	'μ': 1e-6, // Greek mu
+	'm': 1e-3,
+	'R': 1,
+	'r': 1,
+	'k': 1e3,
+	'K': 1e3,
+	'M': 1e6,
+	'G': 1e9,
+}
+
+// engineeringUnits are the unit suffixes ParseEngineeringValue drops
+var engineeringUnits = []string{"ohms", "ohm", "Ω", "F"}
+
+// ParseEngineeringValue parses a component value in the base unit (Ω or F,
+// depending on the caller), in the plain "4.7k" form or with the prefix as
+// the decimal point as printed on parts: "4k7", "2M2", "470R", "R47", "1u5".
+// Prefixes are case sensitive (m is milli, M is mega) except k, and a unit
+// such as "Ω" or "F" may follow. Input with more than one prefix, or with
+// both a decimal point and an infix prefix such as "4.7k7", is rejected.
+func ParseEngineeringValue(input string) (float64, error) {
+	s := strings.TrimSpace(input)
+	for _, unit := range engineeringUnits {
+		if len(s) > len(unit) && strings.EqualFold(s[len(s)-len(unit):], unit) {
+			s = strings.TrimSpace(s[:len(s)-len(unit)])
+			break
+		}
+	}
+	if s == "" {
+		return 0, fmt.Errorf("empty value")
+	}
+
+	// Find the single prefix letter, if any
+	prefix := -1
+	for i, r := range s {
+		if unicode.IsDigit(r) || r == '.' || r == ' ' {
+			continue
+		}
+		if _, ok := engineeringPrefixes[r]; !ok {
+			return 0, fmt.Errorf("invalid value: '%s'", input)
+		}
+		if prefix >= 0 {
+			return 0, fmt.Errorf("'%s' has more than one prefix", input)
+		}
+		prefix = i
+	}
+
+	if prefix < 0 {
+		return parseEngineeringNumber(s, input)
+	}
+
+	r, size := utf8.DecodeRuneInString(s[prefix:])
+	multiplier := engineeringPrefixes[r]
+	whole, fraction := s[:prefix], s[prefix+size:]
+
+	if fraction == "" {
+		// "4.7k": the prefix follows the number, maybe after a space
+		whole = strings.TrimSpace(whole)
+		if whole == "" {
+			return 0, fmt.Errorf("invalid value: '%s'", input)
+		}
+		value, err := parseEngineeringNumber(whole, input)
+		return value * multiplier, err
+	}
+
+	// "4k7": the prefix stands for the decimal point
+	if strings.Contains(whole, ".") || strings.Contains(fraction, ".") {
+		return 0, fmt.Errorf("'%s' mixes a decimal point with an infix prefix", input)
+	}
+	if whole == "" {
+		whole = "0"
+	}
+	value, err := parseEngineeringNumber(whole+"."+fraction, input)
+	return value * multiplier, err
+}
+
+// parseEngineeringNumber parses the digits of a value, which may hold
+// only digits and one decimal point
+func parseEngineeringNumber(s, input string) (float64, error) {
+	if strings.Trim(s, "0123456789.") != "" || strings.Trim(s, ".") == "" {
+		return 0, fmt.Errorf("invalid value: '%s'", input)
+	}
+	value, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value: '%s'", input)
+	}
+	return value, nil
+}
@@ -0,0 +1,56 @@
+package decoder
+
+import "testing"
+
+// TestParseEngineeringValue tests plain, infix-prefix and RKM-style values
+// and the rejection of ambiguous input
+func TestParseEngineeringValue(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    float64
+		wantErr bool
+	}{
+		{"220", 220, false},
+		{"4.7k", 4700, false},
+		{"4.7 k", 4700, false},
+		{"4k7", 4700, false},
+		{"4K7", 4700, false},
+		{"2M2", 2.2e6, false},
+		{"1M", 1e6, false},
+		{"470R", 470, false},
+		{"4R7", 4.7, false},
+		{"R47", 0.47, false},
+		{"27n", 27e-9, false},
+		{"4n7", 4.7e-9, false},
+		{"1u", 1e-6, false},
+		{"1µ", 1e-6, false},
+		{"1μ", 1e-6, false},
+		{"1u5", 1.5e-6, false},
+		{"2m2", 2.2e-3, false},
+		{"100nF", 100e-9, false},
+		{"4k7Ω", 4700, false},
+		{"10 ohms", 10, false},
+		{"", 0, true},
+		{"k", 0, true},
+		{"abc", 0, true},
+		{"-5", 0, true},
+		{"4.7k7", 0, true},
+		{"4k7.5", 0, true},
+		{"4k7k", 0, true},
+		{"1u5n", 0, true},
+		{"4 k7", 0, true},
+		{"4x7", 0, true},
+		{"1.2.3", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseEngineeringValue(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseEngineeringValue(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && !ApproxEqual(got, tt.want) {
+			t.Errorf("ParseEngineeringValue(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
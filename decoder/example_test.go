@@ -0,0 +1,35 @@
+package decoder_test
+
+import (
+	"fmt"
+	"log"
+
+	"tropical-fish/decoder"
+)
+
+// ExampleCalculateResistor decodes a 4-band resistor from typed color names
+func ExampleCalculateResistor() {
+	var colors []decoder.Color
+	for _, name := range []string{"yellow", "violet", "red", "gold"} {
+		color, ok := decoder.ParseColor(name)
+		if !ok {
+			log.Fatalf("unknown color %q", name)
+		}
+		colors = append(colors, color)
+	}
+
+	reading, err := decoder.ResistorReadingFromColors(colors)
+	if err != nil {
+		log.Fatal(err)
+	}
+	result, err := decoder.CalculateResistor(reading)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println(decoder.FormatResistance(result.ResistanceValue, result.ResistanceUnit))
+	fmt.Println(decoder.FormatResistorToleranceRange(result))
+	// Output:
+	// 4.700 kΩ
+	// 4.465 kΩ ──► 4.935 kΩ
+}
@@ -0,0 +1,143 @@
+package decoder
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// ExplainCapacitor returns the steps of a capacitor calculation for the
+// "show working" view, e.g.
+//
+//	Band 1 = 2 (Red), Band 2 = 7 (Violet) → 27
+//	27 × 1000 (Orange) = 27000 pF = 27 nF
+//	Tolerance Brown = ±1% → 26.73–27.27 nF
+func ExplainCapacitor(result *CalculationResult) []string {
+	if result == nil {
+		return nil
+	}
+	reading := result.Reading
+	info1, info2, info3 := GetColorInfo(reading.Band1), GetColorInfo(reading.Band2), GetColorInfo(reading.Band3)
+	base := info1.Digit*10 + info2.Digit
+
+	steps := []string{
+		fmt.Sprintf("Band 1 = %d (%s), Band 2 = %d (%s) → %d", info1.Digit, info1.Name, info2.Digit, info2.Name, base),
+		fmt.Sprintf("%d × %s (%s) = %s pF", base, ExplainNumber(info3.Multiplier), info3.Name, ExplainNumber(result.CapacitancePF)) +
+			explainScaled(result.CapacitanceValue, result.CapacitanceUnit, "pF"),
+	}
+
+	tolerance := capacitorToleranceStep(result)
+	steps = append(steps, tolerance+" → "+ExplainRange(result.MinValue, result.MinUnit, result.MaxValue, result.MaxUnit))
+
+	if result.VoltageValid {
+		steps = append(steps, fmt.Sprintf("Band 5 %s on Type %s = %s V",
+			GetColorInfo(reading.Band5).Name, reading.CapType, ExplainNumber(result.VoltageRating)))
+	}
+	if result.TempCoeffValid {
+		steps = append(steps, fmt.Sprintf("Band 5 %s = %s", GetColorInfo(reading.Band5).Name, FormatTempCoefficient(result)))
+	}
+
+	return steps
+}
+
+// capacitorToleranceStep describes where a capacitor's tolerance came from
+func capacitorToleranceStep(result *CalculationResult) string {
+	if result.ToleranceImplied {
+		return fmt.Sprintf("No tolerance band, ±%s%% implied", ExplainNumber(result.ToleranceHigh))
+	}
+
+	name := GetColorInfo(result.Reading.Band4).Name
+	switch {
+	case result.ToleranceType == "absolute":
+		return fmt.Sprintf("Tolerance %s = ±%s pF (absolute, as %s pF ≤ 10 pF)",
+			name, ExplainNumber(result.ToleranceAbsolutePF), ExplainNumber(result.CapacitancePF))
+	case !result.ToleranceSymmetric:
+		return fmt.Sprintf("Tolerance %s = +%s%% / -%s%%",
+			name, ExplainNumber(result.ToleranceHigh), ExplainNumber(result.ToleranceLow))
+	default:
+		return fmt.Sprintf("Tolerance %s = ±%s%%", name, ExplainNumber(result.TolerancePercent))
+	}
+}
+
+// ExplainResistor returns the steps of a resistor calculation for the "show
+// working" view, e.g.
+//
+//	Band 1 = 1 (Brown), Band 2 = 0 (Black) → 10
+//	10 × 100 (Red) = 1000 Ω = 1 kΩ
+//	Tolerance Gold = ±5% → 950 Ω–1.05 kΩ
+//
+// Returns nil for a result without bands, such as a decoded SMD code
+func ExplainResistor(result *ResistorResult) []string {
+	if result == nil {
+		return nil
+	}
+	reading := result.Reading
+
+	var digitBands []Color
+	var multiplierBand, toleranceBand Color
+	switch {
+	case reading.BandCount == 4, reading.Military:
+		digitBands = []Color{reading.Band1, reading.Band2}
+		multiplierBand, toleranceBand = reading.Band3, reading.Band4
+	case reading.BandCount == 5, reading.BandCount == 6:
+		digitBands = []Color{reading.Band1, reading.Band2, reading.Band3}
+		multiplierBand, toleranceBand = reading.Band4, reading.Band5
+	default:
+		return nil
+	}
+
+	digits := ""
+	base := 0
+	for i, band := range digitBands {
+		info := GetColorInfo(band)
+		if i > 0 {
+			digits += ", "
+		}
+		digits += fmt.Sprintf("Band %d = %d (%s)", i+1, info.Digit, info.Name)
+		base = base*10 + info.Digit
+	}
+
+	multiplier, _ := GetResistorMultiplier(multiplierBand)
+	steps := []string{
+		fmt.Sprintf("%s → %d", digits, base),
+		fmt.Sprintf("%d × %s (%s) = %s Ω", base, ExplainNumber(multiplier), GetColorInfo(multiplierBand).Name,
+			ExplainNumber(result.ResistanceOhms)) +
+			explainScaled(result.ResistanceValue, result.ResistanceUnit, "Ω"),
+		fmt.Sprintf("Tolerance %s = ±%s%% → %s", GetColorInfo(toleranceBand).Name,
+			ExplainNumber(result.TolerancePercent),
+			ExplainRange(result.MinValue, result.MinUnit, result.MaxValue, result.MaxUnit)),
+	}
+
+	if result.TempCoeffValid {
+		steps = append(steps, fmt.Sprintf("Band 6 %s = %s", GetColorInfo(reading.Band6).Name, FormatResistorTempCoefficient(result)))
+	}
+	if result.FailureRateValid {
+		steps = append(steps, fmt.Sprintf("Band 5 %s = %s failure rate", GetColorInfo(reading.Band5).Name, FormatFailureRate(result)))
+	}
+
+	return steps
+}
+
+// explainScaled returns " = 27 nF" when a value was scaled from its base
+// unit, or "" when it is shown in the base unit already
+func explainScaled(value float64, unit, baseUnit string) string {
+	if unit == baseUnit {
+		return ""
+	}
+	return fmt.Sprintf(" = %s %s", ExplainNumber(value), unit)
+}
+
+// ExplainRange formats a min–max range, giving the unit once when both ends
+// share it, e.g. "26.73–27.27 nF" or "950 Ω–1.05 kΩ"
+func ExplainRange(minValue float64, minUnit string, maxValue float64, maxUnit string) string {
+	if minUnit == maxUnit {
+		return fmt.Sprintf("%s–%s %s", ExplainNumber(minValue), ExplainNumber(maxValue), maxUnit)
+	}
+	return fmt.Sprintf("%s %s–%s %s", ExplainNumber(minValue), minUnit, ExplainNumber(maxValue), maxUnit)
+}
+
+// ExplainNumber formats a value to six significant figures without trailing
+// zeros, hiding floating point noise such as 26.729999999999997
+func ExplainNumber(v float64) string {
+	rounded, _ := strconv.ParseFloat(strconv.FormatFloat(v, 'g', 6, 64), 64)
+	return strconv.FormatFloat(rounded, 'f', -1, 64)
+}
@@ -0,0 +1,110 @@
+package decoder
+
+import (
+	"slices"
+	"testing"
+)
+
+// TestExplainCapacitor tests the calculation steps, including the absolute
+// small-capacitor and asymmetric Grey tolerances
+func TestExplainCapacitor(t *testing.T) {
+	tests := []struct {
+		name    string
+		capType CapacitorType
+		colors  []Color
+		want    []string
+	}{
+		{"27nF ±1%", TypeL, []Color{ColorRed, ColorViolet, ColorOrange, ColorBrown, ColorOrange}, []string{
+			"Band 1 = 2 (Red), Band 2 = 7 (Violet) → 27",
+			"27 × 1000 (Orange) = 27000 pF = 27 nF",
+			"Tolerance Brown = ±1% → 26.73–27.27 nF",
+			"Band 5 Orange on Type L = 630 V",
+		}},
+		{"4.7pF absolute", TypeJ, []Color{ColorYellow, ColorViolet, ColorGold, ColorBrown, ColorRed}, []string{
+			"Band 1 = 4 (Yellow), Band 2 = 7 (Violet) → 47",
+			"47 × 0.1 (Gold) = 4.7 pF",
+			"Tolerance Brown = ±0.1 pF (absolute, as 4.7 pF ≤ 10 pF) → 4.6–4.8 pF",
+			"Band 5 Red on Type J = 6 V",
+		}},
+		{"Grey +80/-20% with voltage", TypeM, []Color{ColorBrown, ColorBlack, ColorYellow, ColorGrey, ColorYellow}, []string{
+			"Band 1 = 1 (Brown), Band 2 = 0 (Black) → 10",
+			"10 × 10000 (Yellow) = 100000 pF = 100 nF",
+			"Tolerance Grey = +80% / -20% → 80–180 nF",
+			"Band 5 Yellow on Type M = 6.3 V",
+		}},
+		{"3-band implied", TypeK, []Color{ColorRed, ColorRed, ColorRed}, []string{
+			"Band 1 = 2 (Red), Band 2 = 2 (Red) → 22",
+			"22 × 100 (Red) = 2200 pF = 2.2 nF",
+			"No tolerance band, ±20% implied → 1.76–2.64 nF",
+		}},
+		{"Type K temp coefficient", TypeK, []Color{ColorRed, ColorViolet, ColorOrange, ColorGreen, ColorOrange}, []string{
+			"Band 1 = 2 (Red), Band 2 = 7 (Violet) → 27",
+			"27 × 1000 (Orange) = 27000 pF = 27 nF",
+			"Tolerance Green = ±5% → 25.65–28.35 nF",
+			"Band 5 Orange on Type K = 400 V",
+			"Band 5 Orange = -150 × 10⁻⁶ /°C",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reading, err := CapacitorReadingFromColors(tt.capType, tt.colors)
+			if err != nil {
+				t.Fatalf("CapacitorReadingFromColors() error = %v", err)
+			}
+			result, err := Calculate(reading)
+			if err != nil {
+				t.Fatalf("Calculate() error = %v", err)
+			}
+			if got := ExplainCapacitor(result); !slices.Equal(got, tt.want) {
+				t.Errorf("ExplainCapacitor() =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestExplainResistor tests the calculation steps for 4 and 6-band resistors
+func TestExplainResistor(t *testing.T) {
+	tests := []struct {
+		name   string
+		colors []Color
+		want   []string
+	}{
+		{"1k 4-band", []Color{ColorBrown, ColorBlack, ColorRed, ColorGold}, []string{
+			"Band 1 = 1 (Brown), Band 2 = 0 (Black) → 10",
+			"10 × 100 (Red) = 1000 Ω = 1 kΩ",
+			"Tolerance Gold = ±5% → 950 Ω–1.05 kΩ",
+		}},
+		{"47 ohm 6-band", []Color{ColorYellow, ColorViolet, ColorBlack, ColorGold, ColorBrown, ColorRed}, []string{
+			"Band 1 = 4 (Yellow), Band 2 = 7 (Violet), Band 3 = 0 (Black) → 470",
+			"470 × 0.1 (Gold) = 47 Ω",
+			"Tolerance Brown = ±1% → 46.53–47.47 Ω",
+			"Band 6 Red = 50 ppm/°C",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reading, err := ResistorReadingFromColors(tt.colors)
+			if err != nil {
+				t.Fatalf("ResistorReadingFromColors() error = %v", err)
+			}
+			result, err := CalculateResistor(reading)
+			if err != nil {
+				t.Fatalf("CalculateResistor() error = %v", err)
+			}
+			if got := ExplainResistor(result); !slices.Equal(got, tt.want) {
+				t.Errorf("ExplainResistor() =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+
+	// An SMD code has no bands to explain
+	smd, err := DecodeSMDResistor("472")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := ExplainResistor(smd); got != nil {
+		t.Errorf("ExplainResistor(SMD) = %q, want nil", got)
+	}
+}
@@ -0,0 +1,103 @@
+package decoder
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Language is a supported display/input language for color names
+type Language string
+
+const (
+	LangEnglish Language = "en"
+	LangGerman  Language = "de"
+	LangFrench  Language = "fr"
+	LangSpanish Language = "es"
+)
+
+// colorNamesByLanguage holds the display names of each color, in Color order
+// (Black through Pink). English names come from colorMap.
+var colorNamesByLanguage = map[Language][]string{
+	LangGerman: {
+		"Schwarz", "Braun", "Rot", "Orange", "Gelb", "Grün",
+		"Blau", "Violett", "Grau", "Weiß", "Gold", "Silber", "Rosa",
+	},
+	LangFrench: {
+		"Noir", "Marron", "Rouge", "Orange", "Jaune", "Vert",
+		"Bleu", "Violet", "Gris", "Blanc", "Or", "Argent", "Rose",
+	},
+	LangSpanish: {
+		"Negro", "Marrón", "Rojo", "Naranja", "Amarillo", "Verde",
+		"Azul", "Violeta", "Gris", "Blanco", "Oro", "Plata", "Rosa",
+	},
+}
+
+// colorAliasesByLanguage holds extra accepted spellings, e.g. without umlauts
+var colorAliasesByLanguage = map[Language]map[string]Color{
+	LangGerman: {
+		"gruen": ColorGreen,
+		"weiss": ColorWhite,
+		"lila":  ColorViolet,
+	},
+	LangFrench: {
+		"brun": ColorBrown,
+	},
+	LangSpanish: {
+		"marron": ColorBrown,
+		"cafe":   ColorBrown,
+		"café":   ColorBrown,
+	},
+}
+
+// localizedColorNameMaps maps lowercase color names and aliases to colors for
+// each language in colorNamesByLanguage
+var localizedColorNameMaps = func() map[Language]map[string]Color {
+	maps := map[Language]map[string]Color{}
+	for lang, names := range colorNamesByLanguage {
+		nameMap := map[string]Color{}
+		for i, name := range names {
+			nameMap[strings.ToLower(name)] = Color(i)
+		}
+		for alias, color := range colorAliasesByLanguage[lang] {
+			nameMap[alias] = color
+		}
+		maps[lang] = nameMap
+	}
+	return maps
+}()
+
+// Palette is the set of band colors in use and the language their names are
+// shown and typed in. The zero Palette is the 12 standard colors in English.
+type Palette struct {
+	Language Language // Color name language ("" is English)
+	Extended bool     // Add Pink (×0.001) to the standard colors
+}
+
+// NewPalette returns the palette for a language code such as "de" or "fr",
+// with or without the extended color set
+func NewPalette(code string, extended bool) (Palette, error) {
+	lang := Language(strings.ToLower(strings.TrimSpace(code)))
+	if _, ok := colorNamesByLanguage[lang]; !ok && lang != LangEnglish {
+		return Palette{}, fmt.Errorf("unsupported language '%s' (must be en, de, fr, or es)", code)
+	}
+	return Palette{Language: lang, Extended: extended}, nil
+}
+
+// ColorName returns the display name of a color in the palette's language
+func (p Palette) ColorName(c Color) string {
+	if names, ok := colorNamesByLanguage[p.Language]; ok && int(c) >= 0 && int(c) < len(names) {
+		return names[c]
+	}
+	return GetColorInfo(c).Name
+}
+
+// ColorName returns the English display name of a color
+func ColorName(c Color) string {
+	return Palette{}.ColorName(c)
+}
+
+// parseLocalizedColor matches a lowercase color name or alias in the palette's language
+func (p Palette) parseLocalizedColor(input string) (Color, bool) {
+	color, ok := localizedColorNameMaps[p.Language][input]
+	return color, ok
+}
@@ -0,0 +1,75 @@
+package decoder
+
+import (
+	"testing"
+)
+
+// TestParseLocalizedColor tests parsing color names in the palette's language
+func TestParseLocalizedColor(t *testing.T) {
+	tests := []struct {
+		lang     string
+		input    string
+		expected Color
+		valid    bool
+	}{
+		{"de", "rot", ColorRed, true},
+		{"de", "Grün", ColorGreen, true},
+		{"de", "gruen", ColorGreen, true},
+		{"de", "grau", ColorGrey, true},
+		{"de", "red", ColorRed, true}, // English always accepted
+		{"de", "rouge", 0, false},     // Other languages are not
+		{"fr", "rouge", ColorRed, true},
+		{"fr", "argent", ColorSilver, true},
+		{"es", "marrón", ColorBrown, true},
+		{"en", "rot", 0, false},
+		{"fr", "Or", ColorGold, true}, // The name wins over the OR abbreviation
+		{"fr", "or", ColorGold, true},
+		{"fr", "bn", ColorBrown, true},
+		{"en", "or", ColorOrange, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.lang+"/"+tt.input, func(t *testing.T) {
+			palette, err := NewPalette(tt.lang, false)
+			if err != nil {
+				t.Fatalf("NewPalette(%q) error = %v", tt.lang, err)
+			}
+			color, valid := palette.ParseColor(tt.input)
+			if valid != tt.valid || (valid && color != tt.expected) {
+				t.Errorf("ParseColor(%q) = %v, %v, want %v, %v", tt.input, color, valid, tt.expected, tt.valid)
+			}
+		})
+	}
+
+	if color, ok := (Palette{Language: LangFrench}).ParseColorAbbrev("or"); ok {
+		t.Errorf("ParseColorAbbrev(\"or\") in French = %v, want no abbreviation", color)
+	}
+}
+
+// TestNewPaletteUnsupported tests rejecting unknown language codes
+func TestNewPaletteUnsupported(t *testing.T) {
+	if _, err := NewPalette("xx", false); err == nil {
+		t.Error("NewPalette(\"xx\") error = nil, want error")
+	}
+}
+
+// TestPaletteExtended tests that only the extended palette parses and lists
+// Pink, while decoding a Pink multiplier does not depend on the palette
+func TestPaletteExtended(t *testing.T) {
+	if _, ok := (Palette{}).ParseColor("pink"); ok {
+		t.Error("standard ParseColor(\"pink\") ok, want rejected")
+	}
+	if n := len(Palette{}.Colors()); n != 12 {
+		t.Errorf("standard Colors() has %d colors, want 12", n)
+	}
+	extended := Palette{Extended: true}
+	if color, ok := extended.ParseColor("PK"); !ok || color != ColorPink {
+		t.Errorf("extended ParseColor(\"PK\") = %v, %v, want Pink", color, ok)
+	}
+	if names := extended.ColorNames(); names[len(names)-1] != "Pink" {
+		t.Errorf("extended ColorNames() ends with %q, want Pink", names[len(names)-1])
+	}
+	if mult, ok := GetResistorMultiplier(ColorPink); !ok || mult != 0.001 {
+		t.Errorf("GetResistorMultiplier(Pink) = %v, %v, want 0.001, true", mult, ok)
+	}
+}
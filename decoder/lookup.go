@@ -0,0 +1,216 @@
+package decoder
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// e12Series is the E12 preferred value series (IEC 60063), one decade
+var e12Series = []float64{1.0, 1.2, 1.5, 1.8, 2.2, 2.7, 3.3, 3.9, 4.7, 5.6, 6.8, 8.2}
+
+// E24Series is the E24 preferred value series (IEC 60063), one decade
+var E24Series = []float64{
+	1.0, 1.1, 1.2, 1.3, 1.5, 1.6, 1.8, 2.0, 2.2, 2.4, 2.7, 3.0,
+	3.3, 3.6, 3.9, 4.3, 4.7, 5.1, 5.6, 6.2, 6.8, 7.5, 8.2, 9.1,
+}
+
+// NearestPreferredValue returns the value in the given E-series closest to v
+// Returns 0 if v is not positive
+func NearestPreferredValue(v float64, series []float64) float64 {
+	if v <= 0 {
+		return 0
+	}
+
+	decade := math.Pow(10, math.Floor(math.Log10(v)))
+	normalized := v / decade
+
+	best := series[0]
+	for _, candidate := range series {
+		if math.Abs(candidate-normalized) < math.Abs(best-normalized) {
+			best = candidate
+		}
+	}
+	// The start of the next decade may be closer (e.g. 9.8 → 10)
+	if math.Abs(10-normalized) < math.Abs(best-normalized) {
+		best = 10
+	}
+
+	// Round away floating point noise from the decade scaling
+	rounded, _ := strconv.ParseFloat(strconv.FormatFloat(best*decade, 'g', 6, 64), 64)
+	return rounded
+}
+
+// NearestStandardCapacitance returns the nearest E12 capacitance in pF
+func NearestStandardCapacitance(pF float64) float64 {
+	return NearestPreferredValue(pF, e12Series)
+}
+
+// BandsFromCapacitance returns the first digit, second digit and multiplier
+// colors that encode a capacitance in pF, using Gold (×0.1) and Silver (×0.01)
+// for values under 10 pF
+// Returns an error if the value needs three significant figures or is outside
+// the multiplier range
+func BandsFromCapacitance(pF float64) ([]Color, error) {
+	if pF <= 0 {
+		return nil, fmt.Errorf("capacitance must be positive")
+	}
+
+	// The decimal multipliers are shared with White and Grey; Gold and Silver
+	// are the usual marking as they cannot be mistaken for a digit
+	multipliers := map[float64]Color{}
+	for _, entry := range AllMultipliers() {
+		if existing, ok := multipliers[entry.Multiplier]; !ok || GetColorInfo(existing).ValidDigit {
+			multipliers[entry.Multiplier] = entry.Color
+		}
+	}
+
+	for _, entry := range AllMultipliers() {
+		if multipliers[entry.Multiplier] != entry.Color {
+			continue
+		}
+		base := pF / entry.Multiplier
+		digits := math.Round(base)
+		if digits < 10 || digits > 99 {
+			continue
+		}
+		if math.Abs(base-digits) > 1e-6*base {
+			return nil, fmt.Errorf("%s needs three significant figures but only two digit bands are available (nearest E12 value %s)",
+				FormatCapacitanceValue(pF), FormatCapacitanceValue(NearestStandardCapacitance(pF)))
+		}
+
+		first, _ := colorForDigit(int(digits) / 10)
+		second, _ := colorForDigit(int(digits) % 10)
+		return []Color{first, second, entry.Color}, nil
+	}
+
+	return nil, fmt.Errorf("%s is outside the capacitor multiplier range", FormatCapacitanceValue(pF))
+}
+
+// colorForDigit returns the color whose digit value is d
+func colorForDigit(d int) (Color, bool) {
+	for _, c := range AllColors() {
+		info := GetColorInfo(c)
+		if info.ValidDigit && info.Digit == d {
+			return c, true
+		}
+	}
+	return 0, false
+}
+
+// CapacitanceToCode returns the 3-digit marking code for a capacitance in pF,
+// e.g. 100000 → "104", 47 → "470", 4.7 → "4R7"
+func CapacitanceToCode(pF float64) (string, error) {
+	if pF <= 0 {
+		return "", fmt.Errorf("capacitance must be positive")
+	}
+
+	// Below 10 pF the decimal point is marked with R
+	if pF < 10 {
+		tenths := math.Round(pF * 10)
+		if math.Abs(pF*10-tenths) > 1e-6 {
+			return "", fmt.Errorf("%s cannot be marked with a 3-digit code", FormatCapacitanceValue(pF))
+		}
+		return fmt.Sprintf("%dR%d", int(tenths)/10, int(tenths)%10), nil
+	}
+
+	for zeros := 0; zeros <= 9; zeros++ {
+		base := pF / math.Pow(10, float64(zeros))
+		digits := math.Round(base)
+		if digits >= 10 && digits <= 99 && math.Abs(base-digits) <= 1e-6*base {
+			return fmt.Sprintf("%d%d", int(digits), zeros), nil
+		}
+	}
+
+	return "", fmt.Errorf("%s cannot be marked with a 3-digit code", FormatCapacitanceValue(pF))
+}
+
+// FormatCapacitanceValue formats a capacitance in pF with auto-scaled units
+func FormatCapacitanceValue(pF float64) string {
+	value, unit := ScaleCapacitance(pF)
+	return strconv.FormatFloat(value, 'f', -1, 64) + " " + unit
+}
+
+// ParseCapacitance parses a capacitance such as "27n", "4n7", "4.7 µF" or
+// "100nF", see ParseEngineeringValue
+// A bare number is taken to be in pF
+func ParseCapacitance(input string) (float64, error) {
+	s := strings.TrimSpace(input)
+	if s == "" {
+		return 0, fmt.Errorf("empty value")
+	}
+
+	pF, err := parseEngineeringNumber(s, input)
+	if err != nil {
+		farads, err := ParseEngineeringValue(s)
+		if err != nil {
+			return 0, err
+		}
+		pF = farads * 1e12
+	}
+
+	if pF <= 0 {
+		return 0, fmt.Errorf("capacitance must be positive")
+	}
+	return pF, nil
+}
+
+// ParseCapacitanceWithType parses a capacitance with an optional capacitor
+// type letter, e.g. "27nF" or "27nF N"; the type defaults to K
+func ParseCapacitanceWithType(input string) (float64, CapacitorType, error) {
+	fields := strings.Fields(input)
+	if len(fields) == 0 || len(fields) > 2 {
+		return 0, "", fmt.Errorf("enter a value and optional type, e.g. 27nF K")
+	}
+
+	pF, err := ParseCapacitance(fields[0])
+	if err != nil {
+		return 0, "", err
+	}
+
+	capType := TypeK
+	if len(fields) == 2 {
+		var ok bool
+		capType, ok = ParseCapacitorType(fields[1])
+		if !ok {
+			return 0, "", fmt.Errorf("invalid capacitor type '%s' (must be J, K, L, M, or N)", fields[1])
+		}
+	}
+	return pF, capType, nil
+}
+
+// CapacitanceLookup is the nearest standard part for a requested capacitance
+type CapacitanceLookup struct {
+	RequestedPF      float64
+	StandardPF       float64
+	DeviationPercent float64 // (standard - requested) / requested
+	Bands            []Color // First digit, second digit, multiplier
+	Code             string  // 3-digit marking code
+}
+
+// LookupCapacitance finds the nearest standard capacitance to a requested
+// value along with its band colors and marking code
+func LookupCapacitance(pF float64) (CapacitanceLookup, error) {
+	if pF <= 0 {
+		return CapacitanceLookup{}, fmt.Errorf("capacitance must be positive")
+	}
+
+	standard := NearestStandardCapacitance(pF)
+	bands, err := BandsFromCapacitance(standard)
+	if err != nil {
+		return CapacitanceLookup{}, err
+	}
+	code, err := CapacitanceToCode(standard)
+	if err != nil {
+		return CapacitanceLookup{}, err
+	}
+
+	return CapacitanceLookup{
+		RequestedPF:      pF,
+		StandardPF:       standard,
+		DeviationPercent: (standard - pF) / pF * 100,
+		Bands:            bands,
+		Code:             code,
+	}, nil
+}
@@ -0,0 +1,167 @@
+package decoder
+
+import (
+	"math"
+	"testing"
+)
+
+// TestNearestStandardCapacitance tests rounding to the E12 series
+func TestNearestStandardCapacitance(t *testing.T) {
+	tests := []struct {
+		pF   float64
+		want float64
+	}{
+		{27000, 27000},
+		{25000, 27000},
+		{4500, 4700},
+		{96, 100},
+		{1.05, 1.0},
+		{0, 0},
+	}
+
+	for _, tt := range tests {
+		if got := NearestStandardCapacitance(tt.pF); got != tt.want {
+			t.Errorf("NearestStandardCapacitance(%v) = %v, want %v", tt.pF, got, tt.want)
+		}
+	}
+}
+
+// TestBandsFromCapacitance tests encoding a value as digit and multiplier bands
+func TestBandsFromCapacitance(t *testing.T) {
+	tests := []struct {
+		pF      float64
+		want    []Color
+		wantErr bool
+	}{
+		{27000, []Color{ColorRed, ColorViolet, ColorOrange}, false},
+		{100000, []Color{ColorBrown, ColorBlack, ColorYellow}, false},
+		{4.7, []Color{ColorYellow, ColorViolet, ColorGold}, false},
+		{0.27, []Color{ColorRed, ColorViolet, ColorSilver}, false},
+		{12345, nil, true},
+		{0, nil, true},
+	}
+
+	for _, tt := range tests {
+		got, err := BandsFromCapacitance(tt.pF)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("BandsFromCapacitance(%v) error = %v, wantErr %v", tt.pF, err, tt.wantErr)
+			continue
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("BandsFromCapacitance(%v) = %v, want %v", tt.pF, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("BandsFromCapacitance(%v) = %v, want %v", tt.pF, got, tt.want)
+				break
+			}
+		}
+	}
+}
+
+// TestCapacitanceToCode tests 3-digit marking codes
+func TestCapacitanceToCode(t *testing.T) {
+	tests := []struct {
+		pF      float64
+		want    string
+		wantErr bool
+	}{
+		{100000, "104", false},
+		{47, "470", false},
+		{4.7, "4R7", false},
+		{1000, "102", false},
+		{12345, "", true},
+		{-1, "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := CapacitanceToCode(tt.pF)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("CapacitanceToCode(%v) error = %v, wantErr %v", tt.pF, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("CapacitanceToCode(%v) = %q, want %q", tt.pF, got, tt.want)
+		}
+	}
+}
+
+// TestParseCapacitance tests parsing capacitances with and without units
+func TestParseCapacitance(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    float64
+		wantErr bool
+	}{
+		{"470", 470, false},
+		{"27n", 27000, false},
+		{"100nF", 100000, false},
+		{"4.7µF", 4.7e6, false},
+		{"4n7", 4700, false},
+		{"", 0, true},
+		{"abc", 0, true},
+		{"0", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseCapacitance(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseCapacitance(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && math.Abs(got-tt.want) > 1e-6*tt.want {
+			t.Errorf("ParseCapacitance(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
+
+// TestParseCapacitanceWithType tests the optional type after a capacitance
+func TestParseCapacitanceWithType(t *testing.T) {
+	tests := []struct {
+		input    string
+		wantPF   float64
+		wantType CapacitorType
+		wantErr  bool
+	}{
+		{"27nF", 27000, TypeK, false},
+		{"100p n", 100, TypeN, false},
+		{"1uF Z", 0, "", true},
+		{"", 0, "", true},
+	}
+
+	for _, tt := range tests {
+		pF, capType, err := ParseCapacitanceWithType(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseCapacitanceWithType(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && (math.Abs(pF-tt.wantPF) > 1e-6*tt.wantPF || capType != tt.wantType) {
+			t.Errorf("ParseCapacitanceWithType(%q) = %v, %v, want %v, %v", tt.input, pF, capType, tt.wantPF, tt.wantType)
+		}
+	}
+}
+
+// TestLookupCapacitance tests the combined nearest value, bands and code
+func TestLookupCapacitance(t *testing.T) {
+	lookup, err := LookupCapacitance(25000)
+	if err != nil {
+		t.Fatalf("LookupCapacitance(25000) error = %v", err)
+	}
+	if lookup.StandardPF != 27000 {
+		t.Errorf("StandardPF = %v, want 27000", lookup.StandardPF)
+	}
+	if lookup.Code != "273" {
+		t.Errorf("Code = %q, want %q", lookup.Code, "273")
+	}
+	if math.Abs(lookup.DeviationPercent-8) > 1e-9 {
+		t.Errorf("DeviationPercent = %v, want 8", lookup.DeviationPercent)
+	}
+	if len(lookup.Bands) != 3 || lookup.Bands[2] != ColorOrange {
+		t.Errorf("Bands = %v, want red, violet, orange", lookup.Bands)
+	}
+
+	if _, err := LookupCapacitance(0); err == nil {
+		t.Error("LookupCapacitance(0) expected error")
+	}
+}
@@ -0,0 +1,57 @@
+package decoder
+
+import (
+	"fmt"
+	"math"
+)
+
+// e96Digits holds the three significant digits of each E96 value
+var e96Digits = digitSet(
+	100, 102, 105, 107, 110, 113, 115, 118, 121, 124, 127, 130,
+	133, 137, 140, 143, 147, 150, 154, 158, 162, 165, 169, 174,
+	178, 182, 187, 191, 196, 200, 205, 210, 215, 221, 226, 232,
+	237, 243, 249, 255, 261, 267, 274, 280, 287, 294, 301, 309,
+	316, 324, 332, 340, 348, 357, 365, 374, 383, 392, 402, 412,
+	422, 432, 442, 453, 464, 475, 487, 499, 511, 523, 536, 549,
+	562, 576, 590, 604, 619, 634, 649, 665, 681, 698, 715, 732,
+	750, 768, 787, 806, 825, 845, 866, 887, 909, 931, 953, 976,
+)
+
+// digitSet builds a lookup set of significant digit values
+func digitSet(values ...int) map[int]bool {
+	set := make(map[int]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
+
+// preferredValueTolerancePercent is how far, in percent, a decoded value may
+// be from the nearest preferred value before it is flagged as unusual. Any
+// two-digit value off the E24 series is at least 1% away from it.
+const preferredValueTolerancePercent = 0.5
+
+// preferredValueNote compares a value with the nearest E24 value, or for
+// three significant digits the nearest E24 or E96 value, and returns a note
+// when it is further off than preferredValueTolerancePercent, or "" when it
+// is a standard value. 0 is never flagged; it is a valid zero-ohm part.
+func preferredValueNote(value float64, threeDigit bool, format func(float64) string) string {
+	if value <= 0 {
+		return ""
+	}
+
+	series := "E24"
+	nearest := NearestPreferredValue(value, E24Series)
+	if threeDigit {
+		series = "E24 or E96"
+		if e96 := NearestPreferredValue(value, e96Series()); math.Abs(e96-value) < math.Abs(nearest-value) {
+			nearest = e96
+		}
+	}
+
+	if math.Abs(value-nearest)/nearest*100 <= preferredValueTolerancePercent {
+		return ""
+	}
+	return fmt.Sprintf("Unusual value — %s is not a standard %s value (nearest %s); double-check band order",
+		format(value), series, format(nearest))
+}
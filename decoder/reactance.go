@@ -0,0 +1,88 @@
+package decoder
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// CapacitiveReactance returns the reactance in ohms (Xc = 1/(2πfC)) of a
+// capacitance in picofarads at the given frequency
+// Returns false if either value is not positive
+func CapacitiveReactance(pF float64, freqHz float64) (float64, bool) {
+	if pF <= 0 || freqHz <= 0 {
+		return 0, false
+	}
+	farads := pF * 1e-12
+	return 1 / (2 * math.Pi * freqHz * farads), true
+}
+
+// FormatReactance formats a reactance in ohms with auto-scaled units
+func FormatReactance(ohms float64) string {
+	value, unit := ScaleResistance(ohms)
+	return FormatResistance(value, unit)
+}
+
+// siPrefixes maps SI prefix characters to their multipliers
+var siPrefixes = map[byte]float64{
+	'p': 1e-12,
+	'n': 1e-9,
+	'u': 1e-6,
+	'm': 1e-3,
+	'k': 1e3,
+	'K': 1e3,
+	'M': 1e6,
+	'G': 1e9,
+}
+
+// ParseSIValue parses a number with an optional SI prefix and unit suffix
+// (e.g. "10k", "2.2 MHz", "470n" with unit "Hz"/"F")
+func ParseSIValue(input string, unit string) (float64, error) {
+	s := strings.TrimSpace(input)
+	s = strings.TrimSuffix(s, unit)
+	s = strings.TrimSuffix(s, strings.ToLower(unit))
+	s = strings.TrimSpace(s)
+	s = strings.Replace(s, "µ", "u", 1)
+	if s == "" {
+		return 0, fmt.Errorf("empty value")
+	}
+
+	multiplier := 1.0
+	if m, ok := siPrefixes[s[len(s)-1]]; ok {
+		multiplier = m
+		s = strings.TrimSpace(s[:len(s)-1])
+	}
+
+	value, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid number: '%s'", input)
+	}
+	return value * multiplier, nil
+}
+
+// ParseFrequency parses a frequency such as "1000", "10k", "1.5 MHz"
+func ParseFrequency(input string) (float64, error) {
+	hz, err := ParseSIValue(input, "Hz")
+	if err != nil {
+		return 0, err
+	}
+	if hz <= 0 {
+		return 0, fmt.Errorf("frequency must be positive")
+	}
+	return hz, nil
+}
+
+// FormatFrequency formats a frequency with auto-scaled units (Hz, kHz, MHz, GHz)
+func FormatFrequency(hz float64) string {
+	switch {
+	case hz >= 1e9:
+		return strconv.FormatFloat(hz/1e9, 'f', -1, 64) + " GHz"
+	case hz >= 1e6:
+		return strconv.FormatFloat(hz/1e6, 'f', -1, 64) + " MHz"
+	case hz >= 1e3:
+		return strconv.FormatFloat(hz/1e3, 'f', -1, 64) + " kHz"
+	default:
+		return strconv.FormatFloat(hz, 'f', -1, 64) + " Hz"
+	}
+}
@@ -0,0 +1,52 @@
+package decoder
+
+import (
+	"math"
+	"testing"
+)
+
+// TestCapacitiveReactance tests Xc = 1/(2πfC)
+func TestCapacitiveReactance(t *testing.T) {
+	// 100 nF at 1 kHz ≈ 1591.55 Ω
+	xc, ok := CapacitiveReactance(100000, 1000)
+	if !ok {
+		t.Fatal("CapacitiveReactance() ok = false, want true")
+	}
+	if math.Abs(xc-1591.549) > 0.01 {
+		t.Errorf("CapacitiveReactance() = %v, want ≈1591.549", xc)
+	}
+
+	if _, ok := CapacitiveReactance(0, 1000); ok {
+		t.Error("CapacitiveReactance() with 0 pF ok = true, want false")
+	}
+}
+
+// TestParseFrequency tests frequency parsing with SI prefixes
+func TestParseFrequency(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected float64
+		valid    bool
+	}{
+		{"1000", 1000, true},
+		{"10k", 10000, true},
+		{"10kHz", 10000, true},
+		{"1.5 MHz", 1500000, true},
+		{"60 Hz", 60, true},
+		{"0", 0, false},
+		{"abc", 0, false},
+		{"", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			hz, err := ParseFrequency(tt.input)
+			if (err == nil) != tt.valid {
+				t.Errorf("ParseFrequency(%q) error = %v, valid = %v", tt.input, err, tt.valid)
+			}
+			if tt.valid && hz != tt.expected {
+				t.Errorf("ParseFrequency(%q) = %v, want %v", tt.input, hz, tt.expected)
+			}
+		})
+	}
+}
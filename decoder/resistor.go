@@ -0,0 +1,1080 @@
+package decoder
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ComponentType distinguishes between capacitors and resistors
+type ComponentType int
+
+const (
+	ComponentCapacitor ComponentType = iota
+	ComponentResistor
+)
+
+// ResistorReading represents parsed resistor bands
+type ResistorReading struct {
+	Band1     Color // First digit
+	Band2     Color // Second digit
+	Band3     Color // Third digit (5/6-band) or Multiplier (4-band)
+	Band4     Color // Multiplier (5/6-band) or Tolerance (4-band)
+	Band5     Color // Tolerance (5/6-band)
+	Band6     Color // Temperature coefficient (6-band only)
+	BandCount int   // 4, 5, or 6
+
+	// Military marks a 5-band military part: a 4-band reading followed by a
+	// reliability band, so Band3 is the multiplier, Band4 the tolerance and
+	// Band5 the failure rate
+	Military bool
+}
+
+// Equal reports whether two readings decode the same part: same band count,
+// layout and colors. Bands beyond the band count are ignored.
+func (r ResistorReading) Equal(other ResistorReading) bool {
+	if r.BandCount != other.BandCount || r.Military != other.Military ||
+		r.Band1 != other.Band1 || r.Band2 != other.Band2 ||
+		r.Band3 != other.Band3 || r.Band4 != other.Band4 {
+		return false
+	}
+	if r.BandCount >= 5 && r.Band5 != other.Band5 {
+		return false
+	}
+	if r.BandCount == 6 && r.Band6 != other.Band6 {
+		return false
+	}
+	return true
+}
+
+// Colors returns the bands of the reading in order, up to its band count
+func (r ResistorReading) Colors() []Color {
+	colors := []Color{r.Band1, r.Band2, r.Band3, r.Band4, r.Band5, r.Band6}
+	return colors[:min(max(r.BandCount, 0), len(colors))]
+}
+
+// Configuration describes the band layout, e.g. "4-band" or "5-band military"
+func (r ResistorReading) Configuration() string {
+	if r.Military {
+		return fmt.Sprintf("%d-band military", r.BandCount)
+	}
+	return fmt.Sprintf("%d-band", r.BandCount)
+}
+
+// BandName returns the name of a band in the reading's layout
+func (r ResistorReading) BandName(bandNum int) string {
+	if r.Military {
+		if bandNum == 5 {
+			return "Reliability"
+		}
+		return GetResistorBandName(bandNum, 4)
+	}
+	return GetResistorBandName(bandNum, r.BandCount)
+}
+
+// BandDescription returns a description of a band in the reading's layout
+func (r ResistorReading) BandDescription(bandNum int) string {
+	if r.Military {
+		if bandNum == 5 {
+			return "Failure rate (%/1000 h)"
+		}
+		return GetResistorBandDescription(bandNum, 4)
+	}
+	return GetResistorBandDescription(bandNum, r.BandCount)
+}
+
+// defaultResistorTempCoeff is the temperature coefficient band used when
+// solving 6-band bands from a value (Brown, 100 ppm/°C, the most common)
+const defaultResistorTempCoeff = ColorBrown
+
+// ResistorBandsFromValue returns the bands that encode a resistance with the
+// given tolerance on a 4, 5 or 6-band resistor. 6-band readings use a Brown
+// (100 ppm/°C) temperature coefficient band. A 0 Ω resistor is a single Black
+// band, returned with a band count of 1.
+// Returns an error if the value needs more significant digits than the band
+// count allows, is outside the multiplier range, or the tolerance has no color.
+func ResistorBandsFromValue(ohms float64, tolerancePercent float64, bandCount int) (ResistorReading, error) {
+	if err := ValidateResistorBandCount(bandCount); err != nil {
+		return ResistorReading{}, err
+	}
+	if ohms < 0 {
+		return ResistorReading{}, fmt.Errorf("resistance cannot be negative")
+	}
+	if ohms == 0 {
+		return ResistorReading{Band1: ColorBlack, BandCount: 1}, nil
+	}
+
+	tolerance, ok := resistorToleranceColor(tolerancePercent)
+	if !ok {
+		var valid []string
+		for _, e := range AllResistorTolerances() {
+			valid = append(valid, strconv.FormatFloat(e.Tolerance.Percent, 'f', -1, 64)+"%")
+		}
+		return ResistorReading{}, fmt.Errorf("no tolerance band for ±%s%% (must be one of %s)",
+			strconv.FormatFloat(tolerancePercent, 'f', -1, 64), strings.Join(valid, ", "))
+	}
+
+	digitCount := 2
+	if bandCount >= 5 {
+		digitCount = 3
+	}
+	digits, multiplier, err := resistorDigits(ohms, digitCount)
+	if err != nil {
+		return ResistorReading{}, err
+	}
+
+	colors := make([]Color, 0, bandCount)
+	for _, d := range digits {
+		c, _ := colorForDigit(d)
+		colors = append(colors, c)
+	}
+	colors = append(colors, multiplier, tolerance)
+	if bandCount == 6 {
+		colors = append(colors, defaultResistorTempCoeff)
+	}
+	return ResistorReadingFromColors(colors)
+}
+
+// maxResistorReadings caps the readings listed by AllResistorReadingsFor
+const maxResistorReadings = 48
+
+// AllResistorReadingsFor lists every band combination of a resistor with the
+// given band count that decodes to a resistance: the digit and multiplier
+// bands (Gold and Silver for values under 10 Ω), with each tolerance band and,
+// on 6 bands, each temperature coefficient band. Readings are listed in table
+// order, without duplicates, up to maxResistorReadings.
+// Returns nil if the value is not positive, needs more digits than the band
+// count holds, or the band count is invalid.
+func AllResistorReadingsFor(ohms float64, bandCount int) []ResistorReading {
+	if ohms <= 0 || ValidateResistorBandCount(bandCount) != nil {
+		return nil
+	}
+
+	digitCount := 2
+	if bandCount >= 5 {
+		digitCount = 3
+	}
+	low := math.Pow(10, float64(digitCount-1))
+	high := math.Pow(10, float64(digitCount)) - 1
+
+	tempCoeffs := []Color{0} // Unused below 6 bands
+	if bandCount == 6 {
+		tempCoeffs = nil
+		for _, entry := range AllResistorTempCoefficients() {
+			tempCoeffs = append(tempCoeffs, entry.Color)
+		}
+	}
+
+	var readings []ResistorReading
+	for _, multiplier := range AllResistorMultipliers() {
+		base := ohms / multiplier.Multiplier
+		rounded := math.Round(base)
+		if rounded < low || rounded > high || math.Abs(base-rounded) > 1e-6*base {
+			continue
+		}
+
+		var digits []Color
+		for n, i := int(rounded), 0; i < digitCount; i, n = i+1, n/10 {
+			c, _ := colorForDigit(n % 10)
+			digits = append([]Color{c}, digits...)
+		}
+
+		for _, tolerance := range AllResistorTolerances() {
+			for _, tempCoeff := range tempCoeffs {
+				colors := append(slices.Clone(digits), multiplier.Color, tolerance.Color)
+				if bandCount == 6 {
+					colors = append(colors, tempCoeff)
+				}
+				reading, err := ResistorReadingFromColors(colors)
+				if err != nil || ValidateResistorReading(&reading) != nil ||
+					slices.ContainsFunc(readings, reading.Equal) {
+					continue
+				}
+				readings = append(readings, reading)
+				if len(readings) == maxResistorReadings {
+					return readings
+				}
+			}
+		}
+	}
+
+	return readings
+}
+
+// ParseResistance parses a resistance such as "4.7k", "4k7", "470R",
+// "470 Ω", "1M" or "220", see ParseEngineeringValue
+// A bare number is taken to be in Ω
+func ParseResistance(input string) (float64, error) {
+	return ParseEngineeringValue(input)
+}
+
+// ParseResistanceWithTolerance parses a resistance with an optional
+// tolerance such as "4.7k 5%", "10M ±1%" or "220" (±5% when omitted)
+func ParseResistanceWithTolerance(input string) (float64, float64, error) {
+	fields := strings.Fields(strings.ReplaceAll(input, "±", " "))
+	if len(fields) == 0 || len(fields) > 2 {
+		return 0, 0, fmt.Errorf("enter a value and optional tolerance, e.g. 4.7k 5%%")
+	}
+
+	ohms, err := ParseResistance(fields[0])
+	if err != nil {
+		return 0, 0, err
+	}
+
+	tolerance := 5.0
+	if len(fields) == 2 {
+		tolerance, err = strconv.ParseFloat(strings.TrimSuffix(fields[1], "%"), 64)
+		if err != nil || tolerance <= 0 {
+			return 0, 0, fmt.Errorf("invalid tolerance: '%s'", fields[1])
+		}
+	}
+	return ohms, tolerance, nil
+}
+
+// resistorToleranceColor returns the tolerance band color for a percentage
+func resistorToleranceColor(percent float64) (Color, bool) {
+	for _, e := range AllResistorTolerances() {
+		if ApproxEqual(e.Tolerance.Percent, percent) {
+			return e.Color, true
+		}
+	}
+	return 0, false
+}
+
+// resistorDigits splits a resistance into significant digits and a
+// multiplier color. Returns an error naming the nearest value that fits when
+// the resistance needs more digits than digitCount.
+func resistorDigits(ohms float64, digitCount int) ([]int, Color, error) {
+	low := math.Pow(10, float64(digitCount-1))
+	high := math.Pow(10, float64(digitCount)) - 1
+
+	for _, entry := range AllResistorMultipliers() {
+		base := ohms / entry.Multiplier
+		rounded := math.Round(base)
+		if rounded < low || rounded > high {
+			continue
+		}
+		if math.Abs(base-rounded) > 1e-6*base {
+			// The value has more significant digits than the bands hold
+			suggestion := "use 5 or 6 bands, or the nearest E24 value " +
+				FormatResistanceValue(NearestPreferredValue(ohms, E24Series))
+			if digitCount == 3 {
+				suggestion = "use the nearest E96 value " +
+					FormatResistanceValue(NearestPreferredValue(ohms, e96Series()))
+			}
+			return nil, 0, fmt.Errorf("%s needs more than %d significant digits; %s",
+				FormatResistanceValue(ohms), digitCount, suggestion)
+		}
+
+		digits := make([]int, digitCount)
+		n := int(rounded)
+		for i := digitCount - 1; i >= 0; i-- {
+			digits[i] = n % 10
+			n /= 10
+		}
+		return digits, entry.Color, nil
+	}
+
+	return nil, 0, fmt.Errorf("%s is outside the range of the multiplier bands", FormatResistanceValue(ohms))
+}
+
+// e96Series returns the E96 preferred value series, one decade
+func e96Series() []float64 {
+	series := make([]float64, 0, len(e96Digits))
+	for digits := range e96Digits {
+		series = append(series, float64(digits)/100)
+	}
+	slices.Sort(series)
+	return series
+}
+
+// ResistorReadingFromColors builds a reading from bands listed in order.
+// A single Black band is a zero-ohm jumper with a band count of 1.
+func ResistorReadingFromColors(colors []Color) (ResistorReading, error) {
+	if len(colors) == 1 && colors[0] == ColorBlack {
+		return ResistorReading{Band1: ColorBlack, BandCount: 1}, nil
+	}
+	if err := ValidateResistorBandCount(len(colors)); err != nil {
+		return ResistorReading{}, err
+	}
+
+	reading := ResistorReading{
+		Band1:     colors[0],
+		Band2:     colors[1],
+		Band3:     colors[2],
+		Band4:     colors[3],
+		BandCount: len(colors),
+	}
+	if len(colors) >= 5 {
+		reading.Band5 = colors[4]
+	}
+	if len(colors) == 6 {
+		reading.Band6 = colors[5]
+	}
+	return reading, nil
+}
+
+// ErrAmbiguousBandCount is returned by InferResistorBandCount when the bands
+// fit more than one layout and the user should confirm the count
+var ErrAmbiguousBandCount = errors.New("ambiguous band count")
+
+// InferResistorBandCount works out whether bands entered without a band count
+// are a 4, 5 or 6-band resistor from the role of the last bands: Gold or
+// Silver in band 4 of four is a 4-band tolerance, a fifth band is a 5-band
+// tolerance and a sixth a temperature coefficient.
+// When the layout is ambiguous it returns the likelier count along with an
+// error wrapping ErrAmbiguousBandCount; other errors return 0.
+func InferResistorBandCount(colors []Color) (int, error) {
+	if len(colors) < 4 || len(colors) > 6 {
+		return 0, fmt.Errorf("enter 4 to 6 bands to infer the band count, got %d", len(colors))
+	}
+	reading, err := ResistorReadingFromColors(colors)
+	if err != nil {
+		return 0, err
+	}
+	if err := ValidateResistorReading(&reading); err != nil {
+		return 0, err
+	}
+
+	count := len(colors)
+	band4 := colors[3]
+	goldOrSilver := band4 == ColorGold || band4 == ColorSilver
+	switch {
+	case count == 4 && !goldOrSilver:
+		// A precision 4-band part, or a 5-band part whose tolerance band was missed
+		return count, fmt.Errorf("%w: %s in band 4 could be the tolerance of a 4-band resistor or the multiplier of a 5-band resistor with a band missing",
+			ErrAmbiguousBandCount, GetColorInfo(band4).Name)
+	case count == 5 && goldOrSilver:
+		// Military 4-band parts add a fifth reliability band after the tolerance
+		return count, fmt.Errorf("%w: %s in band 4 could be the tolerance of a 4-band resistor with a reliability band or the multiplier of a 5-band resistor",
+			ErrAmbiguousBandCount, GetColorInfo(band4).Name)
+	}
+	return count, nil
+}
+
+// ReverseResistorReading returns the reading with its bands in the opposite
+// order, for a resistor that was read from the wrong end
+func ReverseResistorReading(reading ResistorReading) (ResistorReading, error) {
+	colors := []Color{reading.Band1, reading.Band2, reading.Band3, reading.Band4, reading.Band5, reading.Band6}
+	if reading.BandCount < 1 || reading.BandCount > len(colors) {
+		return reading, fmt.Errorf("invalid band count: %d", reading.BandCount)
+	}
+	colors = colors[:reading.BandCount]
+	slices.Reverse(colors)
+	reversed, err := ResistorReadingFromColors(colors)
+	reversed.Military = reading.Military
+	return reversed, err
+}
+
+// ReversedReading returns the reading with its bands reversed, and whether
+// that is also a valid reading of a different part, i.e. the resistor could
+// have been read from either end. A reversed reading starting with Black is
+// not counted, as no resistor is marked with a leading zero.
+func ReversedReading(r ResistorReading) (ResistorReading, bool) {
+	reversed, err := ReverseResistorReading(r)
+	if err != nil || ValidateResistorReading(&reversed) != nil ||
+		reversed.Band1 == ColorBlack || reversed.Equal(r) {
+		return reversed, false
+	}
+	return reversed, true
+}
+
+// ResistorResult contains calculated resistor values
+type ResistorResult struct {
+	ResistanceOhms  float64 // Raw value in ohms
+	ResistanceValue float64 // Scaled value
+	ResistanceUnit  string  // Ω, kΩ, MΩ, or GΩ
+
+	TolerancePercent float64 // Tolerance in % (the + side when asymmetric)
+	ToleranceHigh    float64 // +% (equal to ToleranceLow when symmetric)
+	ToleranceLow     float64 // -%
+	MinValue         float64 // Min resistance
+	MaxValue         float64 // Max resistance
+	MinUnit          string
+	MaxUnit          string
+
+	TempCoefficient int // ppm/°C (6-band only)
+	TempCoeffValid  bool
+
+	FailureRatePercent float64 // %/1000 h (5-band military only)
+	FailureRateValid   bool
+
+	Plausible        bool   // Near a standard E24 (or E96) value, see preferredValueNote
+	PlausibilityNote string // Why the value looks like a misread, if not plausible
+
+	Jumper bool // A 0 Ω jumper (zero-ohm link), which has no tolerance range
+
+	Reading ResistorReading
+}
+
+// IsZeroOhmJumper reports whether a reading marks a 0 Ω jumper: a single
+// Black band, or Black digit bands with a Black multiplier
+func IsZeroOhmJumper(reading ResistorReading) bool {
+	var marking []Color
+	switch {
+	case reading.BandCount == 1:
+		marking = []Color{reading.Band1}
+	case reading.BandCount == 4, reading.Military:
+		marking = []Color{reading.Band1, reading.Band2, reading.Band3}
+	case reading.BandCount == 5, reading.BandCount == 6:
+		marking = []Color{reading.Band1, reading.Band2, reading.Band3, reading.Band4}
+	default:
+		return false
+	}
+	for _, color := range marking {
+		if color != ColorBlack {
+			return false
+		}
+	}
+	return true
+}
+
+// ResistorToleranceInfo represents tolerance specifications for resistors
+type ResistorToleranceInfo struct {
+	Percent     float64
+	PercentHigh float64 // +% when asymmetric (0 = Percent)
+	PercentLow  float64 // -% when asymmetric (0 = Percent)
+	Name        string
+}
+
+// Bounds returns the + and - tolerance in percent, both Percent unless the
+// tolerance is asymmetric
+func (t ResistorToleranceInfo) Bounds() (high, low float64) {
+	if t.PercentHigh == 0 && t.PercentLow == 0 {
+		return t.Percent, t.Percent
+	}
+	return t.PercentHigh, t.PercentLow
+}
+
+// Symmetric reports whether the tolerance is the same on both sides
+func (t ResistorToleranceInfo) Symmetric() bool {
+	high, low := t.Bounds()
+	return high == low
+}
+
+// resistorToleranceMap maps colors to resistor tolerance values
+var resistorToleranceMap = map[Color]ResistorToleranceInfo{
+	ColorBrown: {
+		Percent: 1,
+		Name:    "±1% (precision)",
+	},
+	ColorRed: {
+		Percent: 2,
+		Name:    "±2% (precision)",
+	},
+	ColorGreen: {
+		Percent: 0.5,
+		Name:    "±0.5% (high precision)",
+	},
+	ColorBlue: {
+		Percent: 0.25,
+		Name:    "±0.25% (high precision)",
+	},
+	ColorViolet: {
+		Percent: 0.1,
+		Name:    "±0.1% (ultra precision)",
+	},
+	ColorGrey: {
+		Percent: 0.05,
+		Name:    "±0.05% (ultra precision)",
+	},
+	ColorGold: {
+		Percent: 5,
+		Name:    "±5% (standard)",
+	},
+	ColorSilver: {
+		Percent: 10,
+		Name:    "±10% (standard)",
+	},
+}
+
+// resistorTempCoefficientMap maps colors to temperature coefficients (ppm/°C)
+var resistorTempCoefficientMap = map[Color]int{
+	ColorBlack:  250,
+	ColorBrown:  100,
+	ColorRed:    50,
+	ColorOrange: 15,
+	ColorYellow: 25,
+	ColorGreen:  20,
+	ColorBlue:   10,
+	ColorViolet: 5,
+	ColorGrey:   1,
+}
+
+// reliabilityMap maps the fifth band of a military resistor to its failure
+// rate in % per 1000 hours
+var reliabilityMap = map[Color]float64{
+	ColorBrown:  1,
+	ColorRed:    0.1,
+	ColorOrange: 0.01,
+	ColorYellow: 0.001,
+}
+
+// resistorMultiplierMap extends the colorMap multipliers with resistor-specific values
+// Resistors use the same multipliers but with Gold=0.1 and Silver=0.01
+var resistorMultiplierMap = map[Color]float64{
+	ColorBlack:  1,
+	ColorBrown:  10,
+	ColorRed:    100,
+	ColorOrange: 1000,
+	ColorYellow: 10000,
+	ColorGreen:  100000,
+	ColorBlue:   1000000,
+	ColorViolet: 10000000,
+	ColorGrey:   100000000,
+	ColorWhite:  1000000000,
+	ColorGold:   0.1,
+	ColorSilver: 0.01,
+	ColorPink:   0.001, // Extended color set only
+}
+
+// CalculateResistor performs all calculations for a resistor reading
+func CalculateResistor(reading ResistorReading) (*ResistorResult, error) {
+	if reading.Military {
+		return calculateMilitaryResistor(reading)
+	}
+
+	// A single Black band has no tolerance band to read
+	if reading.BandCount == 1 {
+		if !IsZeroOhmJumper(reading) {
+			return nil, fmt.Errorf("a single band must be Black, for a zero-ohm jumper")
+		}
+		return &ResistorResult{
+			ResistanceUnit: "Ω",
+			MinUnit:        "Ω",
+			MaxUnit:        "Ω",
+			Plausible:      true,
+			Jumper:         true,
+			Reading:        reading,
+		}, nil
+	}
+
+	result := &ResistorResult{
+		Reading: reading,
+		Jumper:  IsZeroOhmJumper(reading),
+	}
+
+	var baseValue float64
+	var multiplierColor Color
+
+	// Calculate base value based on band count
+	switch reading.BandCount {
+	case 4:
+		// 4-band: Band1 Band2 Multiplier Tolerance
+		info1 := GetColorInfo(reading.Band1)
+		info2 := GetColorInfo(reading.Band2)
+
+		if !info1.ValidDigit || !info2.ValidDigit {
+			return nil, fmt.Errorf("invalid digit colors for bands 1 or 2")
+		}
+
+		baseValue = float64(info1.Digit*10 + info2.Digit)
+		multiplierColor = reading.Band3
+
+	case 5:
+		// 5-band: Band1 Band2 Band3 Multiplier Tolerance
+		info1 := GetColorInfo(reading.Band1)
+		info2 := GetColorInfo(reading.Band2)
+		info3 := GetColorInfo(reading.Band3)
+
+		if !info1.ValidDigit || !info2.ValidDigit || !info3.ValidDigit {
+			return nil, fmt.Errorf("invalid digit colors for bands 1, 2, or 3")
+		}
+
+		baseValue = float64(info1.Digit*100 + info2.Digit*10 + info3.Digit)
+		multiplierColor = reading.Band4
+
+	case 6:
+		// 6-band: Band1 Band2 Band3 Multiplier Tolerance TempCoeff
+		info1 := GetColorInfo(reading.Band1)
+		info2 := GetColorInfo(reading.Band2)
+		info3 := GetColorInfo(reading.Band3)
+
+		if !info1.ValidDigit || !info2.ValidDigit || !info3.ValidDigit {
+			return nil, fmt.Errorf("invalid digit colors for bands 1, 2, or 3")
+		}
+
+		baseValue = float64(info1.Digit*100 + info2.Digit*10 + info3.Digit)
+		multiplierColor = reading.Band4
+
+		// Get temperature coefficient for 6-band
+		tempCoeff, valid := GetResistorTempCoefficient(reading.Band6)
+		result.TempCoefficient = tempCoeff
+		result.TempCoeffValid = valid
+
+	default:
+		return nil, fmt.Errorf("invalid band count: %d (must be 4, 5, or 6)", reading.BandCount)
+	}
+
+	// Get multiplier
+	multiplier, valid := GetResistorMultiplier(multiplierColor)
+	if !valid {
+		return nil, fmt.Errorf("invalid multiplier color")
+	}
+
+	// Calculate resistance in ohms
+	result.ResistanceOhms = baseValue * multiplier
+
+	// Auto-scale to appropriate unit
+	result.ResistanceValue, result.ResistanceUnit = ScaleResistance(result.ResistanceOhms)
+
+	// Get tolerance
+	toleranceColor := reading.Band4
+	if reading.BandCount >= 5 {
+		toleranceColor = reading.Band5
+	}
+
+	tolInfo, exists := GetResistorTolerance(toleranceColor)
+	if !exists {
+		return nil, fmt.Errorf("invalid tolerance color")
+	}
+
+	result.ToleranceHigh, result.ToleranceLow = tolInfo.Bounds()
+	result.TolerancePercent = result.ToleranceHigh
+
+	// Calculate min/max values
+	result.MinValue = result.ResistanceOhms * (1 - result.ToleranceLow/100)
+	result.MaxValue = result.ResistanceOhms * (1 + result.ToleranceHigh/100)
+
+	// Scale min/max to appropriate units
+	result.MinValue, result.MinUnit = ScaleResistance(result.MinValue)
+	result.MaxValue, result.MaxUnit = ScaleResistance(result.MaxValue)
+
+	// Precision parts below 1% come in E192 and custom values, so only check
+	// the common 1% and looser series
+	if result.TolerancePercent >= 1 {
+		result.PlausibilityNote = preferredValueNote(result.ResistanceOhms, reading.BandCount >= 5, FormatResistanceValue)
+	}
+	result.Plausible = result.PlausibilityNote == ""
+
+	return result, nil
+}
+
+// calculateMilitaryResistor decodes a 5-band military reading as the 4-band
+// reading of its first bands plus the failure rate of the reliability band
+func calculateMilitaryResistor(reading ResistorReading) (*ResistorResult, error) {
+	if reading.BandCount != 5 {
+		return nil, fmt.Errorf("invalid band count for a military resistor: %d (must be 5)", reading.BandCount)
+	}
+
+	fourBand := reading
+	fourBand.Military = false
+	fourBand.BandCount = 4
+	result, err := CalculateResistor(fourBand)
+	if err != nil {
+		return nil, err
+	}
+
+	rate, valid := GetReliabilityBand(reading.Band5)
+	if !valid {
+		return nil, fmt.Errorf("invalid reliability color")
+	}
+	result.FailureRatePercent = rate
+	result.FailureRateValid = true
+	result.Reading = reading
+	return result, nil
+}
+
+// ScaleResistance converts ohms to the most appropriate unit
+// Returns value and unit as separate values
+func ScaleResistance(ohms float64) (float64, string) {
+	// Conversion factors
+	const (
+		ohmsToKOhms = 1000.0       // 1 kΩ = 1,000 Ω
+		ohmsToMOhms = 1000000.0    // 1 MΩ = 1,000,000 Ω
+		ohmsToGOhms = 1000000000.0 // 1 GΩ = 1,000,000,000 Ω
+	)
+
+	// Try GΩ (gigohms)
+	if ohms >= ohmsToGOhms {
+		return ohms / ohmsToGOhms, "GΩ"
+	}
+
+	// Try MΩ (megohms)
+	if ohms >= ohmsToMOhms {
+		return ohms / ohmsToMOhms, "MΩ"
+	}
+
+	// Try kΩ (kilohms)
+	if ohms >= ohmsToKOhms {
+		return ohms / ohmsToKOhms, "kΩ"
+	}
+
+	// Use Ω (ohms)
+	return ohms, "Ω"
+}
+
+// GetResistorMultiplier returns the multiplier for a given color
+func GetResistorMultiplier(c Color) (float64, bool) {
+	mult, exists := resistorMultiplierMap[c]
+	return mult, exists
+}
+
+// GetResistorTolerance returns tolerance information for a color
+func GetResistorTolerance(c Color) (ResistorToleranceInfo, bool) {
+	info, exists := resistorToleranceMap[c]
+	return info, exists
+}
+
+// GetResistorTempCoefficient returns temperature coefficient for a color (6-band resistors)
+func GetResistorTempCoefficient(c Color) (int, bool) {
+	coeff, exists := resistorTempCoefficientMap[c]
+	return coeff, exists
+}
+
+// GetReliabilityBand returns the failure rate in % per 1000 hours of a
+// military resistor's reliability band
+func GetReliabilityBand(c Color) (float64, bool) {
+	rate, ok := reliabilityMap[c]
+	return rate, ok
+}
+
+// SeriesResistance returns the total of resistors in series (R = R1 + R2 + ...)
+func SeriesResistance(ohms ...float64) float64 {
+	return CombineResistorsSeries(ohms)
+}
+
+// ParallelResistance returns the total of resistors in parallel (1/R = 1/R1 + 1/R2 + ...)
+// Returns 0 if there are no values or any value is not positive
+func ParallelResistance(ohms ...float64) float64 {
+	return CombineResistorsParallel(ohms)
+}
+
+// CombineResistorsSeries returns the equivalent of resistors in series, in Ω
+func CombineResistorsSeries(vals []float64) float64 {
+	total := 0.0
+	for _, r := range vals {
+		total += r
+	}
+	return total
+}
+
+// CombineResistorsParallel returns the equivalent of resistors in parallel,
+// in Ω. A 0 Ω term shorts the others, so the result is 0 rather than a
+// division by zero; it is also 0 for no values or a negative value.
+func CombineResistorsParallel(vals []float64) float64 {
+	return reciprocalSum(vals)
+}
+
+// powerRatings are the common resistor power ratings in W, smallest first
+var powerRatings = []float64{0.125, 0.25, 0.5, 1, 2, 5}
+
+// TypicalPowerRating is the rating of a typical through-hole resistor, 1/4 W
+const TypicalPowerRating = 0.25
+
+// ResistorPower returns the power dissipated in a resistor with either the
+// applied voltage (P = V²/R) or the current through it (P = I²R), and the
+// smallest common rating (1/8, 1/4, 1/2, 1, 2, 5 W) that covers it. The
+// suggested rating is 0 when the power is above 5 W.
+// Returns an error unless exactly one of volts and amps is non-zero, or if
+// the resistance is not positive
+func ResistorPower(ohms, volts, amps float64) (watts float64, suggestedRating float64, err error) {
+	switch {
+	case volts == 0 && amps == 0:
+		return 0, 0, fmt.Errorf("enter a voltage or a current")
+	case volts != 0 && amps != 0:
+		return 0, 0, fmt.Errorf("enter a voltage or a current, not both")
+	case ohms <= 0:
+		return 0, 0, fmt.Errorf("resistance must be positive")
+	}
+
+	if volts != 0 {
+		watts = volts * volts / ohms
+	} else {
+		watts = amps * amps * ohms
+	}
+
+	for _, rating := range powerRatings {
+		if watts <= rating {
+			return watts, rating, nil
+		}
+	}
+	return watts, 0, nil
+}
+
+// ParseOperatingPoint parses an applied voltage such as "5V" or "3.3 V", or a
+// current such as "20mA", returning the one given and 0 for the other
+// Returns an error for a value without a V or A unit
+func ParseOperatingPoint(input string) (volts, amps float64, err error) {
+	// Join a unit typed apart from its number, as in "20 mA"
+	var fields []string
+	for _, field := range strings.Fields(input) {
+		if len(fields) > 0 && strings.Trim(field, "0123456789.+-") == field {
+			fields[len(fields)-1] += field
+			continue
+		}
+		fields = append(fields, field)
+	}
+	if len(fields) == 0 {
+		return 0, 0, fmt.Errorf("empty value")
+	}
+
+	for _, field := range fields {
+		switch {
+		case strings.HasSuffix(strings.ToUpper(field), "V"):
+			volts, err = ParseSIValue(field, "V")
+		case strings.HasSuffix(strings.ToUpper(field), "A"):
+			amps, err = ParseSIValue(field, "A")
+		default:
+			return 0, 0, fmt.Errorf("'%s' needs a unit, V or A (e.g. 5V or 20mA)", field)
+		}
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+	return volts, amps, nil
+}
+
+// FormatPower formats a power in W with auto-scaled units, e.g. "62.5 mW"
+func FormatPower(watts float64) string {
+	switch {
+	case watts >= 1:
+		return strconv.FormatFloat(watts, 'g', 4, 64) + " W"
+	case watts >= 1e-3:
+		return strconv.FormatFloat(watts*1e3, 'g', 4, 64) + " mW"
+	default:
+		return strconv.FormatFloat(watts*1e6, 'g', 4, 64) + " µW"
+	}
+}
+
+// FormatPowerRating formats a rating from powerRatings the way it is marked,
+// e.g. "1/4 W" or "2 W"
+func FormatPowerRating(watts float64) string {
+	switch watts {
+	case 0.125:
+		return "1/8 W"
+	case 0.25:
+		return "1/4 W"
+	case 0.5:
+		return "1/2 W"
+	}
+	return strconv.FormatFloat(watts, 'f', -1, 64) + " W"
+}
+
+// FormatResistance formats a resistance value with unit, e.g. "4.700 kΩ", or
+// "0 Ω" for a zero-ohm link
+func FormatResistance(value float64, unit string) string {
+	if value == 0 {
+		return "0 " + unit
+	}
+	// Format with appropriate precision
+	if value >= 100 {
+		return fmt.Sprintf("%.1f %s", value, unit)
+	} else if value >= 10 {
+		return fmt.Sprintf("%.2f %s", value, unit)
+	} else {
+		return fmt.Sprintf("%.3f %s", value, unit)
+	}
+}
+
+// ResistanceUnitOhms is the size of each resistance unit in Ω
+var ResistanceUnitOhms = map[string]float64{"Ω": 1, "kΩ": 1e3, "MΩ": 1e6, "GΩ": 1e9}
+
+// ResistanceDisplayUnits are the fixed units a resistance can be shown in, in
+// the order the results screen cycles through them
+var ResistanceDisplayUnits = []string{"Ω", "kΩ", "MΩ"}
+
+// ResistanceInUnit converts a resistance in Ω to the given unit
+// Returns false for an unknown unit
+func ResistanceInUnit(ohms float64, unit string) (float64, bool) {
+	size, ok := ResistanceUnitOhms[unit]
+	if !ok {
+		return 0, false
+	}
+	return ohms / size, true
+}
+
+// FormatResistanceInUnit formats a resistance in Ω in a fixed unit, to six
+// significant figures, e.g. 470 Ω in kΩ as "0.47 kΩ". An empty or unknown
+// unit auto-scales as FormatResistance does.
+func FormatResistanceInUnit(ohms float64, unit string) string {
+	value, ok := ResistanceInUnit(ohms, unit)
+	if !ok {
+		value, unit = ScaleResistance(ohms)
+		return FormatResistance(value, unit)
+	}
+	return ExplainNumber(value) + " " + unit
+}
+
+// FormatResistanceValue formats a resistance in Ω with auto-scaled units
+func FormatResistanceValue(ohms float64) string {
+	value, unit := ScaleResistance(ohms)
+	return strconv.FormatFloat(value, 'g', 4, 64) + " " + unit
+}
+
+// FormatResistanceWithOhms formats resistance with both scaled unit and Ω
+func FormatResistanceWithOhms(value float64, unit string, ohms float64) string {
+	scaled := FormatResistance(value, unit)
+
+	// Don't show Ω if it's already in Ω
+	if unit == "Ω" {
+		return scaled
+	}
+
+	// Format Ω with appropriate precision
+	var ohmsStr string
+	if ohms >= 1000 {
+		ohmsStr = fmt.Sprintf("%.0f Ω", ohms)
+	} else if ohms >= 1 {
+		ohmsStr = fmt.Sprintf("%.1f Ω", ohms)
+	} else {
+		ohmsStr = fmt.Sprintf("%.3f Ω", ohms)
+	}
+
+	return fmt.Sprintf("%s  (%s)", scaled, ohmsStr)
+}
+
+// FormatResistorTolerance formats tolerance information for resistors
+func FormatResistorTolerance(result *ResistorResult) string {
+	toleranceColor := result.Reading.Band4
+	if result.Reading.BandCount >= 5 {
+		toleranceColor = result.Reading.Band5
+	}
+
+	tolInfo, exists := GetResistorTolerance(toleranceColor)
+	if !exists {
+		return "N/A"
+	}
+
+	if !tolInfo.Symmetric() {
+		high, low := tolInfo.Bounds()
+		return fmt.Sprintf("+%s%% / -%s%% (%s)", ExplainNumber(high), ExplainNumber(low), tolInfo.Name)
+	}
+
+	// Format percentage with appropriate precision
+	if tolInfo.Percent < 1 {
+		return fmt.Sprintf("±%.2f%% (%s)", tolInfo.Percent, tolInfo.Name)
+	}
+	return fmt.Sprintf("±%.0f%% (%s)", tolInfo.Percent, tolInfo.Name)
+}
+
+// FormatResistorToleranceRange formats the min-max range for resistors
+func FormatResistorToleranceRange(result *ResistorResult) string {
+	if result.Jumper {
+		return "0 Ω (zero-ohm link, no tolerance range)"
+	}
+	minStr := FormatResistance(result.MinValue, result.MinUnit)
+	maxStr := FormatResistance(result.MaxValue, result.MaxUnit)
+	if result.ToleranceHigh != result.ToleranceLow {
+		return fmt.Sprintf("%s ──► %s  (+%s%% / -%s%%)", minStr, maxStr,
+			ExplainNumber(result.ToleranceHigh), ExplainNumber(result.ToleranceLow))
+	}
+	return fmt.Sprintf("%s ──► %s", minStr, maxStr)
+}
+
+// FormatResistorTempCoefficient formats temperature coefficient for 6-band resistors
+func FormatResistorTempCoefficient(result *ResistorResult) string {
+	if !result.TempCoeffValid {
+		return "N/A"
+	}
+
+	return fmt.Sprintf("%d ppm/°C", result.TempCoefficient)
+}
+
+// FormatFailureRate formats a military resistor's failure rate, e.g. "0.1%/1000 h"
+func FormatFailureRate(result *ResistorResult) string {
+	if !result.FailureRateValid {
+		return "N/A"
+	}
+
+	return strconv.FormatFloat(result.FailureRatePercent, 'f', -1, 64) + "%/1000 h"
+}
+
+// GetResistorBandName returns a human-readable name for each resistor band
+func GetResistorBandName(bandNum int, bandCount int) string {
+	switch bandCount {
+	case 4:
+		switch bandNum {
+		case 1:
+			return "First Digit"
+		case 2:
+			return "Second Digit"
+		case 3:
+			return "Multiplier"
+		case 4:
+			return "Tolerance"
+		}
+	case 5:
+		switch bandNum {
+		case 1:
+			return "First Digit"
+		case 2:
+			return "Second Digit"
+		case 3:
+			return "Third Digit"
+		case 4:
+			return "Multiplier"
+		case 5:
+			return "Tolerance"
+		}
+	case 6:
+		switch bandNum {
+		case 1:
+			return "First Digit"
+		case 2:
+			return "Second Digit"
+		case 3:
+			return "Third Digit"
+		case 4:
+			return "Multiplier"
+		case 5:
+			return "Tolerance"
+		case 6:
+			return "Temperature Coefficient"
+		}
+	case 1:
+		if bandNum == 1 {
+			return "Zero-Ohm Jumper"
+		}
+	}
+	return fmt.Sprintf("Band %d", bandNum)
+}
+
+// GetResistorBandDescription returns a detailed description for each resistor band
+func GetResistorBandDescription(bandNum int, bandCount int) string {
+	switch bandCount {
+	case 4:
+		switch bandNum {
+		case 1:
+			return "First significant digit (0-9)"
+		case 2:
+			return "Second significant digit (0-9)"
+		case 3:
+			return "Multiplier (×1, ×10, ×100, etc.)"
+		case 4:
+			return "Tolerance (±%)"
+		}
+	case 5:
+		switch bandNum {
+		case 1:
+			return "First significant digit (0-9)"
+		case 2:
+			return "Second significant digit (0-9)"
+		case 3:
+			return "Third significant digit (0-9)"
+		case 4:
+			return "Multiplier (×1, ×10, ×100, etc.)"
+		case 5:
+			return "Tolerance (±%)"
+		}
+	case 6:
+		switch bandNum {
+		case 1:
+			return "First significant digit (0-9)"
+		case 2:
+			return "Second significant digit (0-9)"
+		case 3:
+			return "Third significant digit (0-9)"
+		case 4:
+			return "Multiplier (×1, ×10, ×100, etc.)"
+		case 5:
+			return "Tolerance (±%)"
+		case 6:
+			return "Temperature coefficient (ppm/°C)"
+		}
+	}
+	return ""
+}
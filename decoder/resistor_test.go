@@ -0,0 +1,574 @@
+package decoder
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+)
+
+// TestCalculateResistorWhiteMultiplier tests the GΩ scaling path with a White multiplier
+func TestCalculateResistorWhiteMultiplier(t *testing.T) {
+	reading := ResistorReading{
+		Band1:     ColorWhite, // 9
+		Band2:     ColorWhite, // 9
+		Band3:     ColorWhite, // 9
+		Band4:     ColorWhite, // ×1,000,000,000
+		Band5:     ColorBrown, // ±1%
+		BandCount: 5,
+	}
+
+	result, err := CalculateResistor(reading)
+	if err != nil {
+		t.Fatalf("CalculateResistor() error = %v", err)
+	}
+
+	if result.ResistanceOhms != 999e9 {
+		t.Errorf("ResistanceOhms = %v, want %v", result.ResistanceOhms, 999e9)
+	}
+
+	if result.ResistanceUnit != "GΩ" {
+		t.Errorf("ResistanceUnit = %v, want GΩ", result.ResistanceUnit)
+	}
+
+	if got := FormatResistance(result.ResistanceValue, result.ResistanceUnit); got != "999.0 GΩ" {
+		t.Errorf("FormatResistance() = %q, want %q", got, "999.0 GΩ")
+	}
+}
+
+// TestReverseResistorReading tests decoding a resistor read from the wrong end
+func TestReverseResistorReading(t *testing.T) {
+	// Brown-black-red-gold (1 kΩ ±5%) read backwards
+	backwards, err := ResistorReadingFromColors([]Color{ColorGold, ColorRed, ColorBlack, ColorBrown})
+	if err != nil {
+		t.Fatalf("ResistorReadingFromColors() error = %v", err)
+	}
+
+	reading, err := ReverseResistorReading(backwards)
+	if err != nil {
+		t.Fatalf("ReverseResistorReading() error = %v", err)
+	}
+	result, err := CalculateResistor(reading)
+	if err != nil {
+		t.Fatalf("CalculateResistor() error = %v", err)
+	}
+	if result.ResistanceOhms != 1000 || result.TolerancePercent != 5 {
+		t.Errorf("reversed reading = %v Ω ±%v%%, want 1000 Ω ±5%%", result.ResistanceOhms, result.TolerancePercent)
+	}
+
+	// Reversing twice restores the original
+	again, _ := ReverseResistorReading(reading)
+	if again != backwards {
+		t.Errorf("double reverse = %+v, want %+v", again, backwards)
+	}
+}
+
+// TestReversedReading tests which readings are also valid read from the other end
+func TestReversedReading(t *testing.T) {
+	tests := []struct {
+		name   string
+		colors []Color
+		wantOK bool
+	}{
+		{"brown tolerance reads both ways", []Color{ColorBrown, ColorBlack, ColorRed, ColorBrown}, true},
+		{"gold tolerance can't lead", []Color{ColorBrown, ColorBlack, ColorRed, ColorGold}, false},
+		{"black can't lead", []Color{ColorRed, ColorRed, ColorBrown, ColorBlack}, false},
+		{"5-band both ways", []Color{ColorBrown, ColorBlack, ColorBlack, ColorBrown, ColorBrown}, true},
+		{"symmetric bands read the same", []Color{ColorBrown, ColorBlack, ColorBlack, ColorBlack, ColorBrown}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reading, err := ResistorReadingFromColors(tt.colors)
+			if err != nil {
+				t.Fatalf("ResistorReadingFromColors() error = %v", err)
+			}
+			if _, ok := ReversedReading(reading); ok != tt.wantOK {
+				t.Errorf("ReversedReading() ok = %v, want %v", ok, tt.wantOK)
+			}
+		})
+	}
+}
+
+// TestInferResistorBandCount tests telling 4, 5 and 6-band layouts apart
+func TestInferResistorBandCount(t *testing.T) {
+	tests := []struct {
+		name          string
+		colors        []Color
+		want          int
+		wantAmbiguous bool
+		wantErr       string
+	}{
+		{"gold in band 4 is a 4-band", []Color{ColorYellow, ColorViolet, ColorRed, ColorGold}, 4, false, ""},
+		{"brown in band 4 may be a 5-band", []Color{ColorBrown, ColorBlack, ColorRed, ColorBrown}, 4, true, ""},
+		{"5-band", []Color{ColorBrown, ColorBlack, ColorBlack, ColorRed, ColorBrown}, 5, false, ""},
+		{"5-band gold tolerance", []Color{ColorBrown, ColorBlack, ColorBlack, ColorRed, ColorGold}, 5, false, ""},
+		{"gold in band 4 of five may be a reliability band", []Color{ColorYellow, ColorViolet, ColorRed, ColorGold, ColorBrown}, 5, true, ""},
+		{"6-band", []Color{ColorBrown, ColorBlack, ColorBlack, ColorRed, ColorBrown, ColorRed}, 6, false, ""},
+		{"too few bands", []Color{ColorBrown, ColorBlack, ColorRed}, 0, false, "4 to 6 bands"},
+		{"invalid tolerance", []Color{ColorBrown, ColorBlack, ColorRed, ColorWhite}, 0, false, "tolerance"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := InferResistorBandCount(tt.colors)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("InferResistorBandCount() error = %v, want mention of %q", err, tt.wantErr)
+				}
+				return
+			}
+			if ambiguous := errors.Is(err, ErrAmbiguousBandCount); ambiguous != tt.wantAmbiguous || (err != nil && !ambiguous) {
+				t.Errorf("InferResistorBandCount() error = %v, want ambiguous %v", err, tt.wantAmbiguous)
+			}
+			if got != tt.want {
+				t.Errorf("InferResistorBandCount() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestCalculateMilitaryResistor tests the 5-band military layout, a 4-band
+// reading followed by a reliability band
+func TestCalculateMilitaryResistor(t *testing.T) {
+	tests := []struct {
+		name          string
+		band5         Color
+		wantRate      float64
+		wantFormatted string
+		wantErr       string
+	}{
+		{"brown", ColorBrown, 1, "1%/1000 h", ""},
+		{"red", ColorRed, 0.1, "0.1%/1000 h", ""},
+		{"orange", ColorOrange, 0.01, "0.01%/1000 h", ""},
+		{"yellow", ColorYellow, 0.001, "0.001%/1000 h", ""},
+		{"green is not a reliability color", ColorGreen, 0, "", "reliability"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reading := ResistorReading{
+				Band1: ColorYellow, Band2: ColorViolet, Band3: ColorRed, Band4: ColorGold, Band5: tt.band5,
+				BandCount: 5,
+				Military:  true,
+			}
+
+			validationErr := ValidateResistorReading(&reading)
+			result, err := CalculateResistor(reading)
+			if tt.wantErr != "" {
+				if validationErr == nil || !strings.Contains(validationErr.Error(), tt.wantErr) {
+					t.Errorf("ValidateResistorReading() error = %v, want mention of %q", validationErr, tt.wantErr)
+				}
+				if err == nil {
+					t.Errorf("CalculateResistor() error = nil, want an error")
+				}
+				return
+			}
+			if validationErr != nil || err != nil {
+				t.Fatalf("ValidateResistorReading() error = %v, CalculateResistor() error = %v", validationErr, err)
+			}
+
+			if result.ResistanceOhms != 4700 || result.TolerancePercent != 5 {
+				t.Errorf("result = %v Ω ±%v%%, want 4700 Ω ±5%%", result.ResistanceOhms, result.TolerancePercent)
+			}
+			if !result.FailureRateValid || result.FailureRatePercent != tt.wantRate {
+				t.Errorf("FailureRatePercent = %v (valid %v), want %v", result.FailureRatePercent, result.FailureRateValid, tt.wantRate)
+			}
+			if got := FormatFailureRate(result); got != tt.wantFormatted {
+				t.Errorf("FormatFailureRate() = %q, want %q", got, tt.wantFormatted)
+			}
+			if !result.Reading.Military {
+				t.Errorf("result reading lost its military layout")
+			}
+		})
+	}
+}
+
+// TestResistorBandsFromValue tests finding the bands that encode a resistance
+func TestResistorBandsFromValue(t *testing.T) {
+	tests := []struct {
+		name      string
+		ohms      float64
+		tolerance float64
+		bandCount int
+		want      []Color
+		wantErr   string
+	}{
+		{"4.7k 4-band", 4700, 5, 4, []Color{ColorYellow, ColorViolet, ColorRed, ColorGold}, ""},
+		{"0.47 ohm uses silver", 0.47, 10, 4, []Color{ColorYellow, ColorViolet, ColorSilver, ColorSilver}, ""},
+		{"zero-ohm link", 0, 5, 4, []Color{ColorBlack}, ""},
+		{"4.73k 5-band", 4730, 1, 5, []Color{ColorYellow, ColorViolet, ColorOrange, ColorBrown, ColorBrown}, ""},
+		{"6-band adds tempco", 4730, 1, 6, []Color{ColorYellow, ColorViolet, ColorOrange, ColorBrown, ColorBrown, ColorBrown}, ""},
+		{"too many digits for 4 bands", 4730, 5, 4, nil, "E24"},
+		{"no tolerance color", 4700, 3, 4, nil, "tolerance"},
+		{"negative", -1, 5, 4, nil, "negative"},
+		{"out of range", 1e12, 5, 4, nil, "range"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reading, err := ResistorBandsFromValue(tt.ohms, tt.tolerance, tt.bandCount)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ResistorBandsFromValue() error = %v, want mention of %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ResistorBandsFromValue() error = %v", err)
+			}
+
+			want, err := ResistorReadingFromColors(tt.want)
+			if tt.ohms == 0 {
+				want = ResistorReading{Band1: ColorBlack, BandCount: 1}
+			} else if err != nil {
+				t.Fatalf("ResistorReadingFromColors() error = %v", err)
+			}
+			if reading != want {
+				t.Errorf("ResistorBandsFromValue() = %+v, want %+v", reading, want)
+			}
+
+			if tt.ohms > 0 {
+				result, err := CalculateResistor(reading)
+				if err != nil {
+					t.Fatalf("CalculateResistor() error = %v", err)
+				}
+				if !ApproxEqual(result.ResistanceOhms, tt.ohms) || result.TolerancePercent != tt.tolerance {
+					t.Errorf("round trip = %v Ω ±%v%%, want %v Ω ±%v%%",
+						result.ResistanceOhms, result.TolerancePercent, tt.ohms, tt.tolerance)
+				}
+			}
+		})
+	}
+}
+
+// TestParseResistanceWithTolerance tests parsing a value with an optional tolerance
+func TestParseResistanceWithTolerance(t *testing.T) {
+	tests := []struct {
+		input         string
+		wantOhms      float64
+		wantTolerance float64
+		wantErr       bool
+	}{
+		{"4.7k 5%", 4700, 5, false},
+		{"10M ±1%", 10e6, 1, false},
+		{"220", 220, 5, false},
+		{"", 0, 0, true},
+		{"4.7k five", 0, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			ohms, tolerance, err := ParseResistanceWithTolerance(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseResistanceWithTolerance(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if err == nil && (ohms != tt.wantOhms || tolerance != tt.wantTolerance) {
+				t.Errorf("ParseResistanceWithTolerance(%q) = %v, %v, want %v, %v",
+					tt.input, ohms, tolerance, tt.wantOhms, tt.wantTolerance)
+			}
+		})
+	}
+}
+
+// TestResistorPower tests P = V²/R and P = I²R, the suggested rating and the
+// rejection of neither or both operating values
+func TestResistorPower(t *testing.T) {
+	tests := []struct {
+		name       string
+		ohms       float64
+		volts      float64
+		amps       float64
+		wantWatts  float64
+		wantRating float64
+		wantErr    string
+	}{
+		{"5V across 1k", 1000, 5, 0, 0.025, 0.125, ""},
+		{"20mA through 330", 330, 0, 0.02, 0.132, 0.25, ""},
+		{"exactly 1/4 W", 100, 5, 0, 0.25, 0.25, ""},
+		{"12V across 100", 100, 12, 0, 1.44, 2, ""},
+		{"above 5 W", 10, 12, 0, 14.4, 0, ""},
+		{"neither", 1000, 0, 0, 0, 0, "voltage or a current"},
+		{"both", 1000, 5, 0.005, 0, 0, "not both"},
+		{"zero ohms", 0, 5, 0, 0, 0, "positive"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			watts, rating, err := ResistorPower(tt.ohms, tt.volts, tt.amps)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ResistorPower() error = %v, want mention of %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ResistorPower() error = %v", err)
+			}
+			if !ApproxEqual(watts, tt.wantWatts) || rating != tt.wantRating {
+				t.Errorf("ResistorPower() = %v W, rating %v, want %v W, rating %v", watts, rating, tt.wantWatts, tt.wantRating)
+			}
+		})
+	}
+}
+
+// TestParseOperatingPoint tests voltages and currents with and without a
+// space before the unit
+func TestParseOperatingPoint(t *testing.T) {
+	tests := []struct {
+		input     string
+		wantVolts float64
+		wantAmps  float64
+		wantErr   bool
+	}{
+		{"5V", 5, 0, false},
+		{"3.3 v", 3.3, 0, false},
+		{"20mA", 0, 0.02, false},
+		{"20 mA", 0, 0.02, false},
+		{"5V 20mA", 5, 0.02, false},
+		{"5", 0, 0, true},
+		{"", 0, 0, true},
+		{"fiveV", 0, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			volts, amps, err := ParseOperatingPoint(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseOperatingPoint(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if !ApproxEqual(volts, tt.wantVolts) || !ApproxEqual(amps, tt.wantAmps) {
+				t.Errorf("ParseOperatingPoint(%q) = %v V, %v A, want %v V, %v A", tt.input, volts, amps, tt.wantVolts, tt.wantAmps)
+			}
+		})
+	}
+}
+
+// TestCombineResistors tests series and parallel equivalents, including the
+// 0 Ω parallel guard
+func TestCombineResistors(t *testing.T) {
+	tests := []struct {
+		name         string
+		vals         []float64
+		wantSeries   float64
+		wantParallel float64
+	}{
+		{"two equal", []float64{1000, 1000}, 2000, 500},
+		{"three", []float64{100, 200, 300}, 600, 600.0 / 11},
+		{"zero ohm shorts", []float64{1000, 0}, 1000, 0},
+		{"none", nil, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CombineResistorsSeries(tt.vals); !ApproxEqual(got, tt.wantSeries) {
+				t.Errorf("CombineResistorsSeries() = %v, want %v", got, tt.wantSeries)
+			}
+			if got := CombineResistorsParallel(tt.vals); !ApproxEqual(got, tt.wantParallel) {
+				t.Errorf("CombineResistorsParallel() = %v, want %v", got, tt.wantParallel)
+			}
+		})
+	}
+}
+
+// TestParseResistance tests parsing resistances with and without units
+func TestParseResistance(t *testing.T) {
+	tests := []struct {
+		input     string
+		expected  float64
+		expectErr bool
+	}{
+		{"220", 220, false},
+		{"4.7k", 4700, false},
+		{"4.62 kΩ", 4620, false},
+		{"1M", 1e6, false},
+		{"10 ohms", 10, false},
+		{"4k7", 4700, false},
+		{"470R", 470, false},
+		{"", 0, true},
+		{"abc", 0, true},
+		{"-5", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseResistance(tt.input)
+		if (err != nil) != tt.expectErr {
+			t.Errorf("ParseResistance(%q) error = %v, expectErr %v", tt.input, err, tt.expectErr)
+			continue
+		}
+		if !tt.expectErr && math.Abs(got-tt.expected) > 1e-9*tt.expected {
+			t.Errorf("ParseResistance(%q) = %v, want %v", tt.input, got, tt.expected)
+		}
+	}
+}
+
+// TestReadingEqual tests that readings compare only the bands in use
+func TestReadingEqual(t *testing.T) {
+	four := ResistorReading{Band1: ColorBrown, Band2: ColorBlack, Band3: ColorRed, Band4: ColorGold, BandCount: 4}
+	withStray := four
+	withStray.Band6 = ColorRed
+	if !four.Equal(withStray) {
+		t.Error("4-band readings differing only in band 6 are not Equal")
+	}
+	five := withStray
+	five.BandCount = 5
+	if four.Equal(five) {
+		t.Error("readings with different band counts are Equal")
+	}
+
+	capReading := CapacitorReading{Band1: ColorRed, Band2: ColorViolet, Band3: ColorOrange, BandCount: 5, CapType: TypeK}
+	otherType := capReading
+	otherType.CapType = TypeL
+	if capReading.Equal(otherType) {
+		t.Error("capacitor readings of different types are Equal")
+	}
+	otherVoltage := capReading
+	otherVoltage.Band5 = ColorRed
+	if capReading.Equal(otherVoltage) {
+		t.Error("5-band capacitor readings with different band 5 are Equal")
+	}
+	fourBand, fourBandStray := capReading, otherVoltage
+	fourBand.BandCount, fourBandStray.BandCount = 4, 4
+	if !fourBand.Equal(fourBandStray) {
+		t.Error("4-band capacitor readings differing only in the unused band 5 are not Equal")
+	}
+}
+
+// TestAllResistorReadingsFor tests listing every band combination for a value
+func TestAllResistorReadingsFor(t *testing.T) {
+	tolerances := len(AllResistorTolerances())
+	tests := []struct {
+		name           string
+		ohms           float64
+		bandCount      int
+		wantCount      int
+		wantMultiplier Color
+	}{
+		{"4-band kΩ", 4700, 4, tolerances, ColorRed},
+		{"Gold multiplier", 4.7, 4, tolerances, ColorGold},
+		{"Silver multiplier", 0.47, 4, tolerances, ColorSilver},
+		{"5-band three digits", 4750, 5, tolerances, ColorBrown},
+		{"6-band is capped", 10000, 6, min(tolerances*len(AllResistorTempCoefficients()), maxResistorReadings), ColorRed},
+		{"Too many digits", 4750, 4, 0, 0},
+		{"Zero", 0, 4, 0, 0},
+		{"Invalid band count", 4700, 3, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			readings := AllResistorReadingsFor(tt.ohms, tt.bandCount)
+			if len(readings) != tt.wantCount {
+				t.Fatalf("AllResistorReadingsFor(%v, %d) returned %d readings, want %d", tt.ohms, tt.bandCount, len(readings), tt.wantCount)
+			}
+
+			for i, reading := range readings {
+				result, err := CalculateResistor(reading)
+				if err != nil {
+					t.Fatalf("reading %d %v: CalculateResistor error = %v", i, reading.Colors(), err)
+				}
+				if !ApproxEqual(result.ResistanceOhms, tt.ohms) {
+					t.Errorf("reading %d %v decodes to %v Ω, want %v", i, reading.Colors(), result.ResistanceOhms, tt.ohms)
+				}
+				multiplier := reading.Band3
+				if tt.bandCount >= 5 {
+					multiplier = reading.Band4
+				}
+				if multiplier != tt.wantMultiplier {
+					t.Errorf("reading %d multiplier = %v, want %v", i, multiplier, tt.wantMultiplier)
+				}
+				for _, earlier := range readings[:i] {
+					if earlier.Equal(reading) {
+						t.Errorf("reading %d %v is listed twice", i, reading.Colors())
+					}
+				}
+			}
+		})
+	}
+}
+
+// TestResistorToleranceBounds tests that the standard tolerance colors stay
+// symmetric and that an asymmetric tolerance is applied per side
+func TestResistorToleranceBounds(t *testing.T) {
+	for color, info := range resistorToleranceMap {
+		name := ColorName(color)
+		reading := ResistorReading{Band1: ColorBrown, Band2: ColorBlack, Band3: ColorRed, Band4: color, BandCount: 4}
+		result, err := CalculateResistor(reading)
+		if err != nil {
+			t.Fatalf("CalculateResistor(%s tolerance) error = %v", name, err)
+		}
+		if !info.Symmetric() || result.ToleranceHigh != info.Percent || result.ToleranceLow != info.Percent {
+			t.Errorf("%s tolerance = +%v%% / -%v%%, want ±%v%%", name, result.ToleranceHigh, result.ToleranceLow, info.Percent)
+		}
+		if result.TolerancePercent != info.Percent {
+			t.Errorf("%s TolerancePercent = %v, want %v", name, result.TolerancePercent, info.Percent)
+		}
+		if strings.Contains(FormatResistorToleranceRange(result), "/") {
+			t.Errorf("%s range = %q, want no asymmetric bounds", name, FormatResistorToleranceRange(result))
+		}
+	}
+
+	saved := resistorToleranceMap[ColorGold]
+	defer func() { resistorToleranceMap[ColorGold] = saved }()
+	resistorToleranceMap[ColorGold] = ResistorToleranceInfo{Percent: 20, PercentHigh: 20, PercentLow: 10, Name: "+20% / -10%"}
+
+	// 1 kΩ +20% / -10%
+	result, err := CalculateResistor(ResistorReading{Band1: ColorBrown, Band2: ColorBlack, Band3: ColorRed, Band4: ColorGold, BandCount: 4})
+	if err != nil {
+		t.Fatalf("CalculateResistor() error = %v", err)
+	}
+	if !ApproxEqual(result.MinValue, 900) || !ApproxEqual(result.MaxValue, 1.2) {
+		t.Errorf("range = %v %s to %v %s, want 900 Ω to 1.2 kΩ", result.MinValue, result.MinUnit, result.MaxValue, result.MaxUnit)
+	}
+	if got, want := FormatResistorToleranceRange(result), "900.0 Ω ──► 1.200 kΩ  (+20% / -10%)"; got != want {
+		t.Errorf("FormatResistorToleranceRange() = %q, want %q", got, want)
+	}
+	if got := FormatResistorTolerance(result); !strings.HasPrefix(got, "+20% / -10%") {
+		t.Errorf("FormatResistorTolerance() = %q, want the + and - sides", got)
+	}
+}
+
+// TestIsZeroOhmJumper tests jumper markings, and that CalculateResistor flags
+// them and decodes a single Black band
+func TestIsZeroOhmJumper(t *testing.T) {
+	tests := []struct {
+		name   string
+		colors []Color
+		want   bool
+	}{
+		{"single Black band", []Color{ColorBlack}, true},
+		{"4-band", []Color{ColorBlack, ColorBlack, ColorBlack, ColorGold}, true},
+		{"5-band", []Color{ColorBlack, ColorBlack, ColorBlack, ColorBlack, ColorBrown}, true},
+		{"6-band", []Color{ColorBlack, ColorBlack, ColorBlack, ColorBlack, ColorBrown, ColorBrown}, true},
+		{"10 Ω", []Color{ColorBrown, ColorBlack, ColorBlack, ColorGold}, false},
+		{"1 Ω", []Color{ColorBlack, ColorBrown, ColorBlack, ColorGold}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reading, err := ResistorReadingFromColors(tt.colors)
+			if err != nil {
+				t.Fatalf("ResistorReadingFromColors() error = %v", err)
+			}
+			if got := IsZeroOhmJumper(reading); got != tt.want {
+				t.Errorf("IsZeroOhmJumper() = %v, want %v", got, tt.want)
+			}
+			result, err := CalculateResistor(reading)
+			if err != nil {
+				t.Fatalf("CalculateResistor() error = %v", err)
+			}
+			if result.Jumper != tt.want {
+				t.Errorf("Jumper = %v, want %v", result.Jumper, tt.want)
+			}
+			if tt.want && (result.ResistanceOhms != 0 || result.MinValue != 0 || result.MaxValue != 0) {
+				t.Errorf("jumper = %v Ω, range %v to %v, want 0", result.ResistanceOhms, result.MinValue, result.MaxValue)
+			}
+		})
+	}
+
+	if _, err := ResistorReadingFromColors([]Color{ColorBrown}); err == nil {
+		t.Error("ResistorReadingFromColors(Brown) error = nil, want a band count error")
+	}
+	if _, err := CalculateResistor(ResistorReading{Band1: ColorRed, BandCount: 1}); err == nil {
+		t.Error("CalculateResistor(single Red band) error = nil, want an error")
+	}
+}
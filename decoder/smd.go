@@ -0,0 +1,64 @@
+package decoder
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Usual tolerances of SMD resistor markings: 3-character codes are E24 parts,
+// 4-character codes E96 parts
+const (
+	smdThreeCharTolerance = 5.0
+	smdFourCharTolerance  = 1.0
+)
+
+// DecodeSMDResistor decodes an SMD resistor marking code:
+//
+//	472  = 47 × 10² = 4.7 kΩ (two digits and a power of ten, ±5%)
+//	1002 = 100 × 10² = 10 kΩ (three digits and a power of ten, ±1%)
+//	4R7  = 4.7 Ω, R100 = 0.1 Ω (R marks the decimal point)
+//
+// The result has no band reading.
+// Returns an error if the code is not 3-4 characters of digits and at most one R
+func DecodeSMDResistor(code string) (*ResistorResult, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if len(code) < 3 || len(code) > 4 {
+		return nil, fmt.Errorf("SMD code must be 3 or 4 characters, got '%s'", code)
+	}
+	if strings.Trim(code, "0123456789R") != "" || strings.Count(code, "R") > 1 {
+		return nil, fmt.Errorf("SMD code '%s' may only contain digits and one R", code)
+	}
+
+	var ohms float64
+	if i := strings.IndexByte(code, 'R'); i >= 0 {
+		// R is the decimal point; "47R" parses as "47."
+		value, err := strconv.ParseFloat(code[:i]+"."+code[i+1:], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid SMD code '%s'", code)
+		}
+		ohms = value
+	} else {
+		digits, _ := strconv.Atoi(code[:len(code)-1])
+		exponent := int(code[len(code)-1] - '0')
+		ohms = float64(digits) * math.Pow(10, float64(exponent))
+	}
+
+	tolerance := smdThreeCharTolerance
+	if len(code) == 4 {
+		tolerance = smdFourCharTolerance
+	}
+
+	result := &ResistorResult{
+		ResistanceOhms:   ohms,
+		TolerancePercent: tolerance,
+		ToleranceHigh:    tolerance,
+		ToleranceLow:     tolerance,
+	}
+	result.ResistanceValue, result.ResistanceUnit = ScaleResistance(ohms)
+	result.MinValue, result.MinUnit = ScaleResistance(ohms * (1 - tolerance/100))
+	result.MaxValue, result.MaxUnit = ScaleResistance(ohms * (1 + tolerance/100))
+
+	return result, nil
+}
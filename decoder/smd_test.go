@@ -0,0 +1,55 @@
+package decoder
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestDecodeSMDResistor tests the 3-digit, 4-digit and R-notation SMD codes
+func TestDecodeSMDResistor(t *testing.T) {
+	tests := []struct {
+		code          string
+		wantOhms      float64
+		wantTolerance float64
+		wantFormatted string
+		wantErr       string
+	}{
+		{"472", 4700, 5, "4.700 kΩ", ""},
+		{"100", 10, 5, "10.00 Ω", ""},
+		{"000", 0, 5, "0 Ω", ""},
+		{"1002", 10000, 1, "10.00 kΩ", ""},
+		{"4R7", 4.7, 5, "4.700 Ω", ""},
+		{"r100", 0.1, 1, "0.100 Ω", ""},
+		{"47R", 47, 5, "47.00 Ω", ""},
+		{" 105 ", 1e6, 5, "1.000 MΩ", ""},
+		{"47", 0, 0, "", "3 or 4 characters"},
+		{"10002", 0, 0, "", "3 or 4 characters"},
+		{"4K7", 0, 0, "", "digits and one R"},
+		{"R1R", 0, 0, "", "digits and one R"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			result, err := DecodeSMDResistor(tt.code)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("DecodeSMDResistor(%q) error = %v, want mention of %q", tt.code, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("DecodeSMDResistor(%q) error = %v", tt.code, err)
+			}
+
+			if !ApproxEqual(result.ResistanceOhms, tt.wantOhms) {
+				t.Errorf("ResistanceOhms = %v, want %v", result.ResistanceOhms, tt.wantOhms)
+			}
+			if result.TolerancePercent != tt.wantTolerance {
+				t.Errorf("TolerancePercent = %v, want %v", result.TolerancePercent, tt.wantTolerance)
+			}
+			if got := FormatResistance(result.ResistanceValue, result.ResistanceUnit); got != tt.wantFormatted {
+				t.Errorf("FormatResistance() = %q, want %q", got, tt.wantFormatted)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package decoder
+
+import "fmt"
+
+// WorstCaseRange returns the lowest and highest resistance in Ω the marked
+// tolerance allows
+func (r *ResistorResult) WorstCaseRange() (low, high float64) {
+	return r.ResistanceOhms * (1 - r.ToleranceLow/100), r.ResistanceOhms * (1 + r.ToleranceHigh/100)
+}
+
+// WorstCaseRange returns the lowest and highest capacitance in pF the marked
+// tolerance allows
+func (r *CalculationResult) WorstCaseRange() (low, high float64) {
+	if r.ToleranceType == "absolute" {
+		return max(r.CapacitancePF-r.ToleranceAbsolutePF, 0), r.CapacitancePF + r.ToleranceAbsolutePF
+	}
+	return r.CapacitancePF * (1 - r.ToleranceLow/100), r.CapacitancePF * (1 + r.ToleranceHigh/100)
+}
+
+// MeetsSpec reports whether every resistor the marking allows lies within
+// target ± targetTolPct, with an explanation. It compares the worst-case
+// range rather than the nominal value, so a 1 kΩ ±5% part fails a
+// 1 kΩ ±1% spec.
+func MeetsSpec(result *ResistorResult, target, targetTolPct float64) (bool, string) {
+	low, high := result.WorstCaseRange()
+	return meetsSpec(low, high, target, targetTolPct, FormatResistanceValue)
+}
+
+// MeetsSpecCapacitor reports whether every capacitor the marking allows
+// lies within targetPF ± targetTolPct, with an explanation
+func MeetsSpecCapacitor(result *CalculationResult, targetPF, targetTolPct float64) (bool, string) {
+	low, high := result.WorstCaseRange()
+	return meetsSpec(low, high, targetPF, targetTolPct, FormatCapacitanceValue)
+}
+
+// meetsSpec checks a worst-case range against the window target ± tolPct
+func meetsSpec(low, high, target, tolPct float64, format func(float64) string) (bool, string) {
+	if target <= 0 {
+		return false, "The target value must be positive"
+	}
+	if tolPct < 0 {
+		return false, "The target tolerance cannot be negative"
+	}
+
+	minAllowed, maxAllowed := target*(1-tolPct/100), target*(1+tolPct/100)
+	window := fmt.Sprintf("%s to %s", format(minAllowed), format(maxAllowed))
+	// Bounds equal to the window edges, up to rounding, are inside it
+	tooLow := low < minAllowed && !ApproxEqual(low, minAllowed)
+	tooHigh := high > maxAllowed && !ApproxEqual(high, maxAllowed)
+
+	switch {
+	case tooLow && tooHigh:
+		return false, fmt.Sprintf("Worst case %s to %s is wider than the allowed %s", format(low), format(high), window)
+	case tooLow:
+		return false, fmt.Sprintf("Worst case low %s is below the allowed %s", format(low), window)
+	case tooHigh:
+		return false, fmt.Sprintf("Worst case high %s is above the allowed %s", format(high), window)
+	}
+	return true, fmt.Sprintf("Worst case %s to %s is within the allowed %s", format(low), format(high), window)
+}
@@ -0,0 +1,72 @@
+package decoder
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestMeetsSpec tests that resistors are judged on their worst-case range,
+// not their nominal value
+func TestMeetsSpec(t *testing.T) {
+	// 1 kΩ ±5%: 950 Ω to 1.05 kΩ
+	fivePercent, err := CalculateResistor(ResistorReading{Band1: ColorBrown, Band2: ColorBlack, Band3: ColorRed, Band4: ColorGold, BandCount: 4})
+	if err != nil {
+		t.Fatalf("CalculateResistor() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		target   float64
+		tolPct   float64
+		want     bool
+		wantText string
+	}{
+		{"same window", 1000, 5, true, "within"},
+		{"looser window", 1000, 10, true, "within"},
+		{"nominal matches but range is wider", 1000, 1, false, "wider"},
+		{"window above the part", 1020, 5, false, "below"},
+		{"window below the part", 980, 5, false, "above"},
+		{"other value", 4700, 5, false, "below"},
+		{"invalid target", 0, 5, false, "positive"},
+		{"negative tolerance", 1000, -1, false, "negative"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, why := MeetsSpec(fivePercent, tt.target, tt.tolPct)
+			if got != tt.want || !strings.Contains(why, tt.wantText) {
+				t.Errorf("MeetsSpec(1 kΩ ±5%%, %v ±%v%%) = %v, %q, want %v and %q", tt.target, tt.tolPct, got, why, tt.want, tt.wantText)
+			}
+		})
+	}
+}
+
+// TestMeetsSpecCapacitor tests capacitors against a target window, including
+// the asymmetric Grey tolerance
+func TestMeetsSpecCapacitor(t *testing.T) {
+	tests := []struct {
+		name     string
+		band4    Color
+		targetPF float64
+		tolPct   float64
+		want     bool
+	}{
+		{"±10% within ±10%", ColorWhite, 47000, 10, true},
+		{"±10% outside ±5%", ColorWhite, 47000, 5, false},
+		{"+80/-20% outside ±50%", ColorGrey, 47000, 50, false},
+		{"+80/-20% within ±80%", ColorGrey, 47000, 80, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// 47 nF
+			result, err := Calculate(CapacitorReading{Band1: ColorYellow, Band2: ColorViolet, Band3: ColorOrange, Band4: tt.band4, BandCount: 4, CapType: TypeK})
+			if err != nil {
+				t.Fatalf("Calculate() error = %v", err)
+			}
+			if got, why := MeetsSpecCapacitor(result, tt.targetPF, tt.tolPct); got != tt.want {
+				t.Errorf("MeetsSpecCapacitor(%v pF ±%v%%) = %v (%s), want %v", tt.targetPF, tt.tolPct, got, why, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,220 @@
+package decoder
+
+import (
+	"fmt"
+	"math"
+)
+
+// ColorTolerance pairs a color with its capacitor tolerance
+type ColorTolerance struct {
+	Color     Color
+	Tolerance ToleranceInfo
+}
+
+// ColorResistorTolerance pairs a color with its resistor tolerance
+type ColorResistorTolerance struct {
+	Color     Color
+	Tolerance ResistorToleranceInfo
+}
+
+// ColorTempCoefficient pairs a color with a temperature coefficient
+type ColorTempCoefficient struct {
+	Color       Color
+	Coefficient int
+}
+
+// ColorMultiplier pairs a color with a multiplier
+type ColorMultiplier struct {
+	Color      Color
+	Multiplier float64
+}
+
+// ColorVoltage pairs a color with a capacitor voltage rating
+type ColorVoltage struct {
+	Color   Color
+	Voltage float64
+}
+
+// AllColors returns every band color in code order, Black through Silver
+// and then the extended Pink; see Palette.Colors for the colors in use
+func AllColors() []Color {
+	colors := make([]Color, 0, ColorPink+1)
+	for c := ColorBlack; c <= ColorPink; c++ {
+		colors = append(colors, c)
+	}
+	return colors
+}
+
+// AllTolerances returns the capacitor tolerance table in color order
+func AllTolerances() []ColorTolerance {
+	var entries []ColorTolerance
+	for _, c := range AllColors() {
+		if info, ok := toleranceMap[c]; ok {
+			entries = append(entries, ColorTolerance{Color: c, Tolerance: info})
+		}
+	}
+	return entries
+}
+
+// AllResistorTolerances returns the resistor tolerance table in color order
+func AllResistorTolerances() []ColorResistorTolerance {
+	var entries []ColorResistorTolerance
+	for _, c := range AllColors() {
+		if info, ok := resistorToleranceMap[c]; ok {
+			entries = append(entries, ColorResistorTolerance{Color: c, Tolerance: info})
+		}
+	}
+	return entries
+}
+
+// AllTempCoefficients returns the capacitor temperature coefficient table in color order
+func AllTempCoefficients() []ColorTempCoefficient {
+	return tempCoefficientEntries(temperatureCoefficientMap)
+}
+
+// AllResistorTempCoefficients returns the resistor temperature coefficient table in color order
+func AllResistorTempCoefficients() []ColorTempCoefficient {
+	return tempCoefficientEntries(resistorTempCoefficientMap)
+}
+
+func tempCoefficientEntries(table map[Color]int) []ColorTempCoefficient {
+	var entries []ColorTempCoefficient
+	for _, c := range AllColors() {
+		if coeff, ok := table[c]; ok {
+			entries = append(entries, ColorTempCoefficient{Color: c, Coefficient: coeff})
+		}
+	}
+	return entries
+}
+
+// AllMultipliers returns the capacitor multiplier table in color order
+func AllMultipliers() []ColorMultiplier {
+	var entries []ColorMultiplier
+	for _, c := range AllColors() {
+		if info := GetColorInfo(c); info.ValidMult {
+			entries = append(entries, ColorMultiplier{Color: c, Multiplier: info.Multiplier})
+		}
+	}
+	return entries
+}
+
+// AllResistorMultipliers returns the resistor multiplier table in color order
+func AllResistorMultipliers() []ColorMultiplier {
+	var entries []ColorMultiplier
+	for _, c := range AllColors() {
+		if mult, ok := resistorMultiplierMap[c]; ok {
+			entries = append(entries, ColorMultiplier{Color: c, Multiplier: mult})
+		}
+	}
+	return entries
+}
+
+// VoltageTable returns the valid voltage ratings for a capacitor type in color order,
+// including fractional ratings such as 6.3V
+func VoltageTable(capType CapacitorType) []ColorVoltage {
+	var entries []ColorVoltage
+	for _, c := range AllColors() {
+		if v, ok := GetVoltageRatingFractional(capType, c); ok {
+			entries = append(entries, ColorVoltage{Color: c, Voltage: v})
+		}
+	}
+	return entries
+}
+
+// expectedVoltageCodes is the number of colors with a voltage rating per type
+var expectedVoltageCodes = map[CapacitorType]int{
+	TypeJ: 9,
+	TypeK: 10,
+	TypeL: 4,
+	TypeM: 8,
+	TypeN: 8,
+}
+
+// isPowerOfTen reports whether v is 10^n for some integer n
+func isPowerOfTen(v float64) bool {
+	if v <= 0 {
+		return false
+	}
+	exp := math.Round(math.Log10(v))
+	return math.Abs(v-math.Pow(10, exp)) <= 1e-9*v
+}
+
+// VerifyTables checks the reference tables for data-entry mistakes and returns
+// every inconsistency found
+func VerifyTables() []error {
+	var errs []error
+
+	// Digits: each digit color has a unique digit 0-9
+	seenDigits := map[int]Color{}
+	for _, c := range AllColors() {
+		info := GetColorInfo(c)
+		if !info.ValidDigit {
+			if info.Digit >= 0 {
+				errs = append(errs, fmt.Errorf("%s: digit %d set but not marked as a valid digit", info.Name, info.Digit))
+			}
+			continue
+		}
+		if info.Digit < 0 || info.Digit > 9 {
+			errs = append(errs, fmt.Errorf("%s: digit %d out of range 0-9", info.Name, info.Digit))
+			continue
+		}
+		if other, dup := seenDigits[info.Digit]; dup {
+			errs = append(errs, fmt.Errorf("%s: digit %d already used by %s", info.Name, info.Digit, GetColorInfo(other).Name))
+		}
+		seenDigits[info.Digit] = c
+	}
+	if len(seenDigits) != 10 {
+		errs = append(errs, fmt.Errorf("expected 10 digit colors, found %d", len(seenDigits)))
+	}
+
+	// Multipliers: powers of ten
+	for _, entry := range AllMultipliers() {
+		if !isPowerOfTen(entry.Multiplier) {
+			errs = append(errs, fmt.Errorf("%s: capacitor multiplier %g is not a power of ten", GetColorInfo(entry.Color).Name, entry.Multiplier))
+		}
+	}
+	for _, entry := range AllResistorMultipliers() {
+		if !isPowerOfTen(entry.Multiplier) {
+			errs = append(errs, fmt.Errorf("%s: resistor multiplier %g is not a power of ten", GetColorInfo(entry.Color).Name, entry.Multiplier))
+		}
+	}
+
+	// Tolerances: symmetric entries have equal bounds, asymmetric ones differ
+	for _, entry := range AllTolerances() {
+		name := GetColorInfo(entry.Color).Name
+		tol := entry.Tolerance
+		switch {
+		case tol.PercentHigh <= 0 || tol.PercentLow <= 0:
+			errs = append(errs, fmt.Errorf("%s: tolerance percentages must be positive (+%g%% / -%g%%)", name, tol.PercentHigh, tol.PercentLow))
+		case tol.Symmetric && tol.PercentHigh != tol.PercentLow:
+			errs = append(errs, fmt.Errorf("%s: symmetric tolerance has +%g%% / -%g%%", name, tol.PercentHigh, tol.PercentLow))
+		case !tol.Symmetric && tol.PercentHigh == tol.PercentLow:
+			errs = append(errs, fmt.Errorf("%s: asymmetric tolerance has equal bounds ±%g%%", name, tol.PercentHigh))
+		}
+		if tol.AbsolutePF < 0 {
+			errs = append(errs, fmt.Errorf("%s: negative absolute tolerance %g pF", name, tol.AbsolutePF))
+		}
+	}
+	for _, entry := range AllResistorTolerances() {
+		if entry.Tolerance.Percent <= 0 {
+			errs = append(errs, fmt.Errorf("%s: resistor tolerance %g%% must be positive", GetColorInfo(entry.Color).Name, entry.Tolerance.Percent))
+		}
+	}
+
+	// Voltages: expected number of codes per type, rising with the color digit
+	for _, capType := range []CapacitorType{TypeJ, TypeK, TypeL, TypeM, TypeN} {
+		table := VoltageTable(capType)
+		if want := expectedVoltageCodes[capType]; len(table) != want {
+			errs = append(errs, fmt.Errorf("type %s: %d voltage codes, expected %d", capType, len(table), want))
+		}
+		for i := 1; i < len(table); i++ {
+			if table[i].Voltage <= table[i-1].Voltage {
+				errs = append(errs, fmt.Errorf("type %s: %s (%gV) is not above %s (%gV)", capType,
+					GetColorInfo(table[i].Color).Name, table[i].Voltage,
+					GetColorInfo(table[i-1].Color).Name, table[i-1].Voltage))
+			}
+		}
+	}
+
+	return errs
+}
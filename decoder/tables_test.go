@@ -0,0 +1,84 @@
+package decoder
+
+import (
+	"testing"
+)
+
+// TestTableOrdering tests that table accessors return complete, color-ordered entries
+func TestTableOrdering(t *testing.T) {
+	if got := len(AllTolerances()); got != len(toleranceMap) {
+		t.Errorf("AllTolerances() len = %d, want %d", got, len(toleranceMap))
+	}
+	if got := len(AllResistorTolerances()); got != len(resistorToleranceMap) {
+		t.Errorf("AllResistorTolerances() len = %d, want %d", got, len(resistorToleranceMap))
+	}
+	if got := len(AllTempCoefficients()); got != len(temperatureCoefficientMap) {
+		t.Errorf("AllTempCoefficients() len = %d, want %d", got, len(temperatureCoefficientMap))
+	}
+	if got := len(AllResistorMultipliers()); got != len(resistorMultiplierMap) {
+		t.Errorf("AllResistorMultipliers() len = %d, want %d", got, len(resistorMultiplierMap))
+	}
+
+	tolerances := AllResistorTolerances()
+	for i := 1; i < len(tolerances); i++ {
+		if tolerances[i-1].Color >= tolerances[i].Color {
+			t.Errorf("AllResistorTolerances() not in color order at index %d", i)
+		}
+	}
+}
+
+// TestVoltageTable tests per-type voltage tables including fractional ratings
+func TestVoltageTable(t *testing.T) {
+	tests := []struct {
+		capType       CapacitorType
+		expectedCount int
+		first         float64
+	}{
+		{TypeJ, 9, 3},
+		{TypeK, 10, 100},
+		{TypeL, 4, 100},
+		{TypeM, 8, 1.6},
+		{TypeN, 8, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.capType), func(t *testing.T) {
+			table := VoltageTable(tt.capType)
+			if len(table) != tt.expectedCount {
+				t.Errorf("VoltageTable() len = %d, want %d", len(table), tt.expectedCount)
+			}
+			if len(table) > 0 && table[0].Voltage != tt.first {
+				t.Errorf("VoltageTable()[0] = %v, want %v", table[0].Voltage, tt.first)
+			}
+		})
+	}
+}
+
+// TestVerifyTables tests that the shipped reference tables pass the self-check
+func TestVerifyTables(t *testing.T) {
+	for _, err := range VerifyTables() {
+		t.Error(err)
+	}
+}
+
+// TestIsPowerOfTen tests the multiplier invariant used by VerifyTables
+func TestIsPowerOfTen(t *testing.T) {
+	tests := []struct {
+		value    float64
+		expected bool
+	}{
+		{1, true},
+		{0.01, true},
+		{1e9, true},
+		{0.1, true},
+		{20, false},
+		{0, false},
+		{-10, false},
+	}
+
+	for _, tt := range tests {
+		if got := isPowerOfTen(tt.value); got != tt.expected {
+			t.Errorf("isPowerOfTen(%g) = %v, want %v", tt.value, got, tt.expected)
+		}
+	}
+}
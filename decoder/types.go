@@ -0,0 +1,235 @@
+package decoder
+
+import (
+	"strings"
+	"unicode"
+)
+
+// CapacitorType represents different capacitor types
+type CapacitorType string
+
+const (
+	TypeJ CapacitorType = "J" // Dipped Tantalum
+	TypeK CapacitorType = "K" // Mica
+	TypeL CapacitorType = "L" // Polyester/Polystyrene
+	TypeM CapacitorType = "M" // Electrolytic (4-band)
+	TypeN CapacitorType = "N" // Electrolytic (3-band)
+)
+
+// TypeInfo contains information about each capacitor type
+type TypeInfo struct {
+	Type        CapacitorType
+	Name        string
+	Description string
+	// Voltages are the band 5 voltage ratings indexed by color digit
+	// (Black = 0); 0 means the color has no rating for the type
+	Voltages []float64
+	// DefaultBandCount is the conventional band count for the type, or 0 if
+	// the type has no single convention
+	DefaultBandCount int
+	// TempCoeffBand is set for types whose fifth band also marks a
+	// temperature coefficient; for the others it is a voltage code only
+	TempCoeffBand bool
+	// Dielectric, TypicalUse and TempStability describe the part for
+	// beginners on the type selection and results screens
+	Dielectric    string
+	TypicalUse    string
+	TempStability string
+}
+
+// typeInfoMap stores details about each capacitor type
+var typeInfoMap = map[CapacitorType]TypeInfo{
+	TypeJ: {
+		Type:          TypeJ,
+		Name:          "Dipped Tantalum",
+		Description:   "Type J (Dipped Tantalum)",
+		Voltages:      []float64{3, 4, 6, 10, 15, 20, 25, 35, 50},
+		Dielectric:    "Tantalum pentoxide",
+		TypicalUse:    "Decoupling and filtering where space is tight; polarized",
+		TempStability: "Moderate, about ±10% from -55 to +125 °C",
+	},
+	TypeK: {
+		Type:          TypeK,
+		Name:          "Mica",
+		Description:   "Type K (Mica)",
+		Voltages:      []float64{100, 200, 300, 400, 500, 600, 700, 800, 900, 1000, 2000},
+		TempCoeffBand: true,
+		Dielectric:    "Silver mica",
+		TypicalUse:    "RF tuned circuits, oscillators and precision filters",
+		TempStability: "Excellent, tens of ppm/°C (see band 5)",
+	},
+	TypeL: {
+		Type:          TypeL,
+		Name:          "Polyester / Polystyrene",
+		Description:   "Type L (Polyester / Polystyrene)",
+		Voltages:      []float64{100, 250, 400, 630},
+		Dielectric:    "Polyester (PET) or polystyrene film",
+		TypicalUse:    "Coupling, timing and audio filters",
+		TempStability: "Good; polystyrene about -150 ppm/°C, polyester a few %",
+	},
+	TypeM: {
+		Type:             TypeM,
+		Name:             "Electrolytic (4-band style)",
+		Description:      "Type M (Electrolytic 4-Band)",
+		Voltages:         []float64{0, 1.6, 2.5, 4, 6.3, 10, 16, 25, 40}, // No Black code
+		DefaultBandCount: 4,
+		Dielectric:       "Aluminum oxide with liquid electrolyte",
+		TypicalUse:       "Bulk storage and smoothing in power supplies; polarized",
+		TempStability:    "Poor; capacitance falls and ESR rises in the cold",
+	},
+	TypeN: {
+		Type:             TypeN,
+		Name:             "Electrolytic (3-band style)",
+		Description:      "Type N (Electrolytic 3-Band)",
+		Voltages:         []float64{3, 6, 6.3, 10, 15, 20, 25, 35},
+		DefaultBandCount: 3,
+		Dielectric:       "Aluminum oxide with liquid electrolyte",
+		TypicalUse:       "Bulk storage, smoothing and audio coupling; polarized",
+		TempStability:    "Poor; capacitance falls and ESR rises in the cold",
+	},
+}
+
+// GetVoltageRatingFractional returns the voltage rating for a capacitor type
+// and band 5 color, including fractional ratings such as 1.6V and 6.3V
+func GetVoltageRatingFractional(capType CapacitorType, band5Color Color) (float64, bool) {
+	typeInfo, exists := typeInfoMap[capType]
+	if !exists {
+		return 0, false
+	}
+
+	colorInfo := GetColorInfo(band5Color)
+	if colorInfo.Digit < 0 || colorInfo.Digit >= len(typeInfo.Voltages) {
+		return 0, false
+	}
+
+	voltage := typeInfo.Voltages[colorInfo.Digit]
+	if voltage == 0 {
+		return 0, false
+	}
+
+	return voltage, true
+}
+
+// VoltageCode is a band 5 color and the voltage it encodes
+type VoltageCode struct {
+	Color Color
+	Volts float64
+}
+
+// VoltageCodes returns the valid band 5 voltage codes for a capacitor type,
+// in color order
+func VoltageCodes(capType CapacitorType) []VoltageCode {
+	var codes []VoltageCode
+	for _, c := range AllColors() {
+		if volts, ok := GetVoltageRatingFractional(capType, c); ok {
+			codes = append(codes, VoltageCode{Color: c, Volts: volts})
+		}
+	}
+	return codes
+}
+
+// VoltageCodeAtLeast returns the lowest rated band 5 voltage code of a
+// capacitor type that is rated for at least volts
+// Returns false if every code of the type is rated below volts
+func VoltageCodeAtLeast(capType CapacitorType, volts float64) (VoltageCode, bool) {
+	var best VoltageCode
+	found := false
+	for _, code := range VoltageCodes(capType) {
+		if code.Volts >= volts && (!found || code.Volts < best.Volts) {
+			best, found = code, true
+		}
+	}
+	return best, found
+}
+
+// ValidVoltageColorsForType returns the band 5 colors that mark a voltage
+// rating for a capacitor type, in color order
+func ValidVoltageColorsForType(capType CapacitorType) []Color {
+	var colors []Color
+	for _, code := range VoltageCodes(capType) {
+		colors = append(colors, code.Color)
+	}
+	return colors
+}
+
+// ParseCapacitorType converts string input to CapacitorType
+// Accepts the type letter or a case-insensitive, unambiguous partial match of
+// the type name (e.g. "mica", "tantalum", "poly")
+func ParseCapacitorType(input string) (CapacitorType, bool) {
+	input = strings.ToUpper(strings.TrimSpace(input))
+
+	switch input {
+	case "J":
+		return TypeJ, true
+	case "K":
+		return TypeK, true
+	case "L":
+		return TypeL, true
+	case "M":
+		return TypeM, true
+	case "N":
+		return TypeN, true
+	}
+
+	matches := matchCapacitorTypeNames(input)
+	if len(matches) != 1 {
+		return "", false
+	}
+	return matches[0], true
+}
+
+// matchCapacitorTypeNames returns the types whose name, or a word in their name,
+// starts with the given input
+func matchCapacitorTypeNames(input string) []CapacitorType {
+	input = strings.ToLower(strings.TrimSpace(input))
+	if input == "" {
+		return nil
+	}
+
+	var matches []CapacitorType
+	for _, code := range AllCapacitorTypes() {
+		capType := CapacitorType(code)
+		for _, word := range CapacitorTypeNameWords(capType) {
+			if strings.HasPrefix(strings.ToLower(word), input) {
+				matches = append(matches, capType)
+				break
+			}
+		}
+	}
+	return matches
+}
+
+// CapacitorTypeNameWords returns the full type name followed by its individual words
+func CapacitorTypeNameWords(capType CapacitorType) []string {
+	name := typeInfoMap[capType].Name
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	return append([]string{name}, words...)
+}
+
+// GetTypeInfo returns information about a capacitor type
+func GetTypeInfo(capType CapacitorType) (TypeInfo, bool) {
+	info, exists := typeInfoMap[capType]
+	return info, exists
+}
+
+// HasTempCoeffBand reports whether band 5 of a 5-band capacitor of the type
+// carries a temperature coefficient as well as its voltage rating
+func HasTempCoeffBand(capType CapacitorType) bool {
+	return typeInfoMap[capType].TempCoeffBand
+}
+
+// CapacitorBandCount returns the band count to preselect for a capacitor type,
+// falling back to the given count for types without a conventional one
+func CapacitorBandCount(capType CapacitorType, fallback int) int {
+	if count := typeInfoMap[capType].DefaultBandCount; count != 0 {
+		return count
+	}
+	return fallback
+}
+
+// AllCapacitorTypes returns all valid capacitor type codes
+func AllCapacitorTypes() []string {
+	return []string{"J", "K", "L", "M", "N"}
+}
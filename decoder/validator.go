@@ -1,6 +1,11 @@
-package main
+package decoder
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
 
 // ValidationError represents a validation error with context
 type ValidationError struct {
@@ -72,74 +77,86 @@ func ValidateBand4(color Color, capacitancePF float64) error {
 	return nil
 }
 
-// ValidateBand5 validates the voltage/temp coefficient band
+// ValidateBand5 validates the voltage band against the capacitor type
 func ValidateBand5(color Color, capType CapacitorType, bandCount int) error {
-	if bandCount < 4 {
-		return nil // No band 5 for 3-band capacitors
+	if bandCount < 5 {
+		return nil // Only 5-band capacitors have a voltage band
 	}
 
 	info := GetColorInfo(color)
 
-	// For voltage rating (4-band and 5-band), validate against type
-	if bandCount >= 4 {
-		_, valid := GetVoltageRatingFractional(capType, color)
-		if !valid {
-			typeInfo, _ := GetTypeInfo(capType)
-			return &ValidationError{
-				BandNumber: 5,
-				Message:    fmt.Sprintf("%s is not a valid voltage code for %s capacitors", info.Name, typeInfo.Description),
-			}
+	// Validate the voltage rating against the type
+	_, valid := GetVoltageRatingFractional(capType, color)
+	if !valid {
+		typeInfo, _ := GetTypeInfo(capType)
+		var valid []string
+		for _, c := range ValidVoltageColorsForType(capType) {
+			valid = append(valid, GetColorInfo(c).Name)
 		}
-	}
-
-	// For 5-band, also check if temp coefficient exists
-	if bandCount == 5 {
-		_, hasTempCoeff := GetTempCoefficient(color)
-		if !hasTempCoeff {
-			// This is just a warning - some colors don't have temp coefficients
-			// but still have voltage ratings
+		return &ValidationError{
+			BandNumber: 5,
+			Message: fmt.Sprintf("%s is not a valid voltage code for %s capacitors (must be one of %s)",
+				info.Name, typeInfo.Description, strings.Join(valid, ", ")),
 		}
 	}
 
+	// A Type K band 5 color without a temperature coefficient (e.g. Black)
+	// still has a voltage rating, so it is not an error
+
 	return nil
 }
 
 // ValidateReading validates an entire capacitor reading
 func ValidateReading(reading *CapacitorReading) error {
-	// Validate band 1
-	if err := ValidateBand1(reading.Band1); err != nil {
-		return err
+	if problems := ReadingProblems(reading); len(problems) > 0 {
+		return problems[0]
 	}
+	return nil
+}
 
-	// Validate band 2
-	if err := ValidateBand2(reading.Band2); err != nil {
-		return err
+// ReadingProblems validates every band of a capacitor reading and returns all
+// problems found, in band order
+func ReadingProblems(reading *CapacitorReading) []*ValidationError {
+	var problems []*ValidationError
+	add := func(err error) {
+		var ve *ValidationError
+		if errors.As(err, &ve) {
+			problems = append(problems, ve)
+		}
 	}
 
-	// Validate band 3
-	if err := ValidateBand3(reading.Band3); err != nil {
-		return err
+	add(ValidateBand1(reading.Band1))
+	add(ValidateBand2(reading.Band2))
+	if len(problems) == 0 && reading.Band1 == ColorBlack && reading.Band2 == ColorBlack {
+		// Unlike a zero-ohm resistor there is no zero-value capacitor
+		problems = append(problems, &ValidationError{
+			BandNumber: 2,
+			Message:    "Black-Black digits give 0 pF, which no capacitor is marked with; check the band order",
+		})
 	}
+	add(ValidateBand3(reading.Band3))
 
 	// Calculate capacitance for band 4 validation
 	info1 := GetColorInfo(reading.Band1)
 	info2 := GetColorInfo(reading.Band2)
 	info3 := GetColorInfo(reading.Band3)
 	capacitancePF := float64(info1.Digit*10+info2.Digit) * info3.Multiplier
-
-	// Validate band 4
-	if err := ValidateBand4(reading.Band4, capacitancePF); err != nil {
-		return err
+	if len(problems) > 0 {
+		// The ≤10pF rule needs a valid value; only check the color itself
+		capacitancePF = math.MaxFloat64
 	}
 
-	// Validate band 5 (if applicable)
+	// 3-band capacitors have no tolerance band
 	if reading.BandCount >= 4 {
-		if err := ValidateBand5(reading.Band5, reading.CapType, reading.BandCount); err != nil {
-			return err
-		}
+		add(ValidateBand4(reading.Band4, capacitancePF))
 	}
 
-	return nil
+	// Only 5-band capacitors have a voltage band
+	if reading.BandCount == 5 {
+		add(ValidateBand5(reading.Band5, reading.CapType, reading.BandCount))
+	}
+
+	return problems
 }
 
 // ValidateBandCount validates the band count selection
@@ -185,12 +202,15 @@ func GetBandDescription(bandNum int, bandCount int) string {
 	case 2:
 		return "Second significant digit (0-9)"
 	case 3:
-		return "Multiplier (power of 10)"
+		if bandCount == 3 {
+			return "Multiplier (×1, ×10, ×100, etc., or ×0.1, ×0.01); no tolerance band, so ±20%"
+		}
+		return "Multiplier (×1, ×10, ×100, etc., or ×0.1, ×0.01)"
 	case 4:
 		return "Tolerance (±%)"
 	case 5:
 		if bandCount == 5 {
-			return "Voltage rating and/or temperature coefficient"
+			return "Voltage rating (Type K: also temperature coefficient)"
 		}
 		return "Voltage rating"
 	default:
@@ -275,68 +295,73 @@ func ValidateResistorTempCoeff(color Color) error {
 	return nil
 }
 
+// ValidateResistorReliability validates the reliability band (5-band military only)
+func ValidateResistorReliability(color Color) error {
+	_, exists := GetReliabilityBand(color)
+	if !exists {
+		info := GetColorInfo(color)
+		return &ValidationError{
+			BandNumber: 5,
+			Message:    fmt.Sprintf("%s is not valid for reliability band (must be Brown, Red, Orange or Yellow)", info.Name),
+		}
+	}
+	return nil
+}
+
 // ValidateResistorReading validates an entire resistor reading
 func ValidateResistorReading(reading *ResistorReading) error {
-	switch reading.BandCount {
-	case 4:
-		// 4-band: Band1 Band2 Multiplier Tolerance
-		if err := ValidateResistorBand1(reading.Band1); err != nil {
-			return err
-		}
-		if err := ValidateResistorBand2(reading.Band2); err != nil {
-			return err
-		}
-		if err := ValidateResistorMultiplier(reading.Band3, 3); err != nil {
-			return err
-		}
-		if err := ValidateResistorTolerance(reading.Band4, 4); err != nil {
-			return err
-		}
+	if reading.BandCount == 1 && !reading.Military && IsZeroOhmJumper(*reading) {
+		return nil
+	}
+	if reading.BandCount < 4 || reading.BandCount > 6 {
+		return fmt.Errorf("invalid band count: %d (must be 4, 5, or 6)", reading.BandCount)
+	}
+	if reading.Military && reading.BandCount != 5 {
+		return fmt.Errorf("invalid band count for a military resistor: %d (must be 5)", reading.BandCount)
+	}
+	if problems := ResistorReadingProblems(reading); len(problems) > 0 {
+		return problems[0]
+	}
+	return nil
+}
 
-	case 5:
-		// 5-band: Band1 Band2 Band3 Multiplier Tolerance
-		if err := ValidateResistorBand1(reading.Band1); err != nil {
-			return err
-		}
-		if err := ValidateResistorBand2(reading.Band2); err != nil {
-			return err
-		}
-		if err := ValidateResistorBand3(reading.Band3); err != nil {
-			return err
-		}
-		if err := ValidateResistorMultiplier(reading.Band4, 4); err != nil {
-			return err
-		}
-		if err := ValidateResistorTolerance(reading.Band5, 5); err != nil {
-			return err
+// ResistorReadingProblems validates every band of a resistor reading and
+// returns all problems found, in band order
+func ResistorReadingProblems(reading *ResistorReading) []*ValidationError {
+	var problems []*ValidationError
+	add := func(err error) {
+		var ve *ValidationError
+		if errors.As(err, &ve) {
+			problems = append(problems, ve)
 		}
+	}
 
-	case 6:
-		// 6-band: Band1 Band2 Band3 Multiplier Tolerance TempCoeff
-		if err := ValidateResistorBand1(reading.Band1); err != nil {
-			return err
-		}
-		if err := ValidateResistorBand2(reading.Band2); err != nil {
-			return err
-		}
-		if err := ValidateResistorBand3(reading.Band3); err != nil {
-			return err
-		}
-		if err := ValidateResistorMultiplier(reading.Band4, 4); err != nil {
-			return err
-		}
-		if err := ValidateResistorTolerance(reading.Band5, 5); err != nil {
-			return err
-		}
-		if err := ValidateResistorTempCoeff(reading.Band6); err != nil {
-			return err
+	switch {
+	case reading.BandCount == 4, reading.Military:
+		// 4-band: Band1 Band2 Multiplier Tolerance
+		// 5-band military: adds Reliability
+		add(ValidateResistorBand1(reading.Band1))
+		add(ValidateResistorBand2(reading.Band2))
+		add(ValidateResistorMultiplier(reading.Band3, 3))
+		add(ValidateResistorTolerance(reading.Band4, 4))
+		if reading.Military {
+			add(ValidateResistorReliability(reading.Band5))
 		}
 
-	default:
-		return fmt.Errorf("invalid band count: %d (must be 4, 5, or 6)", reading.BandCount)
+	case reading.BandCount == 5, reading.BandCount == 6:
+		// 5-band: Band1 Band2 Band3 Multiplier Tolerance
+		// 6-band: adds TempCoeff
+		add(ValidateResistorBand1(reading.Band1))
+		add(ValidateResistorBand2(reading.Band2))
+		add(ValidateResistorBand3(reading.Band3))
+		add(ValidateResistorMultiplier(reading.Band4, 4))
+		add(ValidateResistorTolerance(reading.Band5, 5))
+		if reading.BandCount == 6 {
+			add(ValidateResistorTempCoeff(reading.Band6))
+		}
 	}
 
-	return nil
+	return problems
 }
 
 // ValidateResistorBandCount validates the resistor band count selection
@@ -2,36 +2,180 @@ package main
 
 import (
 	"encoding/csv"
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
+
+	"tropical-fish/decoder"
 )
 
 // ComponentEntry represents a decoded component (capacitor or resistor) with notes
 type ComponentEntry struct {
-	ComponentType   ComponentType
-	CapacitorResult *CalculationResult
-	ResistorResult  *ResistorResult
+	ComponentType   decoder.ComponentType
+	CapacitorResult *decoder.CalculationResult
+	ResistorResult  *decoder.ResistorResult
 	Note            string
+	Package         string  // User-entered package for BOMs, e.g. "0805"
+	Quantity        int     // Number of identical parts for BOMs (0 counts as 1)
+	MeasuredValue   float64 // Measured value in pF or Ω, if Measured
+	Measured        bool    // A measurement was entered
+}
+
+// ExportOptions holds session-wide settings that add optional export columns
+type ExportOptions struct {
+	FrequencyHz     float64 // Design frequency for reactance columns (0 = omit)
+	Aggregate       bool    // One row per distinct part with a Qty column
+	CapacitanceUnit string  // Fixed unit for capacitor values, e.g. "nF" ("" = auto-scale)
+	ResistanceUnit  string  // Fixed unit for resistor values, e.g. "kΩ" ("" = auto-scale)
 }
 
 // ExportToCSV exports the component history to a CSV file
 func ExportToCSV(history []ComponentEntry, filename string) error {
+	return ExportToCSVWithOptions(history, filename, ExportOptions{})
+}
+
+// ExportToCSVWithOptions exports the component history to a CSV file,
+// adding frequency and capacitive reactance columns when a frequency is set
+func ExportToCSVWithOptions(history []ComponentEntry, filename string, opts ExportOptions) error {
+	if len(history) == 0 {
+		return fmt.Errorf("no component data to export")
+	}
+
+	return WriteCSVRecords(filename, BuildCSVRecordsWithOptions(history, opts), false)
+}
+
+// AppendToCSVWithOptions appends the component history to an existing export
+// file without writing the header again. Each row keeps the timestamp of the
+// export that wrote it, so appended runs can be told apart.
+// Returns an error if the file does not start with the header for opts
+func AppendToCSVWithOptions(history []ComponentEntry, filename string, opts ExportOptions) error {
 	if len(history) == 0 {
 		return fmt.Errorf("no component data to export")
 	}
 
-	// Create or overwrite the CSV file
-	file, err := os.Create(filename)
+	return WriteCSVRecords(filename, BuildCSVRecordsWithOptions(history, opts), true)
+}
+
+// CSVHeaderMatches reports whether filename exists and starts with the export
+// header for opts, so rows can be appended to it
+func CSVHeaderMatches(filename string, opts ExportOptions) bool {
+	return csvStartsWith(filename, BuildCSVHeaderWithOptions(opts))
+}
+
+// csvStartsWith reports whether filename exists and its first record is header
+func csvStartsWith(filename string, header []string) bool {
+	file, err := os.Open(filename)
 	if err != nil {
-		return fmt.Errorf("failed to create file: %w", err)
+		return false
 	}
 	defer file.Close()
 
-	writer := csv.NewWriter(file)
-	defer writer.Flush()
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	first, err := reader.Read()
+	if err != nil {
+		return false
+	}
+	return slices.Equal(first, header)
+}
+
+// BuildCSVRecords returns the CSV export of the history: the header followed
+// by one row per entry
+func BuildCSVRecords(history []ComponentEntry) [][]string {
+	return BuildCSVRecordsWithOptions(history, ExportOptions{})
+}
+
+// BuildCSVRecordsWithOptions returns the header and the rows an export with
+// opts writes, one per entry or per group of identical parts, stamped with
+// the current time. Entries without a result are skipped.
+func BuildCSVRecordsWithOptions(history []ComponentEntry, opts ExportOptions) [][]string {
+	records := [][]string{BuildCSVHeaderWithOptions(opts)}
+	now := time.Now()
+
+	if opts.Aggregate {
+		for _, group := range AggregateHistory(history) {
+			record, _ := BuildCSVRecordWithOptions(group.Entry, now, opts)
+			records = append(records, append(record, strconv.Itoa(group.Quantity)))
+		}
+		return records
+	}
+	for _, entry := range history {
+		if record, err := BuildCSVRecordWithOptions(entry, now, opts); err == nil {
+			records = append(records, record)
+		}
+	}
+
+	return records
+}
+
+// WriteCSVRecords writes records from BuildCSVRecords to filename, replacing
+// the file, or with appendRows adds the rows after those of an existing
+// export with the same header
+func WriteCSVRecords(filename string, records [][]string, appendRows bool) error {
+	var file *os.File
+	var err error
+	if appendRows {
+		if !csvStartsWith(filename, records[0]) {
+			return fmt.Errorf("cannot append to %s: its columns differ from this export", filepath.Base(filename))
+		}
+		file, err = os.OpenFile(filename, os.O_APPEND|os.O_WRONLY, 0)
+		if err != nil {
+			return fmt.Errorf("failed to open file: %w", err)
+		}
+		records = records[1:]
+	} else {
+		// Create or overwrite the CSV file
+		file, err = os.Create(filename)
+		if err != nil {
+			return fmt.Errorf("failed to create file: %w", err)
+		}
+	}
+	defer file.Close()
+
+	if err := csv.NewWriter(file).WriteAll(records); err != nil {
+		return fmt.Errorf("failed to write records: %w", err)
+	}
+	return nil
+}
 
-	// Write CSV header
+// csvLine formats one record as it appears in the CSV file
+func csvLine(record []string) string {
+	var b strings.Builder
+	writer := csv.NewWriter(&b)
+	_ = writer.Write(record)
+	writer.Flush()
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// writeHistoryCSV writes the export header and one row per entry, or per
+// group of identical parts, to w
+func writeHistoryCSV(w io.Writer, history []ComponentEntry, opts ExportOptions) error {
+	if err := csv.NewWriter(w).WriteAll(BuildCSVRecordsWithOptions(history, opts)); err != nil {
+		return fmt.Errorf("failed to write records: %w", err)
+	}
+	return nil
+}
+
+// csvTimestampLayout is the format of the CSV Timestamp column
+const csvTimestampLayout = "2006-01-02 15:04:05"
+
+// csvBandColumns is the number of Band columns in the CSV export
+const csvBandColumns = 6
+
+// BuildCSVHeader returns the CSV export column names
+func BuildCSVHeader() []string {
+	return BuildCSVHeaderWithOptions(ExportOptions{})
+}
+
+// BuildCSVHeaderWithOptions returns the CSV export column names, with the
+// frequency and Qty columns that opts adds
+func BuildCSVHeaderWithOptions(opts ExportOptions) []string {
 	header := []string{
 		"Timestamp",
 		"Component Type",
@@ -51,131 +195,461 @@ func ExportToCSV(history []ComponentEntry, filename string) error {
 		"Voltage (V)",
 		"Temp Coefficient",
 		"Note",
+		"Measured Value",
+		"In Tolerance?",
+		"Military",
 	}
-
-	if err := writer.Write(header); err != nil {
-		return fmt.Errorf("failed to write header: %w", err)
+	if opts.FrequencyHz > 0 {
+		header = append(header, "Frequency (Hz)", "Xc (Ω)")
+	}
+	if opts.Aggregate {
+		header = append(header, "Qty")
 	}
+	return header
+}
 
-	// Write each component entry
-	timestamp := time.Now().Format("2006-01-02 15:04:05")
-	for _, entry := range history {
-		var record []string
-
-		if entry.ComponentType == ComponentCapacitor && entry.CapacitorResult != nil {
-			result := entry.CapacitorResult
-
-			// Get color names for bands
-			band1Name := GetColorInfo(result.Reading.Band1).Name
-			band2Name := GetColorInfo(result.Reading.Band2).Name
-			band3Name := GetColorInfo(result.Reading.Band3).Name
-			band4Name := GetColorInfo(result.Reading.Band4).Name
-			band5Name := ""
-			if result.Reading.BandCount >= 4 {
-				band5Name = GetColorInfo(result.Reading.Band5).Name
-			}
+// BuildCSVRecord returns the CSV export row for an entry decoded at ts, or
+// an error if the entry has no result
+func BuildCSVRecord(entry ComponentEntry, ts time.Time) ([]string, error) {
+	return BuildCSVRecordWithOptions(entry, ts, ExportOptions{})
+}
+
+// BuildCSVRecordWithOptions returns the CSV export row for an entry, with
+// values in the fixed units and the reactance columns that opts sets. The
+// Qty column of a grouped export is left to the caller.
+func BuildCSVRecordWithOptions(entry ComponentEntry, ts time.Time, opts ExportOptions) ([]string, error) {
+	var record []string
+	timestamp := ts.Format(csvTimestampLayout)
 
-			// Format tolerance
-			tolerancePercent := ""
-			if result.ToleranceType == "percentage" {
+	if entry.ComponentType == decoder.ComponentCapacitor && entry.CapacitorResult != nil {
+		result := entry.CapacitorResult
+		reading := result.Reading
+
+		// Format tolerance (asymmetric tolerances list both bounds)
+		tolerancePercent := ""
+		if result.ToleranceType == "percentage" {
+			if result.ToleranceSymmetric {
 				tolerancePercent = fmt.Sprintf("%.1f", result.TolerancePercent)
+			} else {
+				tolerancePercent = fmt.Sprintf("+%.1f/-%.1f", result.ToleranceHigh, result.ToleranceLow)
 			}
+		}
 
-			// Format voltage
-			voltage := ""
-			if result.VoltageValid {
-				voltage = fmt.Sprintf("%.1f", result.VoltageRating)
-			}
+		// Format voltage
+		voltage := ""
+		if result.VoltageValid {
+			voltage = fmt.Sprintf("%.1f", result.VoltageRating)
+		}
 
-			// Format temperature coefficient
-			tempCoeff := ""
-			if result.TempCoeffValid {
-				tempCoeff = fmt.Sprintf("%d", result.TempCoefficient)
-			}
+		// Format temperature coefficient
+		tempCoeff := ""
+		if result.TempCoeffValid {
+			tempCoeff = fmt.Sprintf("%d", result.TempCoefficient)
+		}
 
-			// Format min/max values
-			minVal := FormatCapacitance(result.MinValue, result.MinUnit)
-			maxVal := FormatCapacitance(result.MaxValue, result.MaxUnit)
-
-			record = []string{
-				timestamp,
-				"Capacitor",
-				string(result.Reading.CapType),
-				fmt.Sprintf("%d", result.Reading.BandCount),
-				band1Name,
-				band2Name,
-				band3Name,
-				band4Name,
-				band5Name,
-				"",
-				fmt.Sprintf("%.3f", result.CapacitanceValue),
-				result.CapacitanceUnit,
-				tolerancePercent,
-				minVal,
-				maxVal,
-				voltage,
-				tempCoeff,
-				entry.Note,
-			}
+		// Format value and min/max, in the fixed unit if one is set
+		value, unit := fmt.Sprintf("%.3f", result.CapacitanceValue), result.CapacitanceUnit
+		minVal := decoder.FormatCapacitance(result.MinValue, result.MinUnit)
+		maxVal := decoder.FormatCapacitance(result.MaxValue, result.MaxUnit)
+		if fixed, ok := decoder.CapacitanceInUnit(result.CapacitancePF, opts.CapacitanceUnit); ok {
+			value, unit = decoder.ExplainNumber(fixed), opts.CapacitanceUnit
+			minVal = decoder.FormatCapacitanceInUnit(result.MinValue*decoder.CapacitanceUnitPF[result.MinUnit], unit)
+			maxVal = decoder.FormatCapacitanceInUnit(result.MaxValue*decoder.CapacitanceUnitPF[result.MaxUnit], unit)
+		}
 
-		} else if entry.ComponentType == ComponentResistor && entry.ResistorResult != nil {
-			result := entry.ResistorResult
-
-			// Get color names for bands
-			band1Name := GetColorInfo(result.Reading.Band1).Name
-			band2Name := GetColorInfo(result.Reading.Band2).Name
-			band3Name := GetColorInfo(result.Reading.Band3).Name
-			band4Name := GetColorInfo(result.Reading.Band4).Name
-			band5Name := ""
-			if result.Reading.BandCount >= 5 {
-				band5Name = GetColorInfo(result.Reading.Band5).Name
-			}
-			band6Name := ""
-			if result.Reading.BandCount == 6 {
-				band6Name = GetColorInfo(result.Reading.Band6).Name
-			}
+		record = []string{
+			timestamp,
+			"Capacitor",
+			string(reading.CapType),
+			fmt.Sprintf("%d", reading.BandCount),
+		}
+		record = append(record, csvBandNames(reading.Colors())...)
+		record = append(record,
+			value,
+			unit,
+			tolerancePercent,
+			minVal,
+			maxVal,
+			voltage,
+			tempCoeff,
+			entry.Note,
+		)
 
-			// Format tolerance
-			tolerancePercent := fmt.Sprintf("%.2f", result.TolerancePercent)
+	} else if entry.ComponentType == decoder.ComponentResistor && entry.ResistorResult != nil {
+		result := entry.ResistorResult
+		reading := result.Reading
 
-			// Format temperature coefficient
-			tempCoeff := ""
-			if result.TempCoeffValid {
-				tempCoeff = fmt.Sprintf("%d ppm/°C", result.TempCoefficient)
-			}
+		// Format tolerance (asymmetric tolerances list both bounds)
+		tolerancePercent := fmt.Sprintf("%.2f", result.TolerancePercent)
+		if result.ToleranceHigh != result.ToleranceLow {
+			tolerancePercent = fmt.Sprintf("+%.2f/-%.2f", result.ToleranceHigh, result.ToleranceLow)
+		}
+
+		// Format temperature coefficient
+		tempCoeff := ""
+		if result.TempCoeffValid {
+			tempCoeff = fmt.Sprintf("%d ppm/°C", result.TempCoefficient)
+		}
+
+		// Format value and min/max, in the fixed unit if one is set
+		value, unit := fmt.Sprintf("%.3f", result.ResistanceValue), result.ResistanceUnit
+		minVal := decoder.FormatResistance(result.MinValue, result.MinUnit)
+		maxVal := decoder.FormatResistance(result.MaxValue, result.MaxUnit)
+		if fixed, ok := decoder.ResistanceInUnit(result.ResistanceOhms, opts.ResistanceUnit); ok {
+			value, unit = decoder.ExplainNumber(fixed), opts.ResistanceUnit
+			minVal = decoder.FormatResistanceInUnit(result.MinValue*decoder.ResistanceUnitOhms[result.MinUnit], unit)
+			maxVal = decoder.FormatResistanceInUnit(result.MaxValue*decoder.ResistanceUnitOhms[result.MaxUnit], unit)
+		}
+
+		// A jumper is exactly 0 Ω, with no tolerance or range to write
+		if result.Jumper {
+			value, tolerancePercent, minVal, maxVal = "0", "", "", ""
+		}
+
+		record = []string{
+			timestamp,
+			"Resistor",
+			"",
+			fmt.Sprintf("%d", reading.BandCount),
+		}
+		record = append(record, csvBandNames(reading.Colors())...)
+		record = append(record,
+			value,
+			unit,
+			tolerancePercent,
+			minVal,
+			maxVal,
+			"",
+			tempCoeff,
+			entry.Note,
+		)
+	} else {
+		return nil, fmt.Errorf("entry has no decoded result")
+	}
+
+	record = append(record, measurementColumns(entry)...)
+	record = append(record, militaryColumn(entry))
 
-			// Format min/max values
-			minVal := FormatResistance(result.MinValue, result.MinUnit)
-			maxVal := FormatResistance(result.MaxValue, result.MaxUnit)
-
-			record = []string{
-				timestamp,
-				"Resistor",
-				"",
-				fmt.Sprintf("%d", result.Reading.BandCount),
-				band1Name,
-				band2Name,
-				band3Name,
-				band4Name,
-				band5Name,
-				band6Name,
-				fmt.Sprintf("%.3f", result.ResistanceValue),
-				result.ResistanceUnit,
-				tolerancePercent,
-				minVal,
-				maxVal,
-				"",
-				tempCoeff,
-				entry.Note,
+	// Reactance columns are left blank for resistors
+	if opts.FrequencyHz > 0 {
+		reactance := ""
+		if entry.ComponentType == decoder.ComponentCapacitor {
+			if xc, ok := decoder.CapacitiveReactance(entry.CapacitorResult.CapacitancePF, opts.FrequencyHz); ok {
+				reactance = fmt.Sprintf("%.3f", xc)
 			}
-		} else {
-			continue
 		}
+		record = append(record, fmt.Sprintf("%g", opts.FrequencyHz), reactance)
+	}
+
+	return record, nil
+}
+
+// militaryColumn returns the "Military" export column: "yes" for a 5-band
+// military resistor, whose fifth band is a failure rate, blank otherwise
+func militaryColumn(entry ComponentEntry) string {
+	if entry.ComponentType == decoder.ComponentResistor && entry.ResistorResult != nil &&
+		entry.ResistorResult.Reading.Military {
+		return "yes"
+	}
+	return ""
+}
+
+// csvBandNames returns the Band columns for a reading's colors: the English
+// names of its bands, then blanks for the bands it doesn't have
+func csvBandNames(colors []decoder.Color) []string {
+	names := bandNames(colors)
+	for len(names) < csvBandColumns {
+		names = append(names, "")
+	}
+	return names
+}
+
+// jsonCapacitor is the JSON form of a CalculationResult
+type jsonCapacitor struct {
+	CapType             string   `json:"cap_type"`
+	BandCount           int      `json:"band_count"`
+	Bands               []string `json:"bands"`
+	CapacitancePF       float64  `json:"capacitance_pf"`
+	CapacitanceValue    float64  `json:"capacitance_value"`
+	CapacitanceUnit     string   `json:"capacitance_unit"`
+	ToleranceType       string   `json:"tolerance_type"`
+	TolerancePercent    float64  `json:"tolerance_percent"`
+	ToleranceAbsolutePF float64  `json:"tolerance_absolute_pf"`
+	ToleranceSymmetric  bool     `json:"tolerance_symmetric"`
+	ToleranceHigh       float64  `json:"tolerance_high_percent"`
+	ToleranceLow        float64  `json:"tolerance_low_percent"`
+	ToleranceImplied    bool     `json:"tolerance_implied"`
+	MinValue            float64  `json:"min_value"`
+	MinUnit             string   `json:"min_unit"`
+	MaxValue            float64  `json:"max_value"`
+	MaxUnit             string   `json:"max_unit"`
+	VoltageRating       float64  `json:"voltage_rating"`
+	VoltageValid        bool     `json:"voltage_valid"`
+	TempCoefficient     int      `json:"temp_coefficient"`
+	TempCoeffValid      bool     `json:"temp_coefficient_valid"`
+}
+
+// jsonResistor is the JSON form of a ResistorResult
+type jsonResistor struct {
+	BandCount          int      `json:"band_count"`
+	Bands              []string `json:"bands"`
+	ResistanceOhms     float64  `json:"resistance_ohms"`
+	ResistanceValue    float64  `json:"resistance_value"`
+	ResistanceUnit     string   `json:"resistance_unit"`
+	TolerancePercent   float64  `json:"tolerance_percent"`
+	ToleranceHigh      float64  `json:"tolerance_high_percent"`
+	ToleranceLow       float64  `json:"tolerance_low_percent"`
+	MinValue           float64  `json:"min_value"`
+	MinUnit            string   `json:"min_unit"`
+	MaxValue           float64  `json:"max_value"`
+	MaxUnit            string   `json:"max_unit"`
+	TempCoefficient    int      `json:"temp_coefficient"`
+	TempCoeffValid     bool     `json:"temp_coefficient_valid"`
+	Military           bool     `json:"military,omitempty"`
+	FailureRatePercent float64  `json:"failure_rate_percent,omitempty"`
+}
+
+// jsonEntry is the JSON form of a ComponentEntry; exactly one of Capacitor
+// and Resistor is set
+type jsonEntry struct {
+	ComponentType string         `json:"component_type"` // "capacitor" or "resistor"
+	Capacitor     *jsonCapacitor `json:"capacitor,omitempty"`
+	Resistor      *jsonResistor  `json:"resistor,omitempty"`
+	Note          string         `json:"note"`
+	Package       string         `json:"package"`
+	Quantity      int            `json:"quantity"`
+	MeasuredValue *float64       `json:"measured_value"` // pF or Ω, null if not measured
+}
+
+// jsonExport is the top-level document written by ExportToJSON
+type jsonExport struct {
+	ExportedAt string      `json:"exported_at"`
+	Components []jsonEntry `json:"components"`
+}
 
-		if err := writer.Write(record); err != nil {
-			return fmt.Errorf("failed to write record: %w", err)
+// bandNames returns the English names of a reading's bands, so exported
+// files do not depend on the display language
+func bandNames(colors []decoder.Color) []string {
+	names := make([]string, 0, len(colors))
+	for _, c := range colors {
+		names = append(names, decoder.GetColorInfo(c).Name)
+	}
+	return names
+}
+
+// newJSONEntry converts a history entry to its JSON form
+func newJSONEntry(entry ComponentEntry) (jsonEntry, bool) {
+	out := jsonEntry{
+		Note:     entry.Note,
+		Package:  entry.Package,
+		Quantity: max(entry.Quantity, 1),
+	}
+	if entry.Measured {
+		measured := entry.MeasuredValue
+		out.MeasuredValue = &measured
+	}
+
+	switch {
+	case entry.ComponentType == decoder.ComponentCapacitor && entry.CapacitorResult != nil:
+		r := entry.CapacitorResult
+		reading := r.Reading
+		out.ComponentType = "capacitor"
+		out.Capacitor = &jsonCapacitor{
+			CapType:             string(reading.CapType),
+			BandCount:           reading.BandCount,
+			Bands:               bandNames(reading.Colors()),
+			CapacitancePF:       r.CapacitancePF,
+			CapacitanceValue:    r.CapacitanceValue,
+			CapacitanceUnit:     r.CapacitanceUnit,
+			ToleranceType:       r.ToleranceType,
+			TolerancePercent:    r.TolerancePercent,
+			ToleranceAbsolutePF: r.ToleranceAbsolutePF,
+			ToleranceSymmetric:  r.ToleranceSymmetric,
+			ToleranceHigh:       r.ToleranceHigh,
+			ToleranceLow:        r.ToleranceLow,
+			ToleranceImplied:    r.ToleranceImplied,
+			MinValue:            r.MinValue,
+			MinUnit:             r.MinUnit,
+			MaxValue:            r.MaxValue,
+			MaxUnit:             r.MaxUnit,
+			VoltageRating:       r.VoltageRating,
+			VoltageValid:        r.VoltageValid,
+			TempCoefficient:     r.TempCoefficient,
+			TempCoeffValid:      r.TempCoeffValid,
+		}
+	case entry.ComponentType == decoder.ComponentResistor && entry.ResistorResult != nil:
+		r := entry.ResistorResult
+		reading := r.Reading
+		out.ComponentType = "resistor"
+		out.Resistor = &jsonResistor{
+			BandCount:          reading.BandCount,
+			Bands:              bandNames(reading.Colors()),
+			ResistanceOhms:     r.ResistanceOhms,
+			ResistanceValue:    r.ResistanceValue,
+			ResistanceUnit:     r.ResistanceUnit,
+			TolerancePercent:   r.TolerancePercent,
+			ToleranceHigh:      r.ToleranceHigh,
+			ToleranceLow:       r.ToleranceLow,
+			MinValue:           r.MinValue,
+			MinUnit:            r.MinUnit,
+			MaxValue:           r.MaxValue,
+			MaxUnit:            r.MaxUnit,
+			TempCoefficient:    r.TempCoefficient,
+			TempCoeffValid:     r.TempCoeffValid,
+			Military:           reading.Military,
+			FailureRatePercent: r.FailureRatePercent,
 		}
+	default:
+		return jsonEntry{}, false
+	}
+	return out, true
+}
+
+// ExportToJSON exports the component history to a JSON file with the full
+// calculation results, including asymmetric tolerances and fractional
+// voltages that the CSV export rounds or flattens
+func ExportToJSON(history []ComponentEntry, filename string) error {
+	return ExportToJSONWithOptions(history, filename, ExportOptions{})
+}
+
+// ExportToJSONWithOptions exports the component history to a JSON file,
+// one component per distinct part with its quantity when opts.Aggregate is
+// set. The frequency option applies to the CSV columns only.
+func ExportToJSONWithOptions(history []ComponentEntry, filename string, opts ExportOptions) error {
+	if opts.Aggregate {
+		history = aggregatedEntries(history)
+	}
+
+	doc := jsonExport{
+		ExportedAt: time.Now().Format(time.RFC3339),
+		Components: []jsonEntry{},
+	}
+	for _, entry := range history {
+		if out, ok := newJSONEntry(entry); ok {
+			doc.Components = append(doc.Components, out)
+		}
+	}
+	if len(doc.Components) == 0 {
+		return fmt.Errorf("no component data to export")
 	}
 
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode JSON: %w", err)
+	}
+	if err := os.WriteFile(filename, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	return nil
+}
+
+// aggregatedEntries returns one entry per distinct part, with its Quantity set
+// to the size of the group
+func aggregatedEntries(history []ComponentEntry) []ComponentEntry {
+	groups := AggregateHistory(history)
+	entries := make([]ComponentEntry, len(groups))
+	for i, group := range groups {
+		entries[i] = group.Entry
+		entries[i].Quantity = group.Quantity
+	}
+	return entries
+}
+
+// markdownCell escapes a value for a Markdown table cell, so pipes and line
+// breaks in notes do not split the row
+func markdownCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	s = strings.NewReplacer("\r\n", " ", "\n", " ", "\r", " ").Replace(s)
+	if s == "" {
+		return "—"
+	}
+	return s
+}
+
+// markdownRow returns the type, value (with tolerance and range), voltage /
+// temp coefficient and note cells for an entry
+func markdownRow(entry ComponentEntry) ([]string, bool) {
+	var cells []string
+	switch {
+	case entry.ComponentType == decoder.ComponentCapacitor && entry.CapacitorResult != nil:
+		r := entry.CapacitorResult
+		var ratings []string
+		if r.VoltageValid {
+			ratings = append(ratings, decoder.FormatVoltage(r))
+		}
+		if r.TempCoeffValid {
+			ratings = append(ratings, decoder.FormatTempCoefficient(r))
+		}
+		cells = []string{
+			fmt.Sprintf("Capacitor (Type %s, %d-band)", r.Reading.CapType, r.Reading.BandCount),
+			ResultSummaryLine(entry),
+			strings.Join(ratings, ", "),
+		}
+	case entry.ComponentType == decoder.ComponentResistor && entry.ResistorResult != nil:
+		r := entry.ResistorResult
+		rating := ""
+		if r.TempCoeffValid {
+			rating = decoder.FormatResistorTempCoefficient(r)
+		} else if r.FailureRateValid {
+			rating = decoder.FormatFailureRate(r)
+		}
+		cells = []string{
+			"Resistor (" + r.Reading.Configuration() + ")",
+			ResultSummaryLine(entry),
+			rating,
+		}
+	default:
+		return nil, false
+	}
+	return append(cells, entry.Note), true
+}
+
+// ExportToMarkdown exports the component history to a Markdown table, for
+// pasting into issues and notebooks
+func ExportToMarkdown(history []ComponentEntry, filename string) error {
+	return ExportToMarkdownWithOptions(history, filename, ExportOptions{})
+}
+
+// ExportToMarkdownWithOptions exports the component history to a Markdown
+// table, one row per distinct part with a Qty column when opts.Aggregate is
+// set. The frequency option applies to the CSV columns only.
+func ExportToMarkdownWithOptions(history []ComponentEntry, filename string, opts ExportOptions) error {
+	if opts.Aggregate {
+		history = aggregatedEntries(history)
+	}
+
+	header := []string{"Type", "Value", "Voltage / Temp Coeff", "Note"}
+	if opts.Aggregate {
+		header = append(header, "Qty")
+	}
+
+	var rows []string
+	for _, entry := range history {
+		cells, ok := markdownRow(entry)
+		if !ok {
+			continue
+		}
+		if opts.Aggregate {
+			cells = append(cells, strconv.Itoa(max(entry.Quantity, 1)))
+		}
+		for i := range cells {
+			cells[i] = markdownCell(cells[i])
+		}
+		rows = append(rows, "| "+strings.Join(cells, " | ")+" |")
+	}
+	if len(rows) == 0 {
+		return fmt.Errorf("no component data to export")
+	}
+
+	var b strings.Builder
+	b.WriteString("| " + strings.Join(header, " | ") + " |\n")
+	b.WriteString("|" + strings.Repeat(" --- |", len(header)) + "\n")
+	for _, row := range rows {
+		b.WriteString(row + "\n")
+	}
+
+	if err := os.WriteFile(filename, []byte(b.String()), 0o644); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
 	return nil
 }
@@ -0,0 +1,412 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"tropical-fish/decoder"
+)
+
+// TestExportCmdSnapshot tests that exportCmd exports the history as it was when started
+func TestExportCmdSnapshot(t *testing.T) {
+	history := []ComponentEntry{{Note: "first"}, {Note: "second"}}
+
+	var exported []ComponentEntry
+	cmd := exportCmd(func(h []ComponentEntry, path string) error {
+		exported = h
+		return nil
+	}, history, 3, "out.csv")
+
+	// Edit the live history before the command runs
+	history[0].Note = "edited"
+
+	msg, ok := cmd().(exportResultMsg)
+	if !ok {
+		t.Fatalf("exportCmd() returned %T, want exportResultMsg", cmd())
+	}
+	if msg.err != nil || msg.path != "out.csv" || msg.count != 2 || msg.trimmed != 3 {
+		t.Errorf("exportResultMsg = %+v, want no error, path out.csv, count 2, trimmed 3", msg)
+	}
+	if exported[0].Note != "first" {
+		t.Errorf("exported note = %q, want %q", exported[0].Note, "first")
+	}
+}
+
+// TestAggregatedExportRoundTrip tests the Qty column survives export and import
+func TestAggregatedExportRoundTrip(t *testing.T) {
+	entry := mustDecode(t, cliOptions{resistor: true, bands: "brown,black,red,gold"}, "")
+	path := filepath.Join(t.TempDir(), "grouped.csv")
+
+	if err := ExportToCSVWithOptions([]ComponentEntry{entry, entry, entry}, path, ExportOptions{Aggregate: true}); err != nil {
+		t.Fatalf("ExportToCSVWithOptions() error = %v", err)
+	}
+
+	imported, err := ImportFromCSV(path)
+	if err != nil {
+		t.Fatalf("ImportFromCSV() error = %v", err)
+	}
+	if len(imported) != 1 {
+		t.Fatalf("imported %d entries, want 1", len(imported))
+	}
+	if imported[0].Quantity != 3 {
+		t.Errorf("imported quantity = %d, want 3", imported[0].Quantity)
+	}
+}
+
+// TestFixedUnitExport tests that the results screen unit lock cycles back to
+// auto-scaling and puts every CSV value in the chosen unit
+func TestFixedUnitExport(t *testing.T) {
+	m := initialModel()
+	m.screen = screenResults
+	m.componentType = decoder.ComponentResistor
+	var units []string
+	for range len(decoder.ResistanceDisplayUnits) + 1 {
+		m = pressKeys(m, "l")
+		units = append(units, m.resistanceUnit)
+	}
+	if want := []string{"Ω", "kΩ", "MΩ", ""}; !slices.Equal(units, want) {
+		t.Errorf("lock unit cycle = %q, want %q", units, want)
+	}
+
+	small := mustDecode(t, cliOptions{resistor: true, bands: "yellow,violet,black,gold"}, "")
+	large := mustDecode(t, cliOptions{resistor: true, bands: "red,red,green,gold"}, "")
+	path := filepath.Join(t.TempDir(), "fixed.csv")
+	if err := ExportToCSVWithOptions([]ComponentEntry{small, large}, path, ExportOptions{ResistanceUnit: "kΩ"}); err != nil {
+		t.Fatalf("ExportToCSVWithOptions() error = %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{",0.047,kΩ,5.00,0.04465 kΩ,0.04935 kΩ,", ",2200,kΩ,5.00,2090 kΩ,2310 kΩ,"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("export missing %q:\n%s", want, data)
+		}
+	}
+}
+
+// TestAppendToCSV tests that appending adds rows under the existing header and
+// refuses a file with different columns
+func TestAppendToCSV(t *testing.T) {
+	entry := mustDecode(t, cliOptions{resistor: true, bands: "brown,black,red,gold"}, "")
+	path := filepath.Join(t.TempDir(), "components.csv")
+
+	if err := ExportToCSV([]ComponentEntry{entry}, path); err != nil {
+		t.Fatalf("ExportToCSV() error = %v", err)
+	}
+	if !CSVHeaderMatches(path, ExportOptions{}) {
+		t.Fatal("CSVHeaderMatches() = false for a fresh export")
+	}
+	if err := AppendToCSVWithOptions([]ComponentEntry{entry, entry}, path, ExportOptions{}); err != nil {
+		t.Fatalf("AppendToCSVWithOptions() error = %v", err)
+	}
+
+	imported, err := ImportFromCSV(path)
+	if err != nil {
+		t.Fatalf("ImportFromCSV() error = %v", err)
+	}
+	if len(imported) != 3 {
+		t.Errorf("imported %d entries after append, want 3", len(imported))
+	}
+
+	// A grouped export has an extra Qty column
+	if CSVHeaderMatches(path, ExportOptions{Aggregate: true}) {
+		t.Error("CSVHeaderMatches() = true for different columns")
+	}
+	if err := AppendToCSVWithOptions([]ComponentEntry{entry}, path, ExportOptions{Aggregate: true}); err == nil {
+		t.Error("AppendToCSVWithOptions() with different columns error = nil, want error")
+	}
+	if CSVHeaderMatches(filepath.Join(t.TempDir(), "missing.csv"), ExportOptions{}) {
+		t.Error("CSVHeaderMatches() = true for a missing file")
+	}
+}
+
+// TestExportToJSON tests that the JSON export keeps the asymmetric tolerance
+// and fractional voltage fields under stable snake_case names
+func TestExportToJSON(t *testing.T) {
+	// Grey tolerance (+80/-20%) on a 6.3V Type M electrolytic
+	capEntry := mustDecode(t, cliOptions{capacitor: true, capType: "M", bands: "brown,black,yellow,grey,yellow"}, "C1")
+	resEntry := mustDecode(t, cliOptions{resistor: true, bands: "brown,black,red,gold"}, "")
+	resEntry.Measured = true
+	resEntry.MeasuredValue = 990
+
+	path := filepath.Join(t.TempDir(), "history.json")
+	if err := ExportToJSON([]ComponentEntry{capEntry, resEntry}, path); err != nil {
+		t.Fatalf("ExportToJSON() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc struct {
+		Components []map[string]any `json:"components"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(doc.Components) != 2 {
+		t.Fatalf("exported %d components, want 2", len(doc.Components))
+	}
+
+	capacitor, _ := doc.Components[0]["capacitor"].(map[string]any)
+	want := map[string]any{
+		"cap_type":               "M",
+		"tolerance_type":         "percentage",
+		"tolerance_symmetric":    false,
+		"tolerance_high_percent": 80.0,
+		"tolerance_low_percent":  20.0,
+		"voltage_rating":         6.3,
+		"voltage_valid":          true,
+		"min_unit":               "nF",
+	}
+	for key, value := range want {
+		if capacitor[key] != value {
+			t.Errorf("capacitor[%q] = %v, want %v", key, capacitor[key], value)
+		}
+	}
+	if doc.Components[0]["note"] != "C1" || doc.Components[0]["measured_value"] != nil {
+		t.Errorf("capacitor entry = %v, want note C1 and no measured value", doc.Components[0])
+	}
+
+	if doc.Components[1]["component_type"] != "resistor" || doc.Components[1]["measured_value"] != 990.0 {
+		t.Errorf("resistor entry = %v, want a resistor measured at 990", doc.Components[1])
+	}
+	if _, ok := doc.Components[1]["capacitor"]; ok {
+		t.Error("resistor entry has a capacitor object")
+	}
+	resistor, _ := doc.Components[1]["resistor"].(map[string]any)
+	if resistor["tolerance_high_percent"] != 5.0 || resistor["tolerance_low_percent"] != 5.0 {
+		t.Errorf("resistor tolerance bounds = +%v / -%v, want ±5", resistor["tolerance_high_percent"], resistor["tolerance_low_percent"])
+	}
+
+	if err := ExportToJSON(nil, path); err == nil {
+		t.Error("ExportToJSON() with no history error = nil, want error")
+	}
+}
+
+// TestExportToMarkdown tests the Markdown table export, including pipes in notes
+func TestExportToMarkdown(t *testing.T) {
+	capEntry := mustDecode(t, cliOptions{capacitor: true, capType: "M", bands: "brown,black,yellow,grey,yellow"}, "C1 | C2")
+	resEntry := mustDecode(t, cliOptions{resistor: true, bands: "brown,black,red,gold"}, "")
+
+	path := filepath.Join(t.TempDir(), "history.md")
+	if err := ExportToMarkdown([]ComponentEntry{capEntry, resEntry}, path); err != nil {
+		t.Fatalf("ExportToMarkdown() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	want := []string{
+		"| Type | Value | Voltage / Temp Coeff | Note |",
+		"| --- | --- | --- | --- |",
+	}
+	if len(lines) != 4 {
+		t.Fatalf("exported %d lines, want 4:\n%s", len(lines), data)
+	}
+	for i, line := range want {
+		if lines[i] != line {
+			t.Errorf("line %d = %q, want %q", i+1, lines[i], line)
+		}
+	}
+
+	// Every row has the same number of unescaped pipes as the header
+	for i, line := range lines[2:] {
+		if got, want := strings.Count(strings.ReplaceAll(line, `\|`, ""), "|"), 5; got != want {
+			t.Errorf("row %d has %d cell separators, want %d: %q", i+1, got, want, line)
+		}
+	}
+	if !strings.HasSuffix(lines[2], `| C1 \| C2 |`) {
+		t.Errorf("capacitor row = %q, want the escaped note", lines[2])
+	}
+	if !strings.Contains(lines[3], ResultSummaryLine(resEntry)) {
+		t.Errorf("resistor row = %q, want the result summary", lines[3])
+	}
+
+	if err := ExportToMarkdown(nil, path); err == nil {
+		t.Error("ExportToMarkdown() with no history error = nil, want error")
+	}
+}
+
+// TestExportChoiceInput tests the append / overwrite prompt for an existing CSV
+func TestExportChoiceInput(t *testing.T) {
+	tests := []struct {
+		name        string
+		canAppend   bool
+		key         string
+		wantPending bool
+		wantScreen  screenType
+	}{
+		{"append", true, "a", false, screenResults},
+		{"append unavailable", false, "a", true, screenFilePicker},
+		{"overwrite", false, "o", false, screenResults},
+		{"choose another file", true, "esc", false, screenFilePicker},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := initialModel()
+			m.screen = screenFilePicker
+			m.exportPending = "components.csv"
+			m.exportCanAppend = tt.canAppend
+
+			updated, _ := m.handleExportChoiceInput(tt.key)
+			got := updated.(model)
+			if (got.exportPending != "") != tt.wantPending {
+				t.Errorf("exportPending = %q, want pending %v", got.exportPending, tt.wantPending)
+			}
+			if got.screen != tt.wantScreen {
+				t.Errorf("screen = %v, want %v", got.screen, tt.wantScreen)
+			}
+		})
+	}
+}
+
+// TestBuildCSVRecords tests the export records without writing a file
+func TestBuildCSVRecords(t *testing.T) {
+	entry := mustDecode(t, cliOptions{resistor: true, bands: "brown,black,red,gold"}, "")
+	history := []ComponentEntry{entry, {ComponentType: decoder.ComponentResistor}, entry}
+
+	tests := []struct {
+		name     string
+		opts     ExportOptions
+		wantRows int
+		wantLast string
+	}{
+		{"one row per decode", ExportOptions{}, 2, "Military"},
+		{"grouped", ExportOptions{Aggregate: true}, 1, "Qty"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			records := BuildCSVRecordsWithOptions(history, tt.opts)
+			if !slices.Equal(records[0], BuildCSVHeaderWithOptions(tt.opts)) {
+				t.Errorf("first record = %v, want the header", records[0])
+			}
+			if got := len(records) - 1; got != tt.wantRows {
+				t.Errorf("got %d rows, want %d", got, tt.wantRows)
+			}
+			if got := records[0][len(records[0])-1]; got != tt.wantLast {
+				t.Errorf("last column = %q, want %q", got, tt.wantLast)
+			}
+			for _, record := range records[1:] {
+				if len(record) != len(records[0]) {
+					t.Errorf("row has %d fields, want %d", len(record), len(records[0]))
+				}
+			}
+		})
+	}
+
+	if got := len(BuildCSVRecords(nil)); got != 1 {
+		t.Errorf("BuildCSVRecords(nil) has %d records, want only the header", got)
+	}
+}
+
+// TestExportPreview tests that a CSV export shows its rows first and writes
+// them only once confirmed
+func TestExportPreview(t *testing.T) {
+	entry := mustDecode(t, cliOptions{resistor: true, bands: "brown,black,red,gold"}, "")
+	path := filepath.Join(t.TempDir(), "components.csv")
+
+	m := initialModel()
+	m.history = []ComponentEntry{entry, entry}
+	m.screen = screenFilePicker
+	m.exportPending = path
+
+	previewing, _ := m.handleExportChoiceInput("o")
+	m = previewing.(model)
+	if m.exportPreview == nil || m.screen != screenFilePicker {
+		t.Fatalf("overwrite went to screen %v without a preview, want the preview first", m.screen)
+	}
+	if view := m.View(); !strings.Contains(view, "Write 2 rows to components.csv") || !strings.Contains(view, "Timestamp,Component Type") {
+		t.Errorf("preview = %q, want the row count and CSV header", view)
+	}
+	if _, err := os.Stat(path); err == nil {
+		t.Fatal("the file was written before confirming")
+	}
+
+	// ESC goes back to the file list without writing
+	if got := pressKeys(m, "esc"); got.exportPreview != nil || got.screen != screenFilePicker {
+		t.Errorf("ESC left preview %v on screen %v, want the file list", got.exportPreview != nil, got.screen)
+	}
+
+	confirmed, cmd := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	if got := confirmed.(model); got.exportPreview != nil || got.screen != screenResults {
+		t.Errorf("ENTER left preview %v on screen %v, want the export started", got.exportPreview != nil, got.screen)
+	}
+	for _, c := range cmd().(tea.BatchMsg) {
+		if msg, ok := c().(exportResultMsg); ok && msg.err != nil {
+			t.Fatalf("export error = %v", msg.err)
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading the export: %v", err)
+	}
+	var want strings.Builder
+	for _, record := range m.exportPreview {
+		want.WriteString(csvLine(record) + "\n")
+	}
+	if string(data) != want.String() {
+		t.Errorf("written file = %q, want the previewed rows %q", data, want.String())
+	}
+}
+
+// TestBuildCSVRecord tests the exact export fields of a capacitor and a resistor
+func TestBuildCSVRecord(t *testing.T) {
+	ts := time.Date(2024, 3, 1, 14, 5, 9, 0, time.UTC)
+	tests := []struct {
+		name  string
+		entry ComponentEntry
+		want  []string
+	}{
+		{
+			"capacitor",
+			mustDecode(t, cliOptions{capacitor: true, capType: "K", bands: "yellow,violet,orange,white"}, ""),
+			[]string{"2024-03-01 14:05:09", "Capacitor", "K", "4", "Yellow", "Violet", "Orange", "White", "", "",
+				"47.000", "nF", "10.0", "42.30 nF", "51.70 nF", "", "", "", "", "", ""},
+		},
+		{
+			"resistor",
+			mustDecode(t, cliOptions{resistor: true, bands: "brown,black,red,gold"}, "R1"),
+			[]string{"2024-03-01 14:05:09", "Resistor", "", "4", "Brown", "Black", "Red", "Gold", "", "",
+				"1.000", "kΩ", "5.00", "950.0 Ω", "1.050 kΩ", "", "", "R1", "", "", ""},
+		},
+		{
+			"military resistor",
+			militaryEntry(t),
+			[]string{"2024-03-01 14:05:09", "Resistor", "", "5", "Yellow", "Violet", "Red", "Gold", "Red", "",
+				"4.700", "kΩ", "5.00", "4.465 kΩ", "4.935 kΩ", "", "", "", "", "", "yes"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := BuildCSVRecord(tt.entry, ts)
+			if err != nil {
+				t.Fatalf("BuildCSVRecord() error = %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("BuildCSVRecord() = %q, want %q", got, tt.want)
+			}
+			if len(got) != len(BuildCSVHeader()) {
+				t.Errorf("record has %d fields, header has %d", len(got), len(BuildCSVHeader()))
+			}
+		})
+	}
+
+	if _, err := BuildCSVRecord(ComponentEntry{ComponentType: decoder.ComponentResistor}, ts); err == nil {
+		t.Error("BuildCSVRecord() of an entry without a result error = nil, want error")
+	}
+}
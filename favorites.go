@@ -0,0 +1,109 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// DefaultFavoritesPath returns the favorites file location, next to the
+// config file, e.g. ~/.config/tropical-fish/favorites.json on Linux
+func DefaultFavoritesPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "tropical-fish", "favorites.json"), nil
+}
+
+// LoadFavorites reads pinned entries saved by SaveFavorites
+// A missing file is an empty list. Entries that no longer decode are skipped
+// and reported in the error, which is returned with the entries that loaded.
+func LoadFavorites(path string) ([]ComponentEntry, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load favorites from %s: %w", path, err)
+	}
+
+	var doc jsonExport
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to load favorites from %s: %w", path, err)
+	}
+
+	var favorites []ComponentEntry
+	var errs []error
+	for i, component := range doc.Components {
+		entry, err := entryFromJSON(component)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("favorite %d: %w", i+1, err))
+			continue
+		}
+		favorites = append(favorites, entry)
+	}
+	if len(errs) > 0 {
+		return favorites, fmt.Errorf("skipped favorites in %s: %w", path, errors.Join(errs...))
+	}
+	return favorites, nil
+}
+
+// SaveFavorites writes pinned entries in the JSON export format, which keeps
+// military and extended-color readings, removing the file when there are none
+func SaveFavorites(favorites []ComponentEntry, path string) error {
+	if len(favorites) == 0 {
+		if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	return ExportToJSON(favorites, path)
+}
+
+// toggleFavorite pins entry, or unpins it if the same part is already
+// pinned, and reports whether it is now pinned
+func toggleFavorite(favorites []ComponentEntry, entry ComponentEntry) ([]ComponentEntry, bool) {
+	if i := FindDuplicate(favorites, entry); i >= 0 {
+		return append(favorites[:i:i], favorites[i+1:]...), false
+	}
+	return append(favorites, entry), true
+}
+
+// favoritesSavedMsg reports the outcome of saving favorites
+type favoritesSavedMsg struct {
+	err error
+}
+
+// saveFavoritesCmd saves a snapshot of the favorites off the UI goroutine
+func saveFavoritesCmd(favorites []ComponentEntry, path string) tea.Cmd {
+	snapshot := make([]ComponentEntry, len(favorites))
+	copy(snapshot, favorites)
+
+	return func() tea.Msg {
+		return favoritesSavedMsg{err: SaveFavorites(snapshot, path)}
+	}
+}
+
+// saveFavorites starts saving the favorites, or marks them to be saved again
+// if a save is still running, so saves never overlap and the last one written
+// is the newest. Does nothing when no favorites file is configured.
+func (m model) saveFavorites() (model, tea.Cmd) {
+	if m.favoritesPath == "" {
+		return m, nil
+	}
+	if m.favoritesSaving {
+		m.favoritesDirty = true
+		return m, nil
+	}
+	m.favoritesSaving = true
+	return m, saveFavoritesCmd(m.favorites, m.favoritesPath)
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"tropical-fish/decoder"
+)
+
+// TestFavoritesRoundTrip tests that pinned entries survive a save and load
+func TestFavoritesRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tropical-fish", "favorites.json")
+
+	favorites, err := LoadFavorites(path)
+	if err != nil || favorites != nil {
+		t.Fatalf("LoadFavorites() of missing file = %v, %v, want nil, nil", favorites, err)
+	}
+
+	resistor := mustDecode(t, cliOptions{resistor: true, bands: "brown,black,red,gold"}, "pull-up")
+	capacitor := mustDecode(t, cliOptions{capacitor: true, capType: "K", bands: "red,violet,orange,brown,orange"}, "")
+	pink := mustDecode(t, cliOptions{resistor: true, bands: "yellow,violet,pink,gold", palette: decoder.Palette{Extended: true}}, "")
+	saved := []ComponentEntry{resistor, capacitor, pink, militaryEntry(t)}
+	if err := SaveFavorites(saved, path); err != nil {
+		t.Fatalf("SaveFavorites() error = %v", err)
+	}
+
+	favorites, err = LoadFavorites(path)
+	if err != nil {
+		t.Fatalf("LoadFavorites() error = %v", err)
+	}
+	if len(favorites) != len(saved) {
+		t.Fatalf("LoadFavorites() = %d entries, want %d", len(favorites), len(saved))
+	}
+	for i := range saved {
+		if !EntryEqual(favorites[i], saved[i]) {
+			t.Errorf("favorite %d does not match after a save and load", i)
+		}
+	}
+	if favorites[0].ResistorResult.ResistanceOhms != 1000 || favorites[0].Note != "pull-up" {
+		t.Errorf("favorite 0 = %v Ω %q, want 1000 Ω \"pull-up\"", favorites[0].ResistorResult.ResistanceOhms, favorites[0].Note)
+	}
+	if favorites[1].CapacitorResult.CapacitancePF != 27000 {
+		t.Errorf("favorite 1 = %v pF, want 27000", favorites[1].CapacitorResult.CapacitancePF)
+	}
+
+	// Unpinning everything removes the file
+	if err := SaveFavorites(nil, path); err != nil {
+		t.Fatalf("SaveFavorites(nil) error = %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("favorites file still exists after saving none: %v", err)
+	}
+}
+
+// TestPinFavorite tests pinning from results and inserting a favorite into history
+func TestPinFavorite(t *testing.T) {
+	entry := mustDecode(t, cliOptions{resistor: true, bands: "yellow,violet,red,gold"}, "")
+
+	m := initialModel()
+	m.screen = screenResults
+	m.componentType = entry.ComponentType
+	m.resistorResult = entry.ResistorResult
+
+	updated, _ := m.handleResultsInput("p")
+	m = updated.(model)
+	if len(m.favorites) != 1 {
+		t.Fatalf("pinning left %d favorites, want 1", len(m.favorites))
+	}
+
+	m.screen = screenFavorites
+	m.resistorResult = nil
+	updated, _ = m.handleFavoritesInput("enter")
+	m = updated.(model)
+	if m.screen != screenResults || m.resistorResult == nil || m.resistorResult.ResistanceOhms != 4700 {
+		t.Errorf("inserting favorite showed screen %d with result %v, want 4700 Ω results", m.screen, m.resistorResult)
+	}
+	if len(m.history) != 1 {
+		t.Errorf("inserting favorite left %d history entries, want 1", len(m.history))
+	}
+
+	// Pinning the same part again unpins it
+	updated, _ = m.handleResultsInput("p")
+	if n := len(updated.(model).favorites); n != 0 {
+		t.Errorf("second pin left %d favorites, want 0", n)
+	}
+}
+
+// TestLoadFavoritesSkipsBadEntries tests that entries that no longer decode
+// are reported without losing the ones that do
+func TestLoadFavoritesSkipsBadEntries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "favorites.json")
+	data := `{"components": [
+  {"component_type": "resistor", "resistor": {"bands": ["Brown", "Black", "Red", "Gold"]}, "note": "pull-up"},
+  {"component_type": "resistor", "resistor": {"bands": ["Brown", "Mauve", "Red", "Gold"]}}
+]}`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	favorites, err := LoadFavorites(path)
+	if err == nil || !strings.Contains(err.Error(), "favorite 2") {
+		t.Errorf("LoadFavorites() error = %v, want favorite 2 reported", err)
+	}
+	if len(favorites) != 1 || favorites[0].Note != "pull-up" {
+		t.Errorf("LoadFavorites() = %+v, want the pull-up entry", favorites)
+	}
+}
+
+// TestSaveFavoritesOneAtATime tests that pinning while a save is running
+// waits for it and then saves the newest favorites
+func TestSaveFavoritesOneAtATime(t *testing.T) {
+	m := initialModel()
+	m.favoritesPath = filepath.Join(t.TempDir(), "favorites.json")
+	m.history = []ComponentEntry{
+		mustDecode(t, cliOptions{resistor: true, bands: "brown,black,red,gold"}, ""),
+		mustDecode(t, cliOptions{resistor: true, bands: "yellow,violet,red,gold"}, ""),
+	}
+	m.screen = screenHistory
+
+	updated, first := m.handleHistoryInput("p")
+	m = updated.(model)
+	if first == nil {
+		t.Fatal("pinning returned no save command")
+	}
+	m.historyCursor = 1
+	updated, second := m.handleHistoryInput("p")
+	m = updated.(model)
+	if second != nil {
+		t.Fatal("pinning during a save started a second save")
+	}
+
+	updated, third := m.Update(first())
+	m = updated.(model)
+	if third == nil {
+		t.Fatal("finishing a save with pending changes did not save again")
+	}
+	m.Update(third())
+
+	favorites, err := LoadFavorites(m.favoritesPath)
+	if err != nil || len(favorites) != 2 {
+		t.Errorf("saved favorites = %d entries, %v, want both pinned entries", len(favorites), err)
+	}
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"tropical-fish/decoder"
+)
+
+// appendHistory adds an entry to the session history, dropping the oldest
+// entries once historyLimit is exceeded (0 = unlimited)
+func appendHistory(m model, entry ComponentEntry) model {
+	m.history = append(m.history, entry)
+
+	if m.historyLimit <= 0 {
+		return m
+	}
+
+	for len(m.history) > m.historyLimit {
+		m.history = m.history[1:]
+		m.historyTrimmed++
+		// Keep pointing at the same entry, or at none if it was dropped
+		if m.currentEntryIndex >= 0 {
+			m.currentEntryIndex--
+		}
+		if m.exportedCount > 0 {
+			m.exportedCount--
+		}
+	}
+
+	return m
+}
+
+// historyFullUnexported reports whether the next append would drop an entry
+// that has not been exported yet
+func (m model) historyFullUnexported() bool {
+	return m.historyLimit > 0 &&
+		len(m.history) >= m.historyLimit &&
+		m.exportedCount == 0
+}
+
+// EntryEqual reports whether two entries are the same decoded part, comparing
+// component type, readings and computed value rather than result pointers.
+// Notes, packages and measurements are not compared.
+func EntryEqual(a, b ComponentEntry) bool {
+	if a.ComponentType != b.ComponentType {
+		return false
+	}
+	switch a.ComponentType {
+	case decoder.ComponentCapacitor:
+		return a.CapacitorResult != nil && b.CapacitorResult != nil &&
+			a.CapacitorResult.Reading.Equal(b.CapacitorResult.Reading) &&
+			decoder.ApproxEqual(a.CapacitorResult.CapacitancePF, b.CapacitorResult.CapacitancePF)
+	case decoder.ComponentResistor:
+		return a.ResistorResult != nil && b.ResistorResult != nil &&
+			a.ResistorResult.Reading.Equal(b.ResistorResult.Reading) &&
+			decoder.ApproxEqual(a.ResistorResult.ResistanceOhms, b.ResistorResult.ResistanceOhms)
+	}
+	return false
+}
+
+// HistoryTotals holds the combined series and parallel values of every
+// capacitor and every resistor in history
+type HistoryTotals struct {
+	Capacitors   int
+	SeriesPF     float64
+	ParallelPF   float64
+	Resistors    int
+	SeriesOhms   float64
+	ParallelOhms float64
+}
+
+// ComputeHistoryTotals combines the values of all capacitors and, separately,
+// all resistors in history
+func ComputeHistoryTotals(history []ComponentEntry) HistoryTotals {
+	var capacitances, resistances []float64
+	for _, entry := range history {
+		switch {
+		case entry.ComponentType == decoder.ComponentCapacitor && entry.CapacitorResult != nil:
+			capacitances = append(capacitances, entry.CapacitorResult.CapacitancePF)
+		case entry.ComponentType == decoder.ComponentResistor && entry.ResistorResult != nil:
+			resistances = append(resistances, entry.ResistorResult.ResistanceOhms)
+		}
+	}
+
+	return HistoryTotals{
+		Capacitors:   len(capacitances),
+		SeriesPF:     decoder.SeriesCapacitance(capacitances...),
+		ParallelPF:   decoder.ParallelCapacitance(capacitances...),
+		Resistors:    len(resistances),
+		SeriesOhms:   decoder.SeriesResistance(resistances...),
+		ParallelOhms: decoder.ParallelResistance(resistances...),
+	}
+}
+
+// AggregatedEntry is a group of identical parts from history
+type AggregatedEntry struct {
+	Entry    ComponentEntry // First entry of the group, with the group's notes merged
+	Quantity int            // Total number of parts in the group
+}
+
+// AggregateHistory groups history entries with the same component type,
+// capacitor type, value, tolerance, voltage rating, temperature coefficient
+// and military layout, in order of first appearance. Entries without a
+// result are skipped.
+func AggregateHistory(history []ComponentEntry) []AggregatedEntry {
+	var groups []AggregatedEntry
+	index := map[string]int{}
+
+	for _, entry := range history {
+		key, ok := aggregateKey(entry)
+		if !ok {
+			continue
+		}
+		quantity := max(entry.Quantity, 1)
+
+		if i, found := index[key]; found {
+			groups[i].Quantity += quantity
+			if !slices.Contains(strings.Split(groups[i].Entry.Note, "; "), entry.Note) {
+				groups[i].Entry.Note = mergeNotes(groups[i].Entry.Note, entry.Note)
+			}
+			continue
+		}
+		index[key] = len(groups)
+		groups = append(groups, AggregatedEntry{Entry: entry, Quantity: quantity})
+	}
+
+	return groups
+}
+
+// aggregateKey returns the value-based grouping key for an entry
+func aggregateKey(entry ComponentEntry) (string, bool) {
+	switch {
+	case entry.ComponentType == decoder.ComponentCapacitor && entry.CapacitorResult != nil:
+		r := entry.CapacitorResult
+		return fmt.Sprintf("C|%s|%g|%s|%g|%g|%g|%g|%t|%d", r.Reading.CapType, r.CapacitancePF,
+			r.ToleranceType, r.ToleranceHigh, r.ToleranceLow, r.ToleranceAbsolutePF, r.VoltageRating,
+			r.TempCoeffValid, r.TempCoefficient), true
+	case entry.ComponentType == decoder.ComponentResistor && entry.ResistorResult != nil:
+		r := entry.ResistorResult
+		return fmt.Sprintf("R|%g|%g|%t|%g|%t|%d", r.ResistanceOhms, r.TolerancePercent,
+			r.Reading.Military, r.FailureRatePercent, r.TempCoeffValid, r.TempCoefficient), true
+	}
+	return "", false
+}
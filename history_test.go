@@ -0,0 +1,452 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"tropical-fish/decoder"
+)
+
+// TestAppendHistory tests history capping and trim bookkeeping
+func TestAppendHistory(t *testing.T) {
+	tests := []struct {
+		name            string
+		limit           int
+		appends         int
+		expectedLen     int
+		expectedTrimmed int
+		expectedFirst   string
+	}{
+		{"Unlimited keeps everything", 0, 5, 5, 0, "1"},
+		{"Under limit", 3, 2, 2, 0, "1"},
+		{"At limit", 3, 3, 3, 0, "1"},
+		{"Over limit drops oldest", 3, 5, 3, 2, "3"},
+		{"Limit of one", 1, 4, 1, 3, "4"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := initialModel()
+			m.historyLimit = tt.limit
+			for i := 1; i <= tt.appends; i++ {
+				m = appendHistory(m, ComponentEntry{Note: string(rune('0' + i))})
+			}
+
+			if len(m.history) != tt.expectedLen {
+				t.Errorf("len(history) = %d, want %d", len(m.history), tt.expectedLen)
+			}
+			if m.historyTrimmed != tt.expectedTrimmed {
+				t.Errorf("historyTrimmed = %d, want %d", m.historyTrimmed, tt.expectedTrimmed)
+			}
+			if m.history[0].Note != tt.expectedFirst {
+				t.Errorf("oldest entry = %q, want %q", m.history[0].Note, tt.expectedFirst)
+			}
+		})
+	}
+}
+
+// TestHistoryFullUnexported tests the warning before unexported entries are trimmed
+func TestHistoryFullUnexported(t *testing.T) {
+	m := initialModel()
+	m.historyLimit = 2
+	m = appendHistory(m, ComponentEntry{Note: "a"})
+	if m.historyFullUnexported() {
+		t.Error("historyFullUnexported() = true below limit, want false")
+	}
+
+	m = appendHistory(m, ComponentEntry{Note: "b"})
+	if !m.historyFullUnexported() {
+		t.Error("historyFullUnexported() = false at limit with nothing exported, want true")
+	}
+
+	// Export everything, then the oldest can be dropped without warning
+	m.exportedCount = len(m.history)
+	if m.historyFullUnexported() {
+		t.Error("historyFullUnexported() = true after export, want false")
+	}
+
+	// Each trim consumes one exported entry
+	m = appendHistory(m, ComponentEntry{Note: "c"})
+	m = appendHistory(m, ComponentEntry{Note: "d"})
+	if m.exportedCount != 0 {
+		t.Errorf("exportedCount = %d, want 0", m.exportedCount)
+	}
+	if !m.historyFullUnexported() {
+		t.Error("historyFullUnexported() = false once exported entries are trimmed, want true")
+	}
+
+	// An export of [c d] finishing after one new entry trimmed c has only
+	// d left exported, so the next append still drops an exported entry
+	m.exporting = true
+	msg := exportResultMsg{path: "out.csv", count: 2, trimmed: m.historyTrimmed}
+	m = appendHistory(m, ComponentEntry{Note: "e"})
+	updated, _ := m.Update(msg)
+	m = updated.(model)
+	if m.exportedCount != 1 {
+		t.Errorf("exportedCount after an export with a trim during it = %d, want 1", m.exportedCount)
+	}
+	if m.historyFullUnexported() {
+		t.Error("historyFullUnexported() = true with d exported, want false")
+	}
+
+	// Once more than the export were trimmed, nothing exported is left
+	msg = exportResultMsg{path: "out.csv", count: 2, trimmed: m.historyTrimmed}
+	m = appendHistory(m, ComponentEntry{Note: "f"})
+	m = appendHistory(m, ComponentEntry{Note: "g"})
+	m = appendHistory(m, ComponentEntry{Note: "h"})
+	updated, _ = m.Update(msg)
+	m = updated.(model)
+	if m.exportedCount != 0 || !m.historyFullUnexported() {
+		t.Errorf("exportedCount = %d after every exported entry was trimmed, want 0 and a warning", m.exportedCount)
+	}
+}
+
+// TestHistoryScreen tests that the history screen opens on the newest entries,
+// scrolls within the list and handles an empty history
+func TestHistoryScreen(t *testing.T) {
+	m := initialModel()
+	m.screen = screenResults
+	m.height = 10 // Room for 3 entries
+
+	updated, _ := m.handleResultsInput("h")
+	m = updated.(model)
+	if m.screen != screenHistory {
+		t.Fatalf("screen = %v, want screenHistory", m.screen)
+	}
+	if view := m.renderHistory(); !strings.Contains(view, "Nothing decoded yet") {
+		t.Errorf("empty history view = %q, want the empty message", view)
+	}
+
+	entry := mustDecode(t, cliOptions{resistor: true, bands: "brown,black,red,gold"}, "")
+	for i := 1; i <= 5; i++ {
+		entry.Note = "part " + string(rune('0'+i))
+		m = appendHistory(m, entry)
+	}
+	m.screen = screenResults
+	updated, _ = m.handleResultsInput("h")
+	m = updated.(model)
+	if m.scrollOffset != 2 {
+		t.Errorf("scrollOffset on open = %d, want 2 (newest entries visible)", m.scrollOffset)
+	}
+
+	view := m.renderHistory()
+	for _, want := range []string{"part 3", "part 5", "Entries 3-5 of 5"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("history view missing %q:\n%s", want, view)
+		}
+	}
+	if strings.Contains(view, "part 2") {
+		t.Errorf("history view shows an entry scrolled out of view:\n%s", view)
+	}
+
+	// Scrolling stops at either end of the list
+	for _, key := range []string{"down", "pgdown"} {
+		updated, _ = m.handleHistoryInput(key)
+		if got := updated.(model).scrollOffset; got != 2 {
+			t.Errorf("scrollOffset after %s at the end = %d, want 2", key, got)
+		}
+	}
+	updated, _ = m.handleHistoryInput("pgup")
+	if got := updated.(model).scrollOffset; got != 0 {
+		t.Errorf("scrollOffset after pgup = %d, want 0", got)
+	}
+
+	updated, _ = m.handleHistoryInput("esc")
+	if got := updated.(model); got.screen != screenResults || got.scrollOffset != 0 {
+		t.Errorf("after esc screen = %v, scrollOffset = %d, want screenResults and 0", got.screen, got.scrollOffset)
+	}
+}
+
+// pressKeys sends each key to the model as if typed, e.g. "n", "enter", "esc"
+func pressKeys(m model, keys ...string) model {
+	for _, key := range keys {
+		msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+		switch key {
+		case "enter":
+			msg = tea.KeyMsg{Type: tea.KeyEnter}
+		case "esc":
+			msg = tea.KeyMsg{Type: tea.KeyEsc}
+		case "backspace":
+			msg = tea.KeyMsg{Type: tea.KeyBackspace}
+		}
+		updated, _ := m.handleKeyPress(msg)
+		m = updated.(model)
+	}
+	return m
+}
+
+// TestCurrentEntrySavedOnce tests that the note, export and BOM flows update
+// the current decode's history entry instead of adding copies of it
+func TestCurrentEntrySavedOnce(t *testing.T) {
+	tests := []struct {
+		name        string
+		keys        []string
+		wantEntries int
+		wantNote    string
+	}{
+		{"note then export", []string{"n", "a", "enter", "x", "q"}, 1, "a"},
+		{"export then note", []string{"x", "q", "n", "a", "enter"}, 1, "a"},
+		{"note twice", []string{"n", "a", "enter", "n", "b", "enter", "x", "q"}, 1, "ab"},
+		{"note cancelled then export", []string{"n", "esc", "x", "q"}, 1, ""},
+		{"BOM row then note", []string{"b", "enter", "n", "a", "enter"}, 1, "a"},
+		{"reversed result is a new entry", []string{"n", "a", "enter", "r", "x", "q"}, 2, "a"},
+		{"decode again is a new entry", []string{"x", "q", "a", "brown", "enter", "black", "enter", "black", "enter", "brown", "enter", "brown", "enter", "enter", "x", "q"}, 2, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := initialModel()
+			m.componentType = decoder.ComponentResistor
+			// Reads 1 kΩ ±1% forwards and 110 Ω ±1% reversed
+			m.resistorReading = decoder.ResistorReading{BandCount: 5, Band1: decoder.ColorBrown, Band2: decoder.ColorBlack, Band3: decoder.ColorBlack, Band4: decoder.ColorBrown, Band5: decoder.ColorBrown}
+			m.screen = screenReview
+			m = pressKeys(m, "enter")
+			if m.screen != screenResults {
+				t.Fatalf("screen after calculating = %v, want screenResults", m.screen)
+			}
+
+			var keys []string
+			for _, key := range tt.keys {
+				// Multi-letter words are typed a character at a time
+				if len(key) > 1 && key != "enter" && key != "esc" {
+					keys = append(keys, strings.Split(key, "")...)
+					continue
+				}
+				keys = append(keys, key)
+			}
+			m = pressKeys(m, keys...)
+
+			if len(m.history) != tt.wantEntries {
+				t.Fatalf("len(history) = %d, want %d: %+v", len(m.history), tt.wantEntries, m.history)
+			}
+			if got := m.history[0].Note; got != tt.wantNote {
+				t.Errorf("first entry note = %q, want %q", got, tt.wantNote)
+			}
+		})
+	}
+}
+
+// TestComputeHistoryTotals tests series and parallel totals per component type
+func TestComputeHistoryTotals(t *testing.T) {
+	history := []ComponentEntry{
+		{ComponentType: decoder.ComponentCapacitor, CapacitorResult: &decoder.CalculationResult{CapacitancePF: 10000}},
+		{ComponentType: decoder.ComponentCapacitor, CapacitorResult: &decoder.CalculationResult{CapacitancePF: 10000}},
+		{ComponentType: decoder.ComponentResistor, ResistorResult: &decoder.ResistorResult{ResistanceOhms: 100}},
+		{ComponentType: decoder.ComponentResistor, ResistorResult: &decoder.ResistorResult{ResistanceOhms: 300}},
+		{ComponentType: decoder.ComponentResistor},
+	}
+
+	totals := ComputeHistoryTotals(history)
+	expected := HistoryTotals{
+		Capacitors:   2,
+		SeriesPF:     5000,
+		ParallelPF:   20000,
+		Resistors:    2,
+		SeriesOhms:   400,
+		ParallelOhms: 75,
+	}
+	if totals != expected {
+		t.Errorf("ComputeHistoryTotals() = %+v, want %+v", totals, expected)
+	}
+
+	if empty := ComputeHistoryTotals(nil); empty != (HistoryTotals{}) {
+		t.Errorf("ComputeHistoryTotals(nil) = %+v, want zero totals", empty)
+	}
+}
+
+// TestAggregateHistory tests grouping identical parts by value, tolerance and type
+func TestAggregateHistory(t *testing.T) {
+	first := mustDecode(t, cliOptions{resistor: true, bands: "brown,black,red,gold"}, "R1")
+	sameValue := mustDecode(t, cliOptions{resistor: true, bands: "brown,black,black,brown,gold"}, "R2")
+	sameValue.Quantity = 2
+	tighter := mustDecode(t, cliOptions{resistor: true, bands: "brown,black,red,brown"}, "")
+	capacitor := mustDecode(t, cliOptions{capacitor: true, capType: "K", bands: "red,violet,orange,brown,orange"}, "")
+
+	// Same value and tolerance, but a military part or a different
+	// temperature coefficient is a different part
+	plain := mustDecode(t, cliOptions{resistor: true, bands: "yellow,violet,red,gold"}, "plain")
+	military := militaryEntry(t)
+	military.Note = "military"
+	tc100 := mustDecode(t, cliOptions{resistor: true, bands: "brown,black,black,brown,gold,brown"}, "100 ppm")
+	tc50 := mustDecode(t, cliOptions{resistor: true, bands: "brown,black,black,brown,gold,red"}, "50 ppm")
+
+	groups := AggregateHistory([]ComponentEntry{first, tighter, sameValue, capacitor, {}, first, plain, military, tc100, tc50, tc100})
+
+	expected := []struct {
+		note     string
+		quantity int
+	}{
+		{"R1; R2", 4},
+		{"", 1},
+		{"", 1},
+		{"plain", 1},
+		{"military", 1},
+		{"100 ppm", 2},
+		{"50 ppm", 1},
+	}
+	if len(groups) != len(expected) {
+		t.Fatalf("AggregateHistory() returned %d groups, want %d", len(groups), len(expected))
+	}
+	for i, want := range expected {
+		if groups[i].Entry.Note != want.note || groups[i].Quantity != want.quantity {
+			t.Errorf("group %d = note %q qty %d, want note %q qty %d",
+				i, groups[i].Entry.Note, groups[i].Quantity, want.note, want.quantity)
+		}
+	}
+}
+
+// TestEntryEqual tests comparing entries by bands and value rather than pointers
+func TestEntryEqual(t *testing.T) {
+	resistor := mustDecode(t, cliOptions{resistor: true, bands: "brown,black,red,gold"}, "")
+	capacitor := mustDecode(t, cliOptions{capacitor: true, capType: "K", bands: "red,violet,orange"}, "")
+
+	// A fresh decode has new result pointers
+	sameResistor := mustDecode(t, cliOptions{resistor: true, bands: "brown,black,red,gold"}, "a note")
+
+	// The same value computed with floating point noise
+	noisy := *capacitor.CapacitorResult
+	noisy.CapacitancePF = 27000 * (1 + 1e-12)
+
+	// Bands beyond the band count are ignored
+	stray := *capacitor.CapacitorResult
+	stray.Reading.Band4 = decoder.ColorBrown
+
+	otherValue := *capacitor.CapacitorResult
+	otherValue.CapacitancePF = 27001
+
+	tests := []struct {
+		name     string
+		a, b     ComponentEntry
+		expected bool
+	}{
+		{"Same decode, new pointers", resistor, sameResistor, true},
+		{"Near-equal value", capacitor, ComponentEntry{ComponentType: decoder.ComponentCapacitor, CapacitorResult: &noisy}, true},
+		{"Unused band differs", capacitor, ComponentEntry{ComponentType: decoder.ComponentCapacitor, CapacitorResult: &stray}, true},
+		{"Different value", capacitor, ComponentEntry{ComponentType: decoder.ComponentCapacitor, CapacitorResult: &otherValue}, false},
+		{"Different bands", resistor, mustDecode(t, cliOptions{resistor: true, bands: "brown,black,orange,gold"}, ""), false},
+		{"Different component", resistor, capacitor, false},
+		{"Missing result", resistor, ComponentEntry{ComponentType: decoder.ComponentResistor}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := EntryEqual(tt.a, tt.b); got != tt.expected {
+				t.Errorf("EntryEqual() = %v, want %v", got, tt.expected)
+			}
+			if got := EntryEqual(tt.b, tt.a); got != tt.expected {
+				t.Errorf("EntryEqual() reversed = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestCombineScreen tests picking resistors from history and the combined
+// values shown
+func TestCombineScreen(t *testing.T) {
+	m := initialModel()
+	m = appendHistory(m, mustDecode(t, cliOptions{resistor: true, bands: "brown,black,red,gold"}, ""))
+	m = appendHistory(m, mustDecode(t, cliOptions{capType: "K", bands: "red,violet,orange"}, ""))
+	m = appendHistory(m, mustDecode(t, cliOptions{resistor: true, bands: "brown,black,red,gold"}, ""))
+	m.componentType = decoder.ComponentResistor
+	m.screen = screenResults
+
+	m = pressKeys(m, "t")
+	if m.screen != screenCombine {
+		t.Fatalf("screen = %v, want screenCombine", m.screen)
+	}
+	if got := m.combineEntries(); len(got) != 2 || got[0] != 0 || got[1] != 2 {
+		t.Fatalf("combineEntries() = %v, want the two resistors [0 2]", got)
+	}
+
+	m = pressKeys(m, "enter", "j", "enter")
+	view := m.renderCombine()
+	for _, want := range []string{"2.000 kΩ", "500.0 Ω", "nearest E24: 510.0 Ω"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("combine view missing %q:\n%s", want, view)
+		}
+	}
+
+	// Dropping one leaves too few to combine
+	m = pressKeys(m, "enter")
+	if view := m.renderCombine(); !strings.Contains(view, "1 picked") {
+		t.Errorf("combine view = %q, want the pick prompt", view)
+	}
+
+	m = pressKeys(m, "esc")
+	if m.screen != screenResults {
+		t.Errorf("screen = %v, want screenResults", m.screen)
+	}
+
+	// On a capacitor result the capacitors are listed: 10 pF with 10 µF
+	m = initialModel()
+	m = appendHistory(m, mustDecode(t, cliOptions{capType: "K", bands: "brown,black,black"}, ""))
+	m = appendHistory(m, mustDecode(t, cliOptions{resistor: true, bands: "brown,black,red,gold"}, ""))
+	m = appendHistory(m, mustDecode(t, cliOptions{capType: "K", bands: "brown,black,blue"}, ""))
+	m.screen = screenResults
+	m = pressKeys(m, "t", "enter", "j", "enter")
+	view = m.renderCombine()
+	for _, want := range []string{"Series:", "10.000 pF", "Parallel: 10.00 µF", "nearest E12"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("capacitor combine view missing %q:\n%s", want, view)
+		}
+	}
+}
+
+// TestRedecodeHistoryEntry tests that editing the bands of a history entry
+// replaces it in place and keeps its note
+func TestRedecodeHistoryEntry(t *testing.T) {
+	m := initialModel()
+	m.screen = screenResults
+	first := mustDecode(t, cliOptions{resistor: true, bands: "brown,black,red,gold"}, "R1 pull-up")
+	second := mustDecode(t, cliOptions{resistor: true, bands: "yellow,violet,brown,gold"}, "R2")
+	m = appendHistory(m, first)
+	m = appendHistory(m, second)
+
+	updated, _ := m.handleResultsInput("h")
+	updated, _ = updated.(model).handleHistoryInput("up")
+	m = pressKeys(updated.(model), "e")
+	if m.screen != screenBandInput || m.input != "Brown" {
+		t.Fatalf("after e screen = %v, input = %q, want screenBandInput prefilled with Brown", m.screen, m.input)
+	}
+
+	// Keep bands 1, 2 and 4; retype band 3 as Orange
+	m = pressKeys(m, "enter", "enter", "o", "r", "a", "n", "g", "e", "enter", "enter")
+	for m.screen != screenResults {
+		before := m.screen
+		m = pressKeys(m, "enter")
+		if m.screen == before {
+			t.Fatalf("stuck on screen %v: %v", m.screen, m.err)
+		}
+	}
+
+	if len(m.history) != 2 {
+		t.Fatalf("len(history) = %d, want 2 (entry replaced, not added)", len(m.history))
+	}
+	got := m.history[0]
+	if got.ResistorResult == nil || got.ResistorResult.ResistanceOhms != 10000 {
+		t.Errorf("entry 1 = %+v, want 10 kΩ", got.ResistorResult)
+	}
+	if got.Note != "R1 pull-up" {
+		t.Errorf("entry 1 note = %q, want it kept", got.Note)
+	}
+	if m.history[1].Note != "R2" {
+		t.Errorf("entry 2 note = %q, want it untouched", m.history[1].Note)
+	}
+	if m.redecodeIndex != -1 {
+		t.Errorf("redecodeIndex = %d after finishing, want -1", m.redecodeIndex)
+	}
+
+	// An SMD entry has no bands to edit
+	smd, err := decoder.DecodeSMDResistor("472")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m = appendHistory(m, ComponentEntry{ComponentType: decoder.ComponentResistor, ResistorResult: smd})
+	updated, _ = m.handleResultsInput("h")
+	m = pressKeys(updated.(model), "e")
+	if m.screen != screenHistory || m.err == nil {
+		t.Errorf("e on an SMD entry: screen = %v, err = %v, want an error on the history screen", m.screen, m.err)
+	}
+}
@@ -0,0 +1,263 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"tropical-fish/decoder"
+)
+
+// ImportOptions controls how imported entries are merged into history
+type ImportOptions struct {
+	SkipDuplicates bool // Skip entries with the same bands, value and note
+	MergeNotes     bool // Merge the note into an entry with the same bands and value
+}
+
+// ImportSummary counts what happened to each imported entry
+type ImportSummary struct {
+	Imported int
+	Skipped  int // Exact duplicates
+	Merged   int // Same bands and value, note merged
+}
+
+// String returns a one-line summary such as "imported 3, skipped 2 duplicates"
+func (s ImportSummary) String() string {
+	summary := fmt.Sprintf("imported %d, skipped %d duplicate%s", s.Imported, s.Skipped,
+		map[bool]string{true: "", false: "s"}[s.Skipped == 1])
+	if s.Merged > 0 {
+		summary += fmt.Sprintf(", merged %d note%s", s.Merged,
+			map[bool]string{true: "", false: "s"}[s.Merged == 1])
+	}
+	return summary
+}
+
+// exportPalette reads the English band names written by the exports,
+// including Pink, whatever colors the session has in use
+var exportPalette = decoder.Palette{Extended: true}
+
+// ImportFromCSV reads a CSV written by ExportToCSV back into history entries,
+// recalculating each result from its bands
+func ImportFromCSV(filename string) ([]ComponentEntry, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("no component data to import")
+	}
+
+	columns := map[string]int{}
+	for i, name := range records[0] {
+		columns[name] = i
+	}
+	for _, name := range []string{"Component Type", "Band Count", "Band 1"} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("missing %q column (not an export file?)", name)
+		}
+	}
+
+	var entries []ComponentEntry
+	for i, record := range records[1:] {
+		entry, err := entryFromRecord(record, columns)
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", i+2, err)
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// entryFromRecord rebuilds a history entry from one export row
+func entryFromRecord(record []string, columns map[string]int) (ComponentEntry, error) {
+	field := func(name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	bandCount, err := strconv.Atoi(field("Band Count"))
+	if err != nil {
+		return ComponentEntry{}, fmt.Errorf("invalid band count '%s'", field("Band Count"))
+	}
+	if bandCount < 1 || bandCount > 6 {
+		return ComponentEntry{}, fmt.Errorf("invalid band count: %d", bandCount)
+	}
+
+	colors := make([]decoder.Color, 0, bandCount)
+	for band := 1; band <= bandCount; band++ {
+		name := field(fmt.Sprintf("Band %d", band))
+		color, ok := exportPalette.ParseColor(name)
+		if !ok {
+			return ComponentEntry{}, fmt.Errorf("band %d: invalid color '%s'", band, name)
+		}
+		colors = append(colors, color)
+	}
+
+	var entry ComponentEntry
+	switch field("Component Type") {
+	case "Capacitor":
+		capType, ok := decoder.ParseCapacitorType(field("Cap Type"))
+		if !ok {
+			return ComponentEntry{}, fmt.Errorf("invalid capacitor type '%s'", field("Cap Type"))
+		}
+		reading, err := decoder.CapacitorReadingFromColors(capType, colors)
+		if err != nil {
+			return ComponentEntry{}, err
+		}
+		result, err := decoder.Calculate(reading)
+		if err != nil {
+			return ComponentEntry{}, err
+		}
+		entry = ComponentEntry{ComponentType: decoder.ComponentCapacitor, CapacitorResult: result}
+	case "Resistor":
+		reading, err := decoder.ResistorReadingFromColors(colors)
+		if err != nil {
+			return ComponentEntry{}, err
+		}
+		reading.Military = strings.EqualFold(field("Military"), "yes")
+		result, err := decoder.CalculateResistor(reading)
+		if err != nil {
+			return ComponentEntry{}, err
+		}
+		entry = ComponentEntry{ComponentType: decoder.ComponentResistor, ResistorResult: result}
+	default:
+		return ComponentEntry{}, fmt.Errorf("unknown component type '%s'", field("Component Type"))
+	}
+
+	entry.Note = field("Note")
+	// Aggregated exports carry a part count
+	if qty, err := strconv.Atoi(field("Qty")); err == nil && qty > 1 {
+		entry.Quantity = qty
+	}
+	if measured := field("Measured Value"); measured != "" {
+		value, err := ParseMeasuredValue(entry, measured)
+		if err != nil {
+			return ComponentEntry{}, fmt.Errorf("invalid measured value '%s': %w", measured, err)
+		}
+		entry.MeasuredValue = value
+		entry.Measured = true
+	}
+
+	return entry, nil
+}
+
+// entryFromJSON rebuilds a history entry from its JSON export form,
+// recalculating the result from its bands
+func entryFromJSON(component jsonEntry) (ComponentEntry, error) {
+	var bands []string
+	switch {
+	case component.Capacitor != nil:
+		bands = component.Capacitor.Bands
+	case component.Resistor != nil:
+		bands = component.Resistor.Bands
+	default:
+		return ComponentEntry{}, fmt.Errorf("unknown component type '%s'", component.ComponentType)
+	}
+	colors := make([]decoder.Color, 0, len(bands))
+	for i, name := range bands {
+		color, ok := exportPalette.ParseColor(name)
+		if !ok {
+			return ComponentEntry{}, fmt.Errorf("band %d: invalid color '%s'", i+1, name)
+		}
+		colors = append(colors, color)
+	}
+
+	var entry ComponentEntry
+	if component.Capacitor != nil {
+		capType, ok := decoder.ParseCapacitorType(component.Capacitor.CapType)
+		if !ok {
+			return ComponentEntry{}, fmt.Errorf("invalid capacitor type '%s'", component.Capacitor.CapType)
+		}
+		reading, err := decoder.CapacitorReadingFromColors(capType, colors)
+		if err != nil {
+			return ComponentEntry{}, err
+		}
+		result, err := decoder.Calculate(reading)
+		if err != nil {
+			return ComponentEntry{}, err
+		}
+		entry = ComponentEntry{ComponentType: decoder.ComponentCapacitor, CapacitorResult: result}
+	} else {
+		reading, err := decoder.ResistorReadingFromColors(colors)
+		if err != nil {
+			return ComponentEntry{}, err
+		}
+		reading.Military = component.Resistor.Military
+		result, err := decoder.CalculateResistor(reading)
+		if err != nil {
+			return ComponentEntry{}, err
+		}
+		entry = ComponentEntry{ComponentType: decoder.ComponentResistor, ResistorResult: result}
+	}
+
+	entry.Note = component.Note
+	entry.Package = component.Package
+	if component.Quantity > 1 {
+		entry.Quantity = component.Quantity
+	}
+	if component.MeasuredValue != nil {
+		entry.MeasuredValue = *component.MeasuredValue
+		entry.Measured = true
+	}
+	return entry, nil
+}
+
+// FindDuplicate returns the index of the first history entry with the same
+// component type, bands and value as entry (notes are not compared), or -1
+func FindDuplicate(history []ComponentEntry, entry ComponentEntry) int {
+	for i, existing := range history {
+		if EntryEqual(existing, entry) {
+			return i
+		}
+	}
+	return -1
+}
+
+// mergeNotes joins two notes, skipping empty ones
+func mergeNotes(existing, imported string) string {
+	if existing == "" {
+		return imported
+	}
+	if imported == "" {
+		return existing
+	}
+	return existing + "; " + imported
+}
+
+// importHistory adds imported entries to the model's history, skipping exact
+// duplicates and merging notes as configured
+func importHistory(m model, imported []ComponentEntry, opts ImportOptions) (model, ImportSummary) {
+	var summary ImportSummary
+
+	for _, entry := range imported {
+		if i := FindDuplicate(m.history, entry); i >= 0 {
+			switch {
+			case opts.SkipDuplicates && m.history[i].Note == entry.Note:
+				summary.Skipped++
+				continue
+			case opts.MergeNotes && m.history[i].Note != entry.Note:
+				m.history[i].Note = mergeNotes(m.history[i].Note, entry.Note)
+				summary.Merged++
+				continue
+			}
+		}
+		m = appendHistory(m, entry)
+		summary.Imported++
+	}
+
+	return m, summary
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+
+	"tropical-fish/decoder"
+)
+
+// TestImportFromCSVRoundTrip tests that exported entries import back with the same values
+func TestImportFromCSVRoundTrip(t *testing.T) {
+	history := []ComponentEntry{
+		mustDecode(t, cliOptions{resistor: true, bands: "yellow,violet,black,brown,brown"}, "R12"),
+		mustDecode(t, cliOptions{capacitor: true, capType: "K", bands: "red,violet,orange,brown,orange"}, ""),
+		// Pink reads back without the extended color set in use
+		mustDecode(t, cliOptions{resistor: true, bands: "yellow,violet,pink,gold", palette: decoder.Palette{Extended: true}}, ""),
+		militaryEntry(t),
+	}
+
+	path := filepath.Join(t.TempDir(), "history.csv")
+	if err := ExportToCSVWithOptions(history, path, ExportOptions{FrequencyHz: 1000}); err != nil {
+		t.Fatalf("ExportToCSVWithOptions() error = %v", err)
+	}
+
+	imported, err := ImportFromCSV(path)
+	if err != nil {
+		t.Fatalf("ImportFromCSV() error = %v", err)
+	}
+	if len(imported) != len(history) {
+		t.Fatalf("imported %d entries, want %d", len(imported), len(history))
+	}
+	for i := range history {
+		if FindDuplicate(imported[i:i+1], history[i]) != 0 {
+			t.Errorf("entry %d does not match after round trip", i)
+		}
+		if imported[i].Note != history[i].Note {
+			t.Errorf("entry %d note = %q, want %q", i, imported[i].Note, history[i].Note)
+		}
+	}
+}
+
+// TestImportHistoryDedup tests skipping duplicates and merging notes on import
+func TestImportHistoryDedup(t *testing.T) {
+	resistor := mustDecode(t, cliOptions{resistor: true, bands: "brown,black,red,gold"}, "R1")
+	other := mustDecode(t, cliOptions{resistor: true, bands: "brown,black,orange,gold"}, "")
+	renoted := resistor
+	renoted.Note = "pull-up"
+
+	tests := []struct {
+		name     string
+		opts     ImportOptions
+		expected ImportSummary
+		notes    []string
+	}{
+		{"No dedup", ImportOptions{}, ImportSummary{Imported: 3}, []string{"R1", "R1", "", "pull-up"}},
+		{"Skip duplicates", ImportOptions{SkipDuplicates: true}, ImportSummary{Imported: 2, Skipped: 1}, []string{"R1", "", "pull-up"}},
+		{"Skip and merge", ImportOptions{SkipDuplicates: true, MergeNotes: true}, ImportSummary{Imported: 1, Skipped: 1, Merged: 1}, []string{"R1; pull-up", ""}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := initialModel()
+			m = appendHistory(m, resistor)
+
+			m, summary := importHistory(m, []ComponentEntry{resistor, other, renoted}, tt.opts)
+			if summary != tt.expected {
+				t.Errorf("importHistory() summary = %+v, want %+v", summary, tt.expected)
+			}
+			if len(m.history) != len(tt.notes) {
+				t.Fatalf("len(history) = %d, want %d", len(m.history), len(tt.notes))
+			}
+			for i, note := range tt.notes {
+				if m.history[i].Note != note {
+					t.Errorf("history[%d].Note = %q, want %q", i, m.history[i].Note, note)
+				}
+			}
+		})
+	}
+}
+
+func mustDecode(t *testing.T, opts cliOptions, note string) ComponentEntry {
+	t.Helper()
+	entry, err := decodeFromFlags(opts)
+	if err != nil {
+		t.Fatalf("decodeFromFlags(%+v) error = %v", opts, err)
+	}
+	entry.Note = note
+	return entry
+}
+
+// militaryEntry returns a 4.7 kΩ 5-band military resistor with a 0.1%/1000 h
+// failure rate, which the decode flags cannot describe
+func militaryEntry(t *testing.T) ComponentEntry {
+	t.Helper()
+	result, err := decoder.CalculateResistor(decoder.ResistorReading{
+		Band1: decoder.ColorYellow, Band2: decoder.ColorViolet, Band3: decoder.ColorRed,
+		Band4: decoder.ColorGold, Band5: decoder.ColorRed, BandCount: 5, Military: true,
+	})
+	if err != nil {
+		t.Fatalf("CalculateResistor() error = %v", err)
+	}
+	return ComponentEntry{ComponentType: decoder.ComponentResistor, ResistorResult: result}
+}
@@ -0,0 +1,239 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Action is a named keyboard command that can be rebound in the config file
+type Action string
+
+const (
+	ActionQuit           Action = "quit"
+	ActionContinue       Action = "continue"        // Start / calculate on welcome and review
+	ActionSubmit         Action = "submit"          // Accept typed input
+	ActionCancel         Action = "cancel"          // Leave a typed input without saving
+	ActionHelp           Action = "help"            // Show the keyboard shortcut overlay
+	ActionReference      Action = "reference"       // Open the color code reference chart
+	ActionLookup         Action = "lookup"          // Open the capacitor value lookup
+	ActionAbout          Action = "about"           // Show the version and build info
+	ActionResistorBands  Action = "resistor_bands"  // Find resistor bands from a value
+	ActionCapacitorBands Action = "capacitor_bands" // Find capacitor bands from a value
+	ActionFavorites      Action = "favorites"       // Open the pinned favorites list
+	ActionScrollUp       Action = "scroll_up"
+	ActionScrollDown     Action = "scroll_down"
+	ActionPageUp         Action = "page_up"
+	ActionPageDown       Action = "page_down"
+	ActionCapacitor      Action = "capacitor"
+	ActionResistor       Action = "resistor"
+	ActionSMD            Action = "smd"        // Decode an SMD resistor code
+	ActionMilitary       Action = "military"   // 5-band military resistor at the band count
+	ActionAutoBands      Action = "auto_bands" // Infer the resistor band count from the bands
+	ActionCorrect        Action = "correct"    // Pick a band to change on review
+	ActionFix            Action = "fix"        // Jump to the first bad band on review
+	ActionDecode         Action = "decode"     // Decode another component
+	ActionAgain          Action = "again"      // Decode again with the same type and band count
+	ActionEdit           Action = "edit"
+	ActionNote           Action = "note"
+	ActionExport         Action = "export"
+	ActionMeasure        Action = "measure"    // Enter a measured value
+	ActionSpec           Action = "spec"       // Check the result against a target value and tolerance
+	ActionBOM            Action = "bom"        // Copy the result as a BOM row
+	ActionBOMExport      Action = "bom_export" // Export history as an aggregated BOM
+	ActionPin            Action = "pin"        // Pin or unpin a result in favorites
+	ActionAggregate      Action = "aggregate"  // Toggle grouped CSV export in the file picker
+	ActionAppend         Action = "append"     // Append to an existing CSV export
+	ActionOverwrite      Action = "overwrite"  // Overwrite an existing export file
+	ActionUnits          Action = "units"
+	ActionFrequency      Action = "frequency"
+	ActionReverse        Action = "reverse"
+	ActionCompact        Action = "compact"
+	ActionHistory        Action = "history"       // Browse the decoded history
+	ActionWorking        Action = "working"       // Show the calculation steps
+	ActionBack           Action = "back"          // Back one step while decoding
+	ActionUndo           Action = "undo"          // Re-enter the previous band
+	ActionPower          Action = "power"         // Resistor power at a voltage or current
+	ActionCombine        Action = "combine"       // Series and parallel of parts from history
+	ActionDivider        Action = "divider"       // Voltage divider of two resistors from history
+	ActionCopy           Action = "copy"          // Copy a one-line result summary
+	ActionLockUnit       Action = "lock_unit"     // Cycle a fixed display unit
+	ActionVoltageTable   Action = "voltage_table" // Capacitor voltage codes by type
+	ActionSummary        Action = "summary"       // Toggle the history summary
+)
+
+// keyBinding is an action with its default keys, help text and the screen
+// group it is listed under on the help screen
+type keyBinding struct {
+	action Action
+	keys   []string
+	help   string
+	group  string
+}
+
+// Help screen groups, in the order they are listed
+const (
+	groupGeneral   = "General"
+	groupWelcome   = "Welcome"
+	groupComponent = "Component selection"
+	groupBands     = "Band input"
+	groupReview    = "Review"
+	groupResults   = "Results"
+	groupLists     = "Reference, history and favorites"
+	groupExport    = "Export"
+)
+
+// helpGroups lists the help screen groups in display order
+var helpGroups = []string{groupGeneral, groupWelcome, groupComponent, groupBands, groupReview, groupResults, groupLists, groupExport}
+
+// defaultBindings lists every action in help screen order
+var defaultBindings = []keyBinding{
+	{ActionContinue, []string{"enter", " "}, "Begin on welcome, calculate on review", groupGeneral},
+	{ActionSubmit, []string{"enter"}, "Accept typed input", groupGeneral},
+	{ActionCancel, []string{"esc"}, "Leave typed input without saving", groupGeneral},
+	{ActionQuit, []string{"q"}, "Quit (or go back from reference, edit and export)", groupGeneral},
+	{ActionHelp, []string{"?"}, "Show this help", groupGeneral},
+	{ActionBack, []string{"esc", "b"}, "Back one step while decoding (only Esc while typing)", groupGeneral},
+	{ActionReference, []string{"r"}, "Color code reference chart", groupWelcome},
+	{ActionLookup, []string{"l"}, "Capacitor value lookup", groupWelcome},
+	{ActionResistorBands, []string{"b"}, "Resistor bands from a value", groupWelcome},
+	{ActionCapacitorBands, []string{"v"}, "Capacitor bands from a value", groupWelcome},
+	{ActionAbout, []string{"a"}, "Version and build info", groupWelcome},
+	{ActionFavorites, []string{"f"}, "Pinned favorites", groupWelcome},
+	{ActionScrollUp, []string{"up", "k"}, "Scroll up", groupLists},
+	{ActionScrollDown, []string{"down", "j"}, "Scroll down", groupLists},
+	{ActionPageUp, []string{"pgup"}, "Page up", groupLists},
+	{ActionPageDown, []string{"pgdown", " "}, "Page down", groupLists},
+	{ActionSummary, []string{"s"}, "Summary of the decoded history: counts by type, value range (on history screen)", groupLists},
+	{ActionCapacitor, []string{"c"}, "Choose capacitor", groupComponent},
+	{ActionResistor, []string{"r"}, "Choose resistor", groupComponent},
+	{ActionSMD, []string{"s"}, "Choose SMD resistor code", groupComponent},
+	{ActionVoltageTable, []string{"v"}, "Capacitor voltage codes by type (on type selection)", groupComponent},
+	{ActionMilitary, []string{"m"}, "5-band military resistor with a reliability band (at the band count)", groupComponent},
+	{ActionAutoBands, []string{"a"}, "Enter resistor bands and infer the band count (at the band count)", groupComponent},
+	{ActionUndo, []string{"ctrl+z", "-"}, "Step back to the previous band and enter it again", groupBands},
+	{ActionCorrect, []string{"c"}, "Correct a band", groupReview},
+	{ActionFix, []string{"f"}, "Fix the first bad band", groupReview},
+	{ActionDecode, []string{"d"}, "Decode another component", groupResults},
+	{ActionAgain, []string{"a"}, "Decode again with the same settings", groupResults},
+	{ActionEdit, []string{"e"}, "Edit the bands (of the selected entry on the history screen)", groupResults},
+	{ActionNote, []string{"n"}, "Add or edit a note", groupResults},
+	{ActionExport, []string{"x"}, "Export history to CSV, JSON or Markdown", groupResults},
+	{ActionMeasure, []string{"v"}, "Log a measured value with pass/fail", groupResults},
+	{ActionSpec, []string{"g"}, "Check the worst-case range against a target value and tolerance", groupResults},
+	{ActionBOM, []string{"b"}, "Copy a BOM row with package and quantity", groupResults},
+	{ActionBOMExport, []string{"m"}, "Export history as a BOM", groupResults},
+	{ActionPin, []string{"p"}, "Pin or unpin in favorites (also on history and favorites)", groupResults},
+	{ActionAggregate, []string{"tab"}, "Group identical parts with a Qty count", groupExport},
+	{ActionAppend, []string{"a"}, "Append to an existing CSV", groupExport},
+	{ActionOverwrite, []string{"o"}, "Overwrite an existing file", groupExport},
+	{ActionUnits, []string{"u"}, "Toggle base unit value", groupResults},
+	{ActionFrequency, []string{"f"}, "Set design frequency", groupResults},
+	{ActionReverse, []string{"r"}, "Reverse the band order", groupResults},
+	{ActionCompact, []string{"c"}, "Toggle the one-line result", groupResults},
+	{ActionHistory, []string{"h"}, "Browse the decoded history", groupResults},
+	{ActionPower, []string{"o"}, "Power dissipated at a voltage or current, with a rating to use (resistor results)", groupResults},
+	{ActionCombine, []string{"t"}, "Series and parallel total of parts picked from history (Enter picks)", groupResults},
+	{ActionDivider, []string{"i"}, "Voltage divider of two resistors from history", groupResults},
+	{ActionWorking, []string{"w"}, "Show the working: the calculation step by step", groupResults},
+	{ActionCopy, []string{"y"}, "Copy a one-line summary to the clipboard", groupResults},
+	{ActionLockUnit, []string{"l"}, "Cycle a fixed unit for values and CSV export (pF/nF/µF or Ω/kΩ/MΩ, then auto)", groupResults},
+}
+
+// Keymap maps actions to the keys that trigger them
+type Keymap map[Action][]string
+
+// DefaultKeymap returns the built-in key bindings
+func DefaultKeymap() Keymap {
+	keymap := Keymap{}
+	for _, binding := range defaultBindings {
+		keymap[binding.action] = append([]string(nil), binding.keys...)
+	}
+	return keymap
+}
+
+// NewKeymap returns the default key bindings with overrides applied
+// Returns an error for an unknown action name or an action with no keys
+func NewKeymap(overrides map[string][]string) (Keymap, error) {
+	keymap := DefaultKeymap()
+
+	// Sorted so the first error reported is stable
+	names := make([]string, 0, len(overrides))
+	for name := range overrides {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		action := Action(name)
+		if _, ok := keymap[action]; !ok {
+			return nil, fmt.Errorf("unknown action '%s' in keymap", name)
+		}
+		if len(overrides[name]) == 0 {
+			return nil, fmt.Errorf("action '%s' has no keys", name)
+		}
+		keymap[action] = append([]string(nil), overrides[name]...)
+	}
+
+	return keymap, nil
+}
+
+// Matches reports whether key triggers action. Letters match either case.
+func (k Keymap) Matches(key string, action Action) bool {
+	for _, bound := range k.Keys(action) {
+		if strings.EqualFold(key, bound) {
+			return true
+		}
+	}
+	return false
+}
+
+// Keys returns the keys bound to an action
+// Actions missing from the keymap use their default keys.
+func (k Keymap) Keys(action Action) []string {
+	if keys, ok := k[action]; ok {
+		return keys
+	}
+	return defaultKeys(action)
+}
+
+// Describe returns the keys bound to an action for display, e.g. "up/k"
+func (k Keymap) Describe(action Action) string {
+	keys := k.Keys(action)
+	names := make([]string, len(keys))
+	for i, key := range keys {
+		if key == " " {
+			key = "space"
+		}
+		names[i] = key
+	}
+	return strings.Join(names, "/")
+}
+
+// Label marks the key bound to an action within a prompt word, e.g.
+// "e(X)port" for export on x, or "(Enter) calculate" when no bound key is
+// one of the word's letters
+func (k Keymap) Label(action Action, word string) string {
+	keys := k.Keys(action)
+	lower := strings.ToLower(word)
+	for _, key := range keys {
+		if len(key) != 1 || key == " " {
+			continue
+		}
+		if i := strings.Index(lower, strings.ToLower(key)); i >= 0 {
+			return word[:i] + "(" + strings.ToUpper(word[i:i+1]) + ")" + word[i+1:]
+		}
+	}
+	name := strings.Split(k.Describe(action), "/")[0]
+	return "(" + strings.ToUpper(name[:1]) + name[1:] + ") " + word
+}
+
+// defaultKeys returns the built-in keys for an action
+func defaultKeys(action Action) []string {
+	for _, binding := range defaultBindings {
+		if binding.action == action {
+			return binding.keys
+		}
+	}
+	return nil
+}
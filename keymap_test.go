@@ -0,0 +1,530 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"tropical-fish/decoder"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// TestKeymapMatches tests default bindings, overrides and case folding
+func TestKeymapMatches(t *testing.T) {
+	keys, err := NewKeymap(map[string][]string{"export": {"s"}})
+	if err != nil {
+		t.Fatalf("NewKeymap() error = %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		keymap Keymap
+		key    string
+		action Action
+		want   bool
+	}{
+		{"default quit", DefaultKeymap(), "q", ActionQuit, true},
+		{"upper case letter", DefaultKeymap(), "Q", ActionQuit, true},
+		{"second default key", DefaultKeymap(), "k", ActionScrollUp, true},
+		{"overridden key", keys, "s", ActionExport, true},
+		{"old key no longer bound", keys, "x", ActionExport, false},
+		{"other actions keep defaults", keys, "n", ActionNote, true},
+		{"nil keymap uses defaults", nil, "enter", ActionSubmit, true},
+		{"unbound key", DefaultKeymap(), "z", ActionQuit, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.keymap.Matches(tt.key, tt.action); got != tt.want {
+				t.Errorf("Matches(%q, %s) = %v, want %v", tt.key, tt.action, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestNewKeymapErrors tests rejection of invalid keymap sections
+func TestNewKeymapErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		overrides map[string][]string
+	}{
+		{"unknown action", map[string][]string{"explode": {"z"}}},
+		{"no keys", map[string][]string{"quit": {}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewKeymap(tt.overrides); err == nil {
+				t.Errorf("NewKeymap(%v) expected error", tt.overrides)
+			}
+		})
+	}
+}
+
+// TestKeymapDescribe tests key names shown in the help overlay
+func TestKeymapDescribe(t *testing.T) {
+	keys := DefaultKeymap()
+	if got := keys.Describe(ActionPageDown); got != "pgdown/space" {
+		t.Errorf("Describe(page_down) = %q, want %q", got, "pgdown/space")
+	}
+}
+
+// TestKeymapLabel tests marking the bound key within a prompt word
+func TestKeymapLabel(t *testing.T) {
+	keys, _ := NewKeymap(map[string][]string{"compact": {"z"}})
+	tests := []struct {
+		action Action
+		word   string
+		want   string
+	}{
+		{ActionExport, "export", "e(X)port"},
+		{ActionBack, "Back", "(B)ack"}, // Esc is bound first
+		{ActionCompact, "Compact", "(Z) Compact"},
+		{ActionContinue, "calculate", "(Enter) calculate"},
+	}
+	for _, tt := range tests {
+		if got := keys.Label(tt.action, tt.word); got != tt.want {
+			t.Errorf("Label(%s, %q) = %q, want %q", tt.action, tt.word, got, tt.want)
+		}
+	}
+}
+
+// TestLoadConfig tests reading key bindings from a config file
+func TestLoadConfig(t *testing.T) {
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "config.json")
+	if err := os.WriteFile(valid, []byte(`{"keymap": {"scroll_down": ["j", "n"]}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	invalid := filepath.Join(dir, "bad.json")
+	if err := os.WriteFile(invalid, []byte(`{"keymap": {"nope": ["z"]}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	keys, _, err := loadConfig(valid)
+	if err != nil {
+		t.Fatalf("loadConfig(valid) error = %v", err)
+	}
+	if !keys.Matches("n", ActionScrollDown) || keys.Matches("down", ActionScrollDown) {
+		t.Errorf("scroll_down = %v, want [j n]", keys[ActionScrollDown])
+	}
+
+	if _, _, err := loadConfig(invalid); err == nil {
+		t.Error("loadConfig(invalid) expected error for unknown action")
+	}
+	if _, _, err := loadConfig(filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("loadConfig(missing) expected error for an explicit path")
+	}
+}
+
+// TestPreferences tests saving and restoring the last session's choices
+func TestPreferences(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.json")
+	if err := os.WriteFile(path, []byte(`{"keymap": {"export": ["s"]}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	saved := Preferences{ComponentType: "resistor", CapacitorBands: 4, ResistorBands: 6, ExportDir: dir}
+	if err := SavePreferences(path, saved); err != nil {
+		t.Fatalf("SavePreferences() error = %v", err)
+	}
+	if got := LoadPreferences(path); got != saved {
+		t.Errorf("LoadPreferences() = %+v, want %+v", got, saved)
+	}
+	if keys, _, err := loadConfig(path); err != nil || !keys.Matches("s", ActionExport) {
+		t.Errorf("keymap section lost after saving preferences (err %v)", err)
+	}
+
+	corrupt := filepath.Join(dir, "corrupt.json")
+	if err := os.WriteFile(corrupt, []byte(`{"keymap": `), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got := LoadPreferences(corrupt); got != (Preferences{}) {
+		t.Errorf("LoadPreferences(corrupt) = %+v, want defaults", got)
+	}
+	if err := SavePreferences(corrupt, saved); err == nil {
+		t.Error("SavePreferences(corrupt) expected error rather than overwriting")
+	}
+
+	m := initialModel().withPreferences(saved)
+	if m.componentType != decoder.ComponentResistor || m.capacitorBands != 4 || m.resistorReading.BandCount != 6 ||
+		m.filepicker.CurrentDirectory != dir {
+		t.Errorf("withPreferences(%+v) gave %+v", saved, m.preferences())
+	}
+	m = pressKeys(m, "enter", "enter") // Past the welcome screen, then the remembered type
+	if m.screen != screenBandCountSelection || m.componentType != decoder.ComponentResistor {
+		t.Errorf("Enter on component selection: screen %v, type %v, want resistor band count", m.screen, m.componentType)
+	}
+
+	m = initialModel().withPreferences(Preferences{ComponentType: "diode", CapacitorBands: 7, ResistorBands: 3, ExportDir: filepath.Join(dir, "missing")})
+	if got, want := m.preferences(), initialModel().preferences(); got != want {
+		t.Errorf("withPreferences(out of range) = %+v, want defaults %+v", got, want)
+	}
+}
+
+// TestRemappedResultsKey tests that handlers consult the keymap
+func TestRemappedResultsKey(t *testing.T) {
+	m := initialModel()
+	m.screen = screenResults
+	m.keys, _ = NewKeymap(map[string][]string{"compact": {"o"}})
+
+	updated, _ := m.handleResultsInput("o")
+	if !updated.(model).compact {
+		t.Error("remapped compact key did not toggle compact")
+	}
+	updated, _ = m.handleResultsInput("c")
+	if updated.(model).compact {
+		t.Error("old compact key still toggles compact")
+	}
+}
+
+// TestRemappedBandCountKeys tests that the band count screen uses the
+// military and auto band bindings and shows their keys
+func TestRemappedBandCountKeys(t *testing.T) {
+	initial := initialModel()
+	initial.keys, _ = NewKeymap(map[string][]string{"military": {"x"}, "auto_bands": {"z"}})
+	initial = pressKeys(initial, "enter", "r")
+	view := initial.View()
+	for _, want := range []string{"X = 5-band military", "Z = auto"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("band count screen missing %q:\n%s", want, view)
+		}
+	}
+
+	m := pressKeys(initial, "x")
+	if !m.resistorReading.Military || m.resistorReading.BandCount != 5 {
+		t.Errorf("remapped military key gave BandCount %d, Military %t", m.resistorReading.BandCount, m.resistorReading.Military)
+	}
+	if m = pressKeys(initial, "z"); !m.autoBandCount {
+		t.Error("remapped auto band key did not start an auto band count")
+	}
+	if m = pressKeys(initial, "a"); m.autoBandCount {
+		t.Error("old auto band key still starts an auto band count")
+	}
+}
+
+// TestHelpScreen tests opening the help screen with "?", returning to the
+// screen it was opened from, and that every binding is listed under a group
+func TestHelpScreen(t *testing.T) {
+	tests := []struct {
+		name     string
+		screen   screenType
+		input    string
+		keys     []string
+		wantHelp bool
+	}{
+		{"from welcome", screenWelcome, "", []string{"?"}, true},
+		{"from band input", screenBandInput, "bro", []string{"?"}, true},
+		{"not from note input", screenNoteInput, "why", []string{"?"}, false},
+		{"? closes", screenResults, "", []string{"?", "?"}, false},
+		{"esc closes", screenReview, "", []string{"?", "esc"}, false},
+		{"other keys stay", screenResults, "", []string{"?", "d"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := initialModel()
+			m.screen = tt.screen
+			m.input = tt.input
+
+			m = pressKeys(m, tt.keys...)
+			if got := m.screen == screenHelp; got != tt.wantHelp {
+				t.Fatalf("on help screen = %v, want %v (screen %v)", got, tt.wantHelp, m.screen)
+			}
+			if !tt.wantHelp && m.screen != tt.screen {
+				t.Errorf("screen = %v, want %v", m.screen, tt.screen)
+			}
+			if tt.screen == screenNoteInput && m.input != tt.input+"?" {
+				t.Errorf("input = %q, want %q", m.input, tt.input+"?")
+			}
+		})
+	}
+
+	m := initialModel()
+	view := strings.Join(m.helpLines(), "\n")
+	for _, group := range helpGroups {
+		if !strings.Contains(view, group) {
+			t.Errorf("help screen missing group %q", group)
+		}
+	}
+	for _, binding := range defaultBindings {
+		if binding.group == "" || binding.help == "" {
+			t.Errorf("binding %s has no group or help text", binding.action)
+		}
+		if !strings.Contains(view, binding.help) {
+			t.Errorf("help screen missing %s", binding.action)
+		}
+	}
+}
+
+// TestBackNavigation tests stepping back through the decode chain with Esc
+// and B, and the input restored on the screen returned to
+func TestBackNavigation(t *testing.T) {
+	m := pressKeys(initialModel(), "enter", "c", "k", "enter", "enter", "b", "r", "o")
+	if m.screen != screenBandInput || m.input != "bro" {
+		t.Fatalf("screen = %v, input = %q, want band input with \"bro\"", m.screen, m.input)
+	}
+
+	steps := []struct {
+		key        string
+		wantScreen screenType
+		wantInput  string
+	}{
+		{"esc", screenBandCountSelection, ""},
+		{"esc", screenTypeSelection, "K"},
+		{"esc", screenComponentSelection, ""},
+		{"b", screenWelcome, ""},
+		{"esc", screenWelcome, ""}, // Nothing left to go back to
+	}
+	for _, step := range steps {
+		m = pressKeys(m, step.key)
+		if m.screen != step.wantScreen || m.input != step.wantInput {
+			t.Fatalf("after %q: screen = %v, input = %q, want %v, %q", step.key, m.screen, m.input, step.wantScreen, step.wantInput)
+		}
+		if m.suggestion != "" {
+			t.Errorf("after %q: suggestion = %q, want none", step.key, m.suggestion)
+		}
+	}
+
+	// Back from review refills the last band, so Enter returns to review
+	m = pressKeys(initialModel(), "enter", "r", "4",
+		"b", "n", "enter", "b", "k", "enter", "r", "d", "enter", "g", "d", "enter")
+	if m.screen != screenReview {
+		t.Fatalf("screen = %v, want screenReview", m.screen)
+	}
+	m = pressKeys(m, "b")
+	if m.screen != screenBandInput || m.currentBand != 4 || m.input != "Gold" {
+		t.Fatalf("back from review: screen = %v, band %d, input %q, want band input, band 4, \"Gold\"", m.screen, m.currentBand, m.input)
+	}
+	m = pressKeys(m, "enter")
+	if m.screen != screenReview {
+		t.Fatalf("screen = %v, want screenReview", m.screen)
+	}
+
+	// Correcting a band and decoding again keep the chain
+	m = pressKeys(m, "c", "2", "esc")
+	if m.screen != screenBandCountSelection {
+		t.Errorf("back from a corrected band: screen = %v, want screenBandCountSelection", m.screen)
+	}
+	m = pressKeys(m, "enter", "b", "n", "enter", "b", "k", "enter", "r", "d", "enter", "g", "d", "enter", "enter", "a", "esc", "b")
+	if m.screen != screenComponentSelection {
+		t.Errorf("back from decode again: screen = %v, want screenComponentSelection", m.screen)
+	}
+}
+
+// TestUndoBand tests stepping back a band during band input for capacitors
+// and resistors, and that it does nothing on band 1
+func TestUndoBand(t *testing.T) {
+	// Resistor: undo band 2 (Black) while typing band 3
+	m := pressKeys(initialModel(), "enter", "r", "4", "b", "n", "enter", "b", "k", "enter", "r")
+	m = pressKeys(m, "-")
+	if m.currentBand != 2 || m.input != "" || m.suggestion != "" {
+		t.Fatalf("after undo: band %d, input %q, suggestion %q, want band 2 with no input", m.currentBand, m.input, m.suggestion)
+	}
+	if m.resistorReading.Band1 != decoder.ColorBrown || m.resistorReading.Band2 != decoder.ColorBlack {
+		t.Errorf("bands = %v, %v, want Brown kept and band 2 cleared", m.resistorReading.Band1, m.resistorReading.Band2)
+	}
+
+	// Band 2 of a 4-band resistor is a digit; re-entered as Red it reads 12 × 100
+	m = pressKeys(m, "r", "e", "d", "enter", "r", "d", "enter", "g", "d", "enter", "enter")
+	if m.resistorResult == nil || m.resistorResult.ResistanceOhms != 1200 {
+		t.Fatalf("resistor result = %+v, want 1200 Ω", m.resistorResult)
+	}
+
+	// Capacitor: undo with Ctrl+Z, down to band 1 and no further
+	m = pressKeys(initialModel(), "enter", "c", "k", "enter", "enter", "r", "d", "enter")
+	for range 2 {
+		updated, _ := m.handleBandInputInput("ctrl+z")
+		m = updated.(model)
+	}
+	if m.currentBand != 1 || m.screen != screenBandInput {
+		t.Errorf("after undo on band 1: screen %v, band %d, want band input, band 1", m.screen, m.currentBand)
+	}
+}
+
+// TestEditBandRevalidates tests that a single-band edit returns to review
+// with the whole reading checked again, so a band that depends on the edited
+// one is flagged
+func TestEditBandRevalidates(t *testing.T) {
+	m := initialModel()
+	m.componentType = decoder.ComponentCapacitor
+	m.capacitorReading = decoder.CapacitorReading{
+		Band1: decoder.ColorBrown, Band2: decoder.ColorGreen, Band3: decoder.ColorBlack, Band4: decoder.ColorBlack,
+		BandCount: 4, CapType: decoder.TypeN,
+	}
+	m.screen = screenReview
+
+	// 15 pF → 5 pF, where Black (±20%) is not a valid small capacitor tolerance
+	m = pressKeys(m, "c", "1", "b", "k", "enter")
+	if m.screen != screenReview {
+		t.Fatalf("after editing band 1: screen %v, band %d, want review", m.screen, m.currentBand)
+	}
+	if m.capacitorReading.Band1 != decoder.ColorBlack || m.capacitorReading.Band4 != decoder.ColorBlack {
+		t.Errorf("bands = %v, want band 1 changed and the rest kept", m.capacitorReading.Colors())
+	}
+	if len(m.reviewProblems) != 1 || m.reviewProblems[0].BandNumber != 4 {
+		t.Fatalf("reviewProblems = %v, want a band 4 problem", m.reviewProblems)
+	}
+
+	// Enter doesn't calculate; fixing band 4 does
+	m = pressKeys(m, "enter")
+	if m.screen != screenReview || m.capacitorResult != nil {
+		t.Fatalf("Enter with a problem: screen %v, want to stay on review", m.screen)
+	}
+	m = pressKeys(m, "f", "b", "n", "enter")
+	if m.screen != screenReview || len(m.reviewProblems) != 0 {
+		t.Fatalf("after fixing band 4: screen %v, problems %v, want review without problems", m.screen, m.reviewProblems)
+	}
+	m = pressKeys(m, "enter")
+	if m.capacitorResult == nil || m.capacitorResult.CapacitancePF != 5 {
+		t.Errorf("result = %+v, want 5 pF", m.capacitorResult)
+	}
+}
+
+// TestReviewFourBandTypeM tests that a valid 4-band Type M reading is listed
+// without a band 5 and passes the review check, with no voltage band to validate
+func TestReviewFourBandTypeM(t *testing.T) {
+	m := initialModel()
+	m.componentType = decoder.ComponentCapacitor
+	m.capacitorReading = decoder.CapacitorReading{
+		Band1: decoder.ColorRed, Band2: decoder.ColorViolet, Band3: decoder.ColorOrange, Band4: decoder.ColorBrown,
+		BandCount: 4, CapType: decoder.TypeM,
+	}
+	m.screen = screenReview
+	if view := m.View(); strings.Contains(view, "Band 5") {
+		t.Errorf("review lists a band 5 for 4 bands:\n%s", view)
+	}
+
+	m = pressKeys(m, "enter")
+	if len(m.reviewProblems) != 0 {
+		t.Fatalf("reviewProblems = %v, want none", m.reviewProblems)
+	}
+	if m.screen != screenResults || m.capacitorResult == nil || m.capacitorResult.CapacitancePF != 27000 {
+		t.Errorf("screen %v, result %+v, want results for 27 nF", m.screen, m.capacitorResult)
+	}
+}
+
+// TestAutoBandCount tests entering resistor bands without choosing a count
+func TestAutoBandCount(t *testing.T) {
+	// Type each band's abbreviation and Enter, after picking resistor / auto
+	bands := func(abbrevs ...string) []string {
+		keys := []string{"enter", "r", "a"}
+		for _, abbrev := range abbrevs {
+			keys = append(keys, strings.Split(abbrev, "")...)
+			keys = append(keys, "enter")
+		}
+		return keys
+	}
+
+	// Gold in band 4 ends a 4-band resistor at the empty Enter
+	m := pressKeys(initialModel(), append(bands("ye", "vi", "rd", "gd"), "enter")...)
+	if m.screen != screenReview || m.resistorReading.BandCount != 4 {
+		t.Fatalf("screen %v, band count %d, want review of 4 bands", m.screen, m.resistorReading.BandCount)
+	}
+
+	// Brown in band 4 is ambiguous and needs a second empty Enter
+	m = pressKeys(initialModel(), append(bands("bn", "bk", "rd", "bn"), "enter")...)
+	if m.screen != screenBandInput || m.err == nil || !strings.Contains(m.err.Error(), "Enter again") {
+		t.Fatalf("screen %v, err %v, want a confirmation prompt", m.screen, m.err)
+	}
+	m = pressKeys(m, "enter")
+	if m.screen != screenReview || m.resistorReading.BandCount != 4 {
+		t.Fatalf("after confirming: screen %v, band count %d, want review of 4 bands", m.screen, m.resistorReading.BandCount)
+	}
+
+	// A sixth band ends entry without an empty Enter
+	m = pressKeys(initialModel(), bands("bn", "bk", "bk", "rd", "bn", "rd")...)
+	if m.screen != screenReview || m.resistorReading.BandCount != 6 {
+		t.Errorf("screen %v, band count %d, want review of 6 bands", m.screen, m.resistorReading.BandCount)
+	}
+}
+
+// TestPasteBands tests that a pasted band list is entered band by band
+// instead of being typed into one band
+func TestPasteBands(t *testing.T) {
+	tests := []struct {
+		name       string
+		msg        tea.KeyMsg
+		wantScreen screenType
+		wantBand   int
+		wantInput  string
+		wantErr    bool
+	}{
+		{"bracketed paste", tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("Brown Black Red Gold"), Paste: true}, screenReview, 4, "", false},
+		{"burst of runes with commas", tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(" brown, black, red, gold,\n")}, screenReview, 4, "", false},
+		{"first bands only", tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("brown black"), Paste: true}, screenBandInput, 3, "", false},
+		{"more bands than left", tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("brown black red gold brown"), Paste: true}, screenBandInput, 1, "", true},
+		{"misspelled color", tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("brown blak red gold"), Paste: true}, screenBandInput, 1, "", true},
+		{"partial name", tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("bro"), Paste: true}, screenBandInput, 1, "bro", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := pressKeys(initialModel(), "enter", "r", "4")
+			updated, _ := m.handleKeyPress(tt.msg)
+			m = updated.(model)
+			if m.screen != tt.wantScreen || m.currentBand != tt.wantBand || m.input != tt.wantInput {
+				t.Errorf("screen = %v, band %d, input %q, want %v, band %d, %q",
+					m.screen, m.currentBand, m.input, tt.wantScreen, tt.wantBand, tt.wantInput)
+			}
+			if (m.err != nil) != tt.wantErr {
+				t.Errorf("err = %v, wantErr %v", m.err, tt.wantErr)
+			}
+		})
+	}
+
+	m := pressKeys(initialModel(), "enter", "r", "4")
+	updated, _ := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("Brown Black Red Gold"), Paste: true})
+	if got := updated.(model).resistorReading.Colors(); !slices.Equal(got, []decoder.Color{decoder.ColorBrown, decoder.ColorBlack, decoder.ColorRed, decoder.ColorGold}) {
+		t.Errorf("pasted bands = %v, want Brown, Black, Red, Gold", got)
+	}
+}
+
+// TestDecodeAgainKeepsSettings tests that decoding again from results goes
+// straight to band 1 with the component, capacitor type and band count kept
+// and only the bands cleared, while decoding another starts over
+func TestDecodeAgainKeepsSettings(t *testing.T) {
+	tests := []struct {
+		name      string
+		keys      []string
+		component decoder.ComponentType
+		capType   decoder.CapacitorType
+		bandCount int
+	}{
+		{"5-band resistor", []string{"enter", "r", "5", "b", "n", "enter", "b", "k", "enter", "b", "k", "enter", "b", "n", "enter", "b", "n", "enter", "enter"},
+			decoder.ComponentResistor, "", 5},
+		{"type K capacitor", []string{"enter", "c", "k", "enter", "3", "b", "n", "enter", "b", "k", "enter", "o", "r", "enter", "enter"},
+			decoder.ComponentCapacitor, "K", 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := pressKeys(initialModel(), tt.keys...)
+			if m.screen != screenResults {
+				t.Fatalf("screen = %v, want screenResults", m.screen)
+			}
+
+			again := pressKeys(m, "a")
+			if again.screen != screenBandInput || again.currentBand != 1 || again.componentType != tt.component {
+				t.Errorf("again: screen = %v, band %d, component %v, want band input, band 1, %v",
+					again.screen, again.currentBand, again.componentType, tt.component)
+			}
+			if tt.component == decoder.ComponentCapacitor {
+				want := decoder.CapacitorReading{BandCount: tt.bandCount, CapType: tt.capType}
+				if again.capacitorReading != want {
+					t.Errorf("again: reading = %+v, want %+v", again.capacitorReading, want)
+				}
+			} else if want := (decoder.ResistorReading{BandCount: tt.bandCount}); again.resistorReading != want {
+				t.Errorf("again: reading = %+v, want %+v", again.resistorReading, want)
+			}
+
+			if decode := pressKeys(m, "d"); decode.screen != screenComponentSelection {
+				t.Errorf("decode: screen = %v, want screenComponentSelection", decode.screen)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"tropical-fish/decoder"
+)
+
+// TestLocalizedDisplayAndAutocomplete tests display names and suggestions in German and French
+func TestLocalizedDisplayAndAutocomplete(t *testing.T) {
+	german := decoder.Palette{Language: decoder.LangGerman}
+	if got := german.ColorName(decoder.ColorWhite); got != "Weiß" {
+		t.Errorf("ColorName(White) = %q, want %q", got, "Weiß")
+	}
+	if got := GetColorSuggestion("gr", 1, german); got != "ün" {
+		t.Errorf("GetColorSuggestion(\"gr\", 1) = %q, want %q", got, "ün")
+	}
+	if got := GetColorSuggestion("sil", 1, german); got != "" {
+		t.Errorf("GetColorSuggestion(\"sil\", 1) = %q, want no suggestion on a digit band", got)
+	}
+	if got := GetColorSuggestion("sil", 4, german); got != "ber" {
+		t.Errorf("GetColorSuggestion(\"sil\", 4) = %q, want %q", got, "ber")
+	}
+
+	// French "Or" is Gold, not the start of Orange or the OR abbreviation
+	french := decoder.Palette{Language: decoder.LangFrench}
+	if got := GetColorSuggestion("or", 4, french); got != "" {
+		t.Errorf("GetColorSuggestion(\"or\", 4) in French = %q, want no suggestion", got)
+	}
+	if got := GetColorSuggestion("ora", 4, french); got != "nge" {
+		t.Errorf("GetColorSuggestion(\"ora\", 4) in French = %q, want %q", got, "nge")
+	}
+}
+
+// TestTypeLocalizedBands tests typing color names with non-ASCII letters
+// during band input
+func TestTypeLocalizedBands(t *testing.T) {
+	m := initialModel()
+	m.palette = decoder.Palette{Language: decoder.LangGerman}
+
+	keys := []string{"enter", "r", "4"}
+	for _, name := range []string{"grün", "weiß", "braun", "gold"} {
+		keys = append(keys, strings.Split(name, "")...)
+		keys = append(keys, "enter")
+	}
+	m = pressKeys(m, keys...)
+	if m.screen != screenReview {
+		t.Fatalf("screen = %v, band %d, input %q, want review", m.screen, m.currentBand, m.input)
+	}
+	want := []decoder.Color{decoder.ColorGreen, decoder.ColorWhite, decoder.ColorBrown, decoder.ColorGold}
+	if got := m.resistorReading.Colors(); !slices.Equal(got, want) {
+		t.Errorf("bands = %v, want Green, White, Brown, Gold", got)
+	}
+}
@@ -1,20 +1,171 @@
 package main
 
 import (
+	"errors"
+	"flag"
 	"fmt"
+	"io/fs"
+	"math"
 	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/charmbracelet/bubbles/filepicker"
+	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+
+	"tropical-fish/decoder"
 )
 
 func main() {
-	p := tea.NewProgram(initialModel(), tea.WithAltScreen())
-	if _, err := p.Run(); err != nil {
+	opts, err := parseCLIFlags(os.Args[1:], os.Stderr)
+	if errors.Is(err, flag.ErrHelp) {
+		os.Exit(0)
+	} else if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(2)
+	}
+
+	// NO_COLOR (https://no-color.org) and output that isn't a terminal get
+	// plain text, with bands named as "[Red]" rather than drawn as swatches
+	if opts.noColor || os.Getenv("NO_COLOR") != "" || !isTerminal(os.Stdout) {
+		lipgloss.SetColorProfile(termenv.Ascii)
+	}
+
+	// Non-interactive modes skip the TUI entirely
+	if opts.showVersion {
+		fmt.Print(CurrentBuildInfo())
+		os.Exit(0)
+	}
+	if opts.selfCheck {
+		os.Exit(runSelfCheck(os.Stdout))
+	}
+	if opts.chart != "" {
+		os.Exit(runChartExport(opts, os.Stdout, os.Stderr))
+	}
+	if opts.in != "" {
+		os.Exit(runBatch(opts, os.Stdout, os.Stderr))
+	}
+	if opts.batch != "" {
+		os.Exit(runComponentBatch(opts, os.Stdout, os.Stderr))
+	}
+	if opts.decodeRequested() {
+		os.Exit(runDecode(opts, os.Stdout, os.Stderr))
+	}
+
+	keys, configPath, err := loadConfig(opts.configPath)
+	if err != nil && opts.configPath != "" {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(2)
+	} else if err != nil {
+		// A broken default config shouldn't keep the tool from starting
+		fmt.Fprintf(os.Stderr, "Warning: %v; using the default settings\n", err)
+		keys = DefaultKeymap()
+	}
+
+	m := initialModel()
+	if configPath != "" {
+		m = m.withPreferences(LoadPreferences(configPath))
+	}
+	m.keys = keys
+	m.configPath = configPath
+	m.palette = opts.palette
+	if path, err := DefaultFavoritesPath(); err == nil {
+		favorites, err := LoadFavorites(path)
+		m.favorites = favorites
+		m.favoritesPath = path
+		if err != nil && len(favorites) == 0 {
+			// Pinning would overwrite a file that may still be repaired
+			fmt.Fprintf(os.Stderr, "Warning: %v; favorites won't be saved this session\n", err)
+			m.favoritesPath = ""
+		} else if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+	}
+	m.historyLimit = opts.historyLimit
+	m.compact = opts.compact
+	if opts.importFile != "" {
+		imported, err := ImportFromCSV(opts.importFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: import failed: %v\n", err)
+			os.Exit(1)
+		}
+		var summary ImportSummary
+		m, summary = importHistory(m, imported, ImportOptions{
+			SkipDuplicates: opts.importDedup,
+			MergeNotes:     opts.importMergeNotes,
+		})
+		// Imported entries already exist on disk
+		m.exportedCount = len(m.history)
+		m.successMsg = "✓ Import from " + opts.importFile + ": " + summary.String()
+	}
+	var programOpts []tea.ProgramOption
+	if useAltScreen(opts.noAltScreen, os.Stdout) {
+		programOpts = append(programOpts, tea.WithAltScreen())
+		if !opts.noMouse {
+			programOpts = append(programOpts, tea.WithMouseCellMotion())
+		}
+	}
+	p := tea.NewProgram(m, programOpts...)
+	final, err := p.Run()
+	if err != nil {
 		fmt.Printf("Error running program: %v\n", err)
 		os.Exit(1)
 	}
+	if final, ok := final.(model); ok && configPath != "" {
+		if err := SavePreferences(configPath, final.preferences()); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: preferences not saved: %v\n", err)
+		}
+	}
+}
+
+// useAltScreen reports whether the TUI should take over the alternate screen.
+// Output that isn't a terminal (a pipe, a log file, some CI runners) is run
+// inline so it isn't garbled by screen switching.
+func useAltScreen(disabled bool, out *os.File) bool {
+	return !disabled && isTerminal(out)
+}
+
+// isTerminal reports whether out is a terminal rather than a pipe or file
+func isTerminal(out *os.File) bool {
+	info, err := out.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// loadConfig reads the config file at path, or at the default location if
+// path is empty, applies its plausibility settings and returns its key
+// bindings. A missing default config is not an error.
+func loadConfig(path string) (Keymap, string, error) {
+	explicit := path != ""
+	if !explicit {
+		var err error
+		path, err = DefaultConfigPath()
+		if err != nil {
+			return DefaultKeymap(), "", nil
+		}
+	}
+
+	cfg, err := LoadConfig(path)
+	if errors.Is(err, fs.ErrNotExist) && !explicit {
+		return DefaultKeymap(), path, nil
+	} else if err != nil {
+		return nil, path, err
+	}
+
+	if err := SetPlausibility(cfg.Plausibility); err != nil {
+		return nil, path, fmt.Errorf("%s: %w", path, err)
+	}
+	keys, err := NewKeymap(cfg.Keymap)
+	if err != nil {
+		return nil, path, fmt.Errorf("%s: %w", path, err)
+	}
+	return keys, path, nil
 }
 
 func initialModel() model {
@@ -22,19 +173,64 @@ func initialModel() model {
 	fp.AllowedTypes = []string{".csv"}
 	fp.CurrentDirectory, _ = os.UserHomeDir()
 
+	sp := spinner.New()
+	sp.Spinner = spinner.Dot
+	sp.Style = lipgloss.NewStyle().Foreground(colorPrimary)
+
 	return model{
 		screen:      screenWelcome,
 		input:       "",
 		currentBand: 1,
-		capacitorReading: CapacitorReading{
+		capacitorReading: decoder.CapacitorReading{
 			BandCount: 5, // Default to 5 bands
 		},
-		resistorReading: ResistorReading{
+		resistorReading: decoder.ResistorReading{
 			BandCount: 4, // Default to 4 bands
 		},
-		history:    []ComponentEntry{},
-		filepicker: fp,
+		capacitorBands:    5,
+		history:           []ComponentEntry{},
+		currentEntryIndex: -1,
+		redecodeIndex:     -1,
+		filepicker:        fp,
+		spinner:           sp,
+		keys:              DefaultKeymap(),
+	}
+}
+
+// withPreferences applies the last session's preferences, keeping the
+// defaults for anything missing or out of range
+func (m model) withPreferences(prefs Preferences) model {
+	switch prefs.ComponentType {
+	case "capacitor":
+		m.componentType = decoder.ComponentCapacitor
+	case "resistor":
+		m.componentType = decoder.ComponentResistor
 	}
+	if decoder.ValidateBandCount(prefs.CapacitorBands) == nil {
+		m.capacitorReading.BandCount = prefs.CapacitorBands
+		m.capacitorBands = prefs.CapacitorBands
+	}
+	if decoder.ValidateResistorBandCount(prefs.ResistorBands) == nil {
+		m.resistorReading.BandCount = prefs.ResistorBands
+	}
+	if info, err := os.Stat(prefs.ExportDir); err == nil && info.IsDir() {
+		m.filepicker.CurrentDirectory = prefs.ExportDir
+	}
+	return m
+}
+
+// preferences returns the choices to restore next session
+func (m model) preferences() Preferences {
+	prefs := Preferences{
+		ComponentType:  "capacitor",
+		CapacitorBands: m.capacitorBands,
+		ResistorBands:  m.resistorReading.BandCount,
+		ExportDir:      m.filepicker.CurrentDirectory,
+	}
+	if m.componentType == decoder.ComponentResistor {
+		prefs.ComponentType = "resistor"
+	}
+	return prefs
 }
 
 type screenType int
@@ -50,28 +246,114 @@ const (
 	screenNoteInput
 	screenEdit
 	screenFilePicker
+	screenReference
+	screenFrequencyInput
+	screenCapacitanceLookup
+	screenAbout
+	screenBOMInput
+	screenMeasuredInput
+	screenSpecInput
+	screenFavorites
+	screenReverseResistor
+	screenReverseCapacitor
+	screenSMDInput
+	screenHistory
+	screenHelp
+	screenPowerInput
+	screenCombine
+	screenDivider
+	screenVoltageTable
 )
 
 type model struct {
-	screen           screenType
-	input            string
-	suggestion       string // Autocomplete suggestion for current input
-	err              error
-	successMsg       string // Success message (e.g., export success)
-	quitting         bool
-	currentBand      int // Current band being input (1-6)
-	componentType    ComponentType
-	capacitorReading CapacitorReading
-	resistorReading  ResistorReading
-	capacitorResult  *CalculationResult
-	resistorResult   *ResistorResult
-	editBandIndex    int              // For edit mode
-	currentNote      string           // Current note being edited
-	history          []ComponentEntry // History of decoded components
-	filepicker       filepicker.Model // File picker for export
-	selectedFile     string           // Selected export file path
+	screen            screenType
+	input             string
+	suggestion        string // Autocomplete suggestion for current input
+	replaceOnType     bool   // Next typed character replaces the rejected input
+	err               error
+	successMsg        string // Success message (e.g., export success)
+	quitting          bool
+	currentBand       int // Current band being input (1-6)
+	componentType     decoder.ComponentType
+	capacitorReading  decoder.CapacitorReading
+	resistorReading   decoder.ResistorReading
+	capacitorResult   *decoder.CalculationResult
+	resistorResult    *decoder.ResistorResult
+	editBandIndex     int                        // For edit mode
+	reviewProblems    []*decoder.ValidationError // Problems found when calculating from review
+	reversed          bool                       // Result uses the bands in the opposite order to how they were entered
+	currentNote       string                     // Current note being edited
+	currentPackage    string                     // Package entered for the BOM row
+	currentQuantity   int                        // Quantity entered for the BOM row (0 = 1)
+	currentMeasured   float64                    // Measured value in pF or Ω
+	hasMeasurement    bool                       // A measured value was entered for this result
+	operatingPoint    string                     // Applied voltage or current for resistor power, as entered ("" = unset)
+	specTarget        string                     // Target value and tolerance to check the result against, as entered ("" = unset)
+	history           []ComponentEntry           // History of decoded components
+	currentEntryIndex int                        // Index in history of the current result (-1 = not saved yet)
+	historyCursor     int                        // Selected entry on the history screen
+	historySummary    bool                       // History screen shows the summary instead of the list
+	redecodeIndex     int                        // History entry whose bands are being entered again, to replace it (-1 = none)
+	favorites         []ComponentEntry           // Pinned components, kept across sessions
+	favoritesPath     string                     // File favorites are saved to ("" = not saved)
+	favoriteCursor    int                        // Selected entry on the favorites screen
+	favoritesSaving   bool                       // A favorites save is running
+	favoritesDirty    bool                       // Favorites changed while saving and need saving again
+	clipboardOSC      string                     // OSC 52 clipboard write for the next frame ("" = none)
+	combineCursor     int                        // Highlighted resistor on the combine screen
+	combineSelected   map[int]bool               // History indexes of the parts picked to combine
+	filepicker        filepicker.Model           // File picker for export
+	selectedFile      string                     // Selected export file path
+	showBaseUnit      bool                       // Show value in base unit (pF / Ω) alongside scaled value
+	capacitanceUnit   string                     // Fixed capacitance display and export unit ("" = auto-scale)
+	resistanceUnit    string                     // Fixed resistance display and export unit ("" = auto-scale)
+	compact           bool                       // Show the one-line result instead of the results box
+	showWorking       bool                       // Show the calculation steps under the result
+	lookup            *decoder.CapacitanceLookup // Last capacitance lookup result
+	bandsFromValue    *decoder.ResistorReading   // Last resistor bands solved from a value
+	capBandsFromValue *decoder.CapacitorReading  // Last capacitor bands solved from a value
+	smdCode           string                     // Last decoded SMD resistor code
+	smdResult         *decoder.ResistorResult    // Last decoded SMD resistor code result
+	reverseBandCount  int                        // Band count for solving bands from a value
+	width             int                        // Terminal width
+	height            int                        // Terminal height
+	scrollOffset      int                        // First visible line on scrollable screens
+	frequencyHz       float64                    // Session-wide design frequency for reactance (0 = unset)
+	historyLimit      int                        // Maximum history entries kept (0 = unlimited)
+	historyTrimmed    int                        // Number of oldest entries dropped by historyLimit
+	exportedCount     int                        // Number of oldest history entries already exported
+	spinner           spinner.Model              // Shown while an export is running
+	exporting         bool                       // An export command is in flight
+	exportBOM         bool                       // The file picker exports an aggregated BOM
+	exportAggregate   bool                       // CSV export groups identical parts with a Qty column
+	exportPending     string                     // Existing CSV waiting for an append / overwrite choice
+	exportCanAppend   bool                       // exportPending has this export's header, so rows can be appended
+	exportPreview     [][]string                 // CSV header and rows waiting for confirmation before they are written
+	exportPreviewPath string                     // File the previewed rows go to
+	exportPreviewAdd  bool                       // The previewed rows are appended to exportPreviewPath
+	keys              Keymap                     // Key bindings for actions
+	palette           decoder.Palette            // Colors in use and the language their names are shown in
+	configPath        string                     // Config file the key bindings can be changed in
+	helpReturn        screenType                 // Screen to go back to when help closes
+	helpOffset        int                        // Help screen scroll position
+	backStack         []screenType               // Decode steps to go back through, newest last
+	autoBandCount     bool                       // Resistor bands are entered until an empty Enter, and the count inferred
+	confirmBandCount  bool                       // An ambiguous inferred band count waits for a second empty Enter
+	capacitorBands    int                        // Band count preselected for capacitor types without a conventional one
+	voltageTableType  decoder.CapacitorType      // Type shown on the voltage code table
+}
+
+// exportResultMsg reports the outcome of an export command
+type exportResultMsg struct {
+	err     error
+	path    string
+	count   int // Number of entries written
+	trimmed int // historyTrimmed when the snapshot was taken
 }
 
+// exporter writes history entries to a file
+type exporter func(history []ComponentEntry, path string) error
+
 func (m model) Init() tea.Cmd {
 	return m.filepicker.Init()
 }
@@ -80,47 +362,263 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		return m.handleKeyPress(msg)
+	case tea.MouseMsg:
+		return m.handleMouse(msg)
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+	case spinner.TickMsg:
+		if m.exporting {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			return m, cmd
+		}
+		return m, nil
+	case exportResultMsg:
+		m.exporting = false
+		if msg.err != nil {
+			m.err = fmt.Errorf("export failed: %v", msg.err)
+			m.successMsg = ""
+		} else if m.exportBOM {
+			// A BOM can't be imported back, so history still counts as unexported
+			m.err = nil
+			m.successMsg = fmt.Sprintf("✓ Exported BOM of %d component%s to %s",
+				msg.count,
+				map[bool]string{true: "", false: "s"}[msg.count == 1],
+				msg.path)
+		} else {
+			m.err = nil
+			// Entries added while the export ran are still unexported, and
+			// exported ones trimmed meanwhile are no longer in history
+			m.exportedCount = max(0, min(msg.count-(m.historyTrimmed-msg.trimmed), len(m.history)))
+			m.successMsg = fmt.Sprintf("✓ Successfully exported %d component%s to %s",
+				msg.count,
+				map[bool]string{true: "", false: "s"}[msg.count == 1],
+				msg.path)
+		}
+		return m, nil
+	case favoritesSavedMsg:
+		m.favoritesSaving = false
+		if msg.err != nil {
+			m.err = fmt.Errorf("saving favorites failed: %v", msg.err)
+			m.successMsg = ""
+		}
+		if m.favoritesDirty {
+			m.favoritesDirty = false
+			return m.saveFavorites()
+		}
+		return m, nil
+	case clipboardResultMsg:
+		switch {
+		case msg.err != nil:
+			m.err = fmt.Errorf("copy failed: %v", msg.err)
+			m.successMsg = ""
+		case msg.osc52 != "":
+			// Whether the terminal acts on OSC 52 can't be known
+			m.err = nil
+			m.clipboardOSC = msg.osc52
+			m.successMsg = "✓ Sent " + msg.what + " to the terminal clipboard (OSC 52); not every terminal supports it"
+		default:
+			m.err = nil
+			m.successMsg = "✓ Copied " + msg.what + " to clipboard"
+		}
+		return m, nil
 	}
 
 	// Handle filepicker messages when on filepicker screen
 	if m.screen == screenFilePicker {
-		var cmd tea.Cmd
-		m.filepicker, cmd = m.filepicker.Update(msg)
-
-		// Check if a file was selected
-		if didSelect, path := m.filepicker.DidSelectFile(msg); didSelect {
-			m.selectedFile = path
-			// Perform export
-			err := ExportToCSV(m.history, path)
-			if err != nil {
-				m.err = fmt.Errorf("export failed: %v", err)
-				m.successMsg = ""
-			} else {
-				m.err = nil
-				m.successMsg = fmt.Sprintf("✓ Successfully exported %d capacitor%s to %s",
-					len(m.history),
-					map[bool]string{true: "", false: "s"}[len(m.history) == 1],
-					path)
+		return m.updateFilePicker(msg)
+	}
+
+	return m, nil
+}
+
+// updateFilePicker forwards a message to the file picker and starts the
+// export once a file has been selected
+func (m model) updateFilePicker(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	m.filepicker, cmd = m.filepicker.Update(msg)
+
+	// Check if a file was selected
+	if didSelect, path := m.filepicker.DidSelectFile(msg); didSelect {
+		// Ask before replacing an existing history CSV, which rows can be
+		// appended to instead
+		if _, err := os.Stat(path); err == nil && !m.exportBOM && strings.EqualFold(filepath.Ext(path), ".csv") {
+			m.exportPending = path
+			m.exportCanAppend = CSVHeaderMatches(path, m.exportOptions())
+			m.err = nil
+			return m, cmd
+		}
+		m, startCmd := m.exportOrPreview(path, false)
+		return m, tea.Batch(cmd, startCmd)
+	}
+
+	return m, cmd
+}
+
+// exportOptions returns the session's settings for history exports
+func (m model) exportOptions() ExportOptions {
+	return ExportOptions{
+		FrequencyHz:     m.frequencyHz,
+		Aggregate:       m.exportAggregate,
+		CapacitanceUnit: m.capacitanceUnit,
+		ResistanceUnit:  m.resistanceUnit,
+	}
+}
+
+// startExport returns to the results screen and exports the history to path,
+// appending rows to an existing CSV if appendRows is set
+func (m model) startExport(path string, appendRows bool) (model, tea.Cmd) {
+	m.selectedFile = path
+	m.exportPending = ""
+	preview := m.exportPreview
+	m.exportPreview = nil
+	m.exporting = true
+	m.err = nil
+	m.successMsg = ""
+	// Return to results screen while the export runs
+	m.screen = screenResults
+	var export exporter = ExportBOM
+	switch {
+	case preview != nil:
+		// Write exactly the rows that were confirmed
+		export = func(_ []ComponentEntry, path string) error {
+			return WriteCSVRecords(path, preview, appendRows)
+		}
+	case !m.exportBOM:
+		opts := m.exportOptions()
+		export = func(history []ComponentEntry, path string) error {
+			switch ext := strings.ToLower(filepath.Ext(path)); {
+			case ext == ".json":
+				return ExportToJSONWithOptions(history, path, opts)
+			case ext == ".md":
+				return ExportToMarkdownWithOptions(history, path, opts)
+			case appendRows:
+				return AppendToCSVWithOptions(history, path, opts)
+			default:
+				return ExportToCSVWithOptions(history, path, opts)
 			}
-			// Return to results screen
-			m.screen = screenResults
 		}
+	}
+	return m, tea.Batch(m.spinner.Tick, exportCmd(export, m.history, m.historyTrimmed, path))
+}
+
+// exportOrPreview shows the rows of a CSV history export for confirmation
+// before anything is written, and starts other exports right away
+func (m model) exportOrPreview(path string, appendRows bool) (model, tea.Cmd) {
+	records := BuildCSVRecordsWithOptions(m.history, m.exportOptions())
+	if m.exportBOM || !strings.EqualFold(filepath.Ext(path), ".csv") || len(records) < 2 {
+		return m.startExport(path, appendRows)
+	}
+	m.exportPending = ""
+	m.exportPreview = records
+	m.exportPreviewPath = path
+	m.exportPreviewAdd = appendRows
+	m.scrollOffset = 0
+	m.err = nil
+	return m, nil
+}
+
+// handleExportPreviewInput scrolls the CSV preview, writes it on
+// confirmation or goes back to the file list
+func (m model) handleExportPreviewInput(key string) (tea.Model, tea.Cmd) {
+	visible := m.exportPreviewVisibleLines()
+	maxOffset := max(len(m.exportPreview)-1-visible, 0)
+
+	switch {
+	case m.keys.Matches(key, ActionSubmit):
+		return m.startExport(m.exportPreviewPath, m.exportPreviewAdd)
+	case m.keys.Matches(key, ActionScrollUp):
+		m.scrollOffset = max(m.scrollOffset-1, 0)
+	case m.keys.Matches(key, ActionScrollDown):
+		m.scrollOffset = min(m.scrollOffset+1, maxOffset)
+	case m.keys.Matches(key, ActionPageUp):
+		m.scrollOffset = max(m.scrollOffset-visible, 0)
+	case m.keys.Matches(key, ActionPageDown):
+		m.scrollOffset = min(m.scrollOffset+visible, maxOffset)
+	case m.keys.Matches(key, ActionCancel) || m.keys.Matches(key, ActionQuit):
+		// Back to the file list without writing
+		m.exportPreview = nil
+		m.scrollOffset = 0
+	}
+	return m, nil
+}
 
-		return m, cmd
+// exportPreviewVisibleLines returns how many previewed rows fit in the terminal
+func (m model) exportPreviewVisibleLines() int {
+	const chrome = 11 // header, mode, file line, column names, blank lines and help text
+	if m.height <= chrome {
+		return len(m.exportPreview)
 	}
+	return m.height - chrome
+}
 
+// handleExportChoiceInput answers the append / overwrite prompt for an
+// existing CSV
+func (m model) handleExportChoiceInput(key string) (tea.Model, tea.Cmd) {
+	switch {
+	case m.keys.Matches(key, ActionAppend) && m.exportCanAppend:
+		return m.exportOrPreview(m.exportPending, true)
+	case m.keys.Matches(key, ActionOverwrite):
+		return m.exportOrPreview(m.exportPending, false)
+	case m.keys.Matches(key, ActionCancel) || m.keys.Matches(key, ActionQuit):
+		// Back to the file list to pick another file
+		m.exportPending = ""
+	}
 	return m, nil
 }
 
+// exportCmd runs an exporter off the UI goroutine on a snapshot of the
+// history, so edits made while it runs don't race with the write. trimmed
+// is the history's trimmed count at the snapshot, to tell how many exported
+// entries are dropped while it runs.
+func exportCmd(export exporter, history []ComponentEntry, trimmed int, path string) tea.Cmd {
+	snapshot := make([]ComponentEntry, len(history))
+	copy(snapshot, history)
+
+	return func() tea.Msg {
+		return exportResultMsg{
+			err:     export(snapshot, path),
+			path:    path,
+			count:   len(snapshot),
+			trimmed: trimmed,
+		}
+	}
+}
+
 func (m model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	key := msg.String()
 
+	// The OSC 52 write went out with the frame after the copy
+	m.clipboardOSC = ""
+
 	// Global quit
 	if key == "ctrl+c" {
 		m.quitting = true
 		return m, tea.Quit
 	}
 
+	// A paste arrives as one message holding every rune
+	if m.screen == screenBandInput && msg.Type == tea.KeyRunes && (msg.Paste || len(msg.Runes) > 1) {
+		return m.pasteBands(string(msg.Runes))
+	}
+
+	return m.handleKey(key, msg)
+}
+
+// handleKey handles a key by name, pressed or standing in for a mouse click.
+// msg is the message it came from, which the file picker reads as well.
+func (m model) handleKey(key string, msg tea.Msg) (tea.Model, tea.Cmd) {
+	if m.keys.Matches(key, ActionBack) && m.backAvailable(key) {
+		return m.goBack(), nil
+	}
+	if m.keys.Matches(key, ActionHelp) && m.helpAvailable(key) {
+		m.helpReturn = m.screen
+		m.helpOffset = 0
+		m.screen = screenHelp
+		return m, nil
+	}
+
 	switch m.screen {
 	case screenWelcome:
 		return m.handleWelcomeInput(key)
@@ -140,544 +638,3041 @@ func (m model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.handleNoteInputInput(key)
 	case screenEdit:
 		return m.handleEditInput(key)
+	case screenReference:
+		return m.handleReferenceInput(key)
+	case screenFrequencyInput:
+		return m.handleFrequencyInput(key)
+	case screenCapacitanceLookup:
+		return m.handleCapacitanceLookupInput(key)
+	case screenAbout:
+		return m.handleAboutInput(key)
+	case screenBOMInput:
+		return m.handleBOMInput(key)
+	case screenMeasuredInput:
+		return m.handleMeasuredInput(key)
+	case screenSpecInput:
+		return m.handleSpecInput(key)
+	case screenPowerInput:
+		return m.handlePowerInput(key)
+	case screenCombine:
+		return m.handleCombineInput(key)
+	case screenDivider:
+		return m.handleDividerInput(key)
+	case screenVoltageTable:
+		return m.handleVoltageTableInput(key)
+	case screenFavorites:
+		return m.handleFavoritesInput(key)
+	case screenReverseResistor:
+		return m.handleReverseResistorInput(key)
+	case screenReverseCapacitor:
+		return m.handleReverseCapacitorInput(key)
+	case screenSMDInput:
+		return m.handleSMDInput(key)
+	case screenHistory:
+		return m.handleHistoryInput(key)
+	case screenHelp:
+		return m.handleHelpInput(key)
+	case screenFilePicker:
+		if m.exportPreview != nil {
+			return m.handleExportPreviewInput(key)
+		}
+		if m.exportPending != "" {
+			return m.handleExportChoiceInput(key)
+		}
+		if m.keys.Matches(key, ActionQuit) {
+			// Cancel export, go back to results
+			m.screen = screenResults
+			m.err = nil
+			return m, nil
+		}
+		if m.keys.Matches(key, ActionAggregate) && !m.exportBOM {
+			// BOM exports are always grouped
+			m.exportAggregate = !m.exportAggregate
+			return m, nil
+		}
+		return m.updateFilePicker(msg)
 	}
 
 	return m, nil
 }
 
+// typingScreen reports whether the current screen takes typed text, so
+// printable keys are input rather than commands
+func (m model) typingScreen() bool {
+	switch m.screen {
+	case screenTypeSelection, screenBandInput, screenNoteInput,
+		screenFrequencyInput, screenCapacitanceLookup, screenBOMInput,
+		screenMeasuredInput, screenSpecInput, screenPowerInput, screenDivider, screenFilePicker, screenReverseResistor,
+		screenReverseCapacitor, screenSMDInput:
+		return true
+	}
+	return false
+}
+
+// trimLastRune removes the last character of typed input, keeping
+// multi-byte characters such as µ, Ω or ü whole
+func trimLastRune(input string) string {
+	_, size := utf8.DecodeLastRuneInString(input)
+	return input[:len(input)-size]
+}
+
+// commandKey reports whether key is a command rather than typed text: any
+// key outside typing screens, and on them only keys that can't be part of
+// the typed text, such as "?" or Esc
+func (m model) commandKey(key string) bool {
+	if !m.typingScreen() {
+		return true
+	}
+	r := []rune(key)
+	return len(r) != 1 || !(unicode.IsLetter(r[0]) || unicode.IsDigit(r[0]) || unicode.IsSpace(r[0]))
+}
+
+// helpAvailable reports whether the help key opens the help screen here. It
+// works everywhere but free text input.
+func (m model) helpAvailable(key string) bool {
+	switch m.screen {
+	case screenHelp, screenNoteInput, screenBOMInput:
+		return false
+	}
+	return m.commandKey(key)
+}
+
+// backAvailable reports whether the back key steps back here: on the screens
+// of the decode chain, when there is a step to go back to
+func (m model) backAvailable(key string) bool {
+	switch m.screen {
+	case screenComponentSelection, screenTypeSelection, screenBandCountSelection,
+		screenBandInput, screenReview:
+		return len(m.backStack) > 0 && m.commandKey(key)
+	}
+	return false
+}
+
+// pushScreen moves to the next step of the decode chain, remembering the
+// current screen for goBack. Moving to a screen already on the stack, as when
+// a band is corrected from review, goes back to that point of the chain.
+func (m model) pushScreen(next screenType) model {
+	if i := slices.Index(m.backStack, next); i >= 0 {
+		m.backStack = m.backStack[:i]
+	} else if m.screen != next {
+		m.backStack = append(m.backStack, m.screen)
+	}
+	m.screen = next
+	return m
+}
+
+// goBack returns to the previous step of the decode chain. Partly typed input
+// is dropped; the type or last band chosen on the screen returned to is
+// filled in again so Enter accepts it.
+func (m model) goBack() model {
+	// Screens left without the stack, such as edit back to review, may
+	// still be on top
+	for len(m.backStack) > 0 && m.backStack[len(m.backStack)-1] == m.screen {
+		m.backStack = m.backStack[:len(m.backStack)-1]
+	}
+	if len(m.backStack) == 0 {
+		return m
+	}
+	m.screen = m.backStack[len(m.backStack)-1]
+	m.backStack = m.backStack[:len(m.backStack)-1]
+	m.input = ""
+	m.suggestion = ""
+	m.replaceOnType = false
+	m.reviewProblems = nil
+	m.editBandIndex = 0
+	m.err = nil
+
+	switch m.screen {
+	case screenTypeSelection:
+		m.input = string(m.capacitorReading.CapType)
+	case screenBandInput:
+		colors := m.resistorReading.Colors()
+		if m.componentType == decoder.ComponentCapacitor {
+			colors = m.capacitorReading.Colors()
+		}
+		if m.currentBand >= 1 && m.currentBand <= len(colors) {
+			m.input = m.palette.ColorName(colors[m.currentBand-1])
+		}
+	}
+	return m
+}
+
+// decodeChain returns the back stack for entering bands of the current
+// component type without having walked the chain, as after "decode again"
+func (m model) decodeChain() []screenType {
+	chain := []screenType{screenWelcome, screenComponentSelection}
+	if m.componentType == decoder.ComponentCapacitor {
+		chain = append(chain, screenTypeSelection)
+	}
+	return append(chain, screenBandCountSelection)
+}
+
 func (m model) handleWelcomeInput(key string) (tea.Model, tea.Cmd) {
-	if key == "enter" || key == " " {
-		m.screen = screenComponentSelection
+	if m.keys.Matches(key, ActionContinue) {
+		m.backStack = nil
+		m = m.pushScreen(screenComponentSelection)
+		m.input = ""
+		m.err = nil
+		m.successMsg = ""
+	} else if m.keys.Matches(key, ActionReference) {
+		m.screen = screenReference
+		m.scrollOffset = 0
+		m.err = nil
+		m.successMsg = ""
+	} else if m.keys.Matches(key, ActionLookup) {
+		m.screen = screenCapacitanceLookup
 		m.input = ""
+		m.lookup = nil
 		m.err = nil
-	} else if key == "q" {
+		m.successMsg = ""
+	} else if m.keys.Matches(key, ActionResistorBands) {
+		m.screen = screenReverseResistor
+		m.input = ""
+		m.bandsFromValue = nil
+		m.reverseBandCount = 4
+		m.err = nil
+		m.successMsg = ""
+	} else if m.keys.Matches(key, ActionCapacitorBands) {
+		m.screen = screenReverseCapacitor
+		m.input = ""
+		m.capBandsFromValue = nil
+		m.reverseBandCount = 3
+		m.err = nil
+		m.successMsg = ""
+	} else if m.keys.Matches(key, ActionAbout) {
+		m.screen = screenAbout
+		m.err = nil
+		m.successMsg = ""
+	} else if m.keys.Matches(key, ActionFavorites) {
+		m.screen = screenFavorites
+		m.favoriteCursor = 0
+		m.err = nil
+		m.successMsg = ""
+	} else if m.keys.Matches(key, ActionQuit) {
 		m.quitting = true
 		return m, tea.Quit
 	}
 	return m, nil
 }
 
-func (m model) handleComponentSelectionInput(key string) (tea.Model, tea.Cmd) {
-	lowerKey := strings.ToLower(key)
+// handleAboutInput returns to the welcome screen on any key
+func (m model) handleAboutInput(key string) (tea.Model, tea.Cmd) {
+	m.screen = screenWelcome
+	return m, nil
+}
 
-	// Accept single key press without Enter
-	if lowerKey == "c" {
-		m.componentType = ComponentCapacitor
-		m.screen = screenTypeSelection
-		m.input = ""
+func (m model) handleFavoritesInput(key string) (tea.Model, tea.Cmd) {
+	switch {
+	case m.keys.Matches(key, ActionScrollUp):
+		if m.favoriteCursor > 0 {
+			m.favoriteCursor--
+		}
+	case m.keys.Matches(key, ActionScrollDown):
+		if m.favoriteCursor < len(m.favorites)-1 {
+			m.favoriteCursor++
+		}
+	case m.keys.Matches(key, ActionSubmit) && len(m.favorites) > 0:
+		// Insert the favorite into history and show it like a fresh decode
+		entry := m.favorites[m.favoriteCursor]
+		m.componentType = entry.ComponentType
+		m.capacitorResult = entry.CapacitorResult
+		m.resistorResult = entry.ResistorResult
+		if entry.CapacitorResult != nil {
+			m.capacitorReading = entry.CapacitorResult.Reading
+		}
+		if entry.ResistorResult != nil {
+			m.resistorReading = entry.ResistorResult.Reading
+		}
+		m.currentNote = entry.Note
+		m.currentPackage = entry.Package
+		m.currentQuantity = entry.Quantity
+		m.currentMeasured = 0
+		m.hasMeasurement = false
+		m.operatingPoint = ""
+		m.specTarget = ""
+		m.reversed = false
+		m.currentEntryIndex = -1
+		m = m.saveCurrentEntry()
+		m.screen = screenResults
 		m.err = nil
-	} else if lowerKey == "r" {
-		m.componentType = ComponentResistor
-		m.screen = screenBandCountSelection
-		m.input = ""
+		m.successMsg = ""
+	case m.keys.Matches(key, ActionPin) && len(m.favorites) > 0:
+		m.favorites, _ = toggleFavorite(m.favorites, m.favorites[m.favoriteCursor])
+		if m.favoriteCursor >= len(m.favorites) && m.favoriteCursor > 0 {
+			m.favoriteCursor--
+		}
+		return m.saveFavorites()
+	case m.keys.Matches(key, ActionQuit), m.keys.Matches(key, ActionCancel):
+		m.screen = screenWelcome
 		m.err = nil
-	} else if key == "q" {
-		m.quitting = true
-		return m, tea.Quit
 	}
-
 	return m, nil
 }
 
-func (m model) handleTypeSelectionInput(key string) (tea.Model, tea.Cmd) {
-	// Accept single key press without Enter
-	capType, valid := ParseCapacitorType(key)
-	if valid {
-		m.capacitorReading.CapType = capType
-		m.screen = screenBandCountSelection
+func (m model) handleReverseResistorInput(key string) (tea.Model, tea.Cmd) {
+	if m.keys.Matches(key, ActionSubmit) && m.input != "" {
+		m = m.solveBandsFromValue()
+	} else if key == "tab" {
+		// Cycle the band count and re-solve the current value
+		m.reverseBandCount++
+		if m.reverseBandCount > 6 {
+			m.reverseBandCount = 4
+		}
+		if m.input != "" {
+			m = m.solveBandsFromValue()
+		}
+	} else if m.keys.Matches(key, ActionCancel) {
+		m.screen = screenWelcome
 		m.input = ""
+		m.bandsFromValue = nil
 		m.err = nil
-	} else if strings.ToLower(key) == "q" {
-		m.quitting = true
-		return m, tea.Quit
+	} else if key == "backspace" || key == "delete" {
+		if len(m.input) > 0 {
+			m.input = trimLastRune(m.input)
+		}
+	} else if len(key) == 1 {
+		m.input += key
 	}
 	return m, nil
 }
 
-func (m model) handleBandCountInput(key string) (tea.Model, tea.Cmd) {
-	// Accept single key press without Enter
-	if key == "3" || key == "4" || key == "5" || key == "6" {
-		bandCount := 0
-		if key == "3" {
-			bandCount = 3
-		} else if key == "4" {
-			bandCount = 4
-		} else if key == "5" {
-			bandCount = 5
-		} else if key == "6" {
-			bandCount = 6
+// solveBandsFromValue finds the resistor bands for the typed value
+func (m model) solveBandsFromValue() model {
+	ohms, tolerance, err := decoder.ParseResistanceWithTolerance(m.input)
+	if err == nil {
+		var reading decoder.ResistorReading
+		reading, err = decoder.ResistorBandsFromValue(ohms, tolerance, m.reverseBandCount)
+		if err == nil && !m.palette.Extended && slices.Contains(reading.Colors(), decoder.ColorPink) {
+			err = fmt.Errorf("%s needs the Pink multiplier; start with -extended-colors to use it",
+				decoder.FormatResistanceValue(ohms))
 		}
+		m.bandsFromValue = &reading
+	}
+	if err != nil {
+		m.err = err
+		m.bandsFromValue = nil
+		return m
+	}
+	m.err = nil
+	return m
+}
 
-		// Validate band count based on component type
-		if m.componentType == ComponentCapacitor {
-			if bandCount < 3 || bandCount > 5 {
-				m.err = fmt.Errorf("invalid band count for capacitor: press 3, 4, or 5")
-				return m, nil
-			}
-			m.capacitorReading.BandCount = bandCount
-		} else if m.componentType == ComponentResistor {
-			if bandCount < 4 || bandCount > 6 {
-				m.err = fmt.Errorf("invalid band count for resistor: press 4, 5, or 6")
-				return m, nil
+// maxMarkingsShown is how many of the markings of a value the resistor bands
+// screen lists before summarizing the rest
+const maxMarkingsShown = 12
+
+// renderAllMarkings lists every band combination that gives the solved value,
+// for matching parts in a bin whose tolerance or temperature bands differ
+func renderAllMarkings(readings []decoder.ResistorReading, palette decoder.Palette) string {
+	if len(readings) < 2 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString(labelStyle.Render(fmt.Sprintf("All %d markings of this value:", len(readings))))
+	b.WriteString("\n")
+	for _, reading := range readings[:min(len(readings), maxMarkingsShown)] {
+		b.WriteString("  ")
+		for i, color := range reading.Colors() {
+			if i > 0 {
+				b.WriteString(" ")
 			}
-			m.resistorReading.BandCount = bandCount
+			b.WriteString(renderSwatch(color, palette.ColorName(color)))
 		}
+		b.WriteString("\n")
+	}
+	if more := len(readings) - maxMarkingsShown; more > 0 {
+		b.WriteString(mutedStyle.Render(fmt.Sprintf("  … and %d more", more)))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	return b.String()
+}
 
-		m.screen = screenBandInput
-		m.currentBand = 1
+func (m model) handleReverseCapacitorInput(key string) (tea.Model, tea.Cmd) {
+	if m.keys.Matches(key, ActionSubmit) && m.input != "" {
+		m = m.solveCapacitorBandsFromValue()
+	} else if key == "tab" {
+		// Cycle the band count and re-solve the current value
+		m.reverseBandCount++
+		if m.reverseBandCount > 5 {
+			m.reverseBandCount = 3
+		}
+		if m.input != "" {
+			m = m.solveCapacitorBandsFromValue()
+		}
+	} else if m.keys.Matches(key, ActionCancel) {
+		m.screen = screenWelcome
 		m.input = ""
+		m.capBandsFromValue = nil
 		m.err = nil
-	} else if strings.ToLower(key) == "q" {
-		m.quitting = true
-		return m, tea.Quit
+	} else if key == "backspace" || key == "delete" {
+		if len(m.input) > 0 {
+			m.input = trimLastRune(m.input)
+		}
+	} else if len(key) == 1 || key == "µ" {
+		m.input += key
 	}
 	return m, nil
 }
 
-func (m model) handleBandInputInput(key string) (tea.Model, tea.Cmd) {
-	if key == "tab" {
-		// Accept autocomplete suggestion
-		if m.suggestion != "" {
-			m.input = GetFullColorFromInput(m.input, m.suggestion)
-			m.suggestion = "" // Clear suggestion after accepting
-		}
-		return m, nil
-	} else if key == "enter" && m.input != "" {
-		color, valid := ParseColor(m.input)
-		if !valid {
-			m.err = fmt.Errorf("invalid color: '%s' - please enter a valid color name", m.input)
-			return m, nil
-		}
-
-		// Validate and store based on component type
-		var validationErr error
-		var bandCount int
-
-		if m.componentType == ComponentCapacitor {
-			bandCount = m.capacitorReading.BandCount
-			// Validate based on current band
-			switch m.currentBand {
-			case 1:
-				validationErr = ValidateBand1(color)
-				if validationErr == nil {
-					m.capacitorReading.Band1 = color
-				}
-			case 2:
-				validationErr = ValidateBand2(color)
-				if validationErr == nil {
-					m.capacitorReading.Band2 = color
-				}
-			case 3:
-				validationErr = ValidateBand3(color)
-				if validationErr == nil {
-					m.capacitorReading.Band3 = color
-				}
-			case 4:
-				// Calculate capacitance for validation
-				info1 := GetColorInfo(m.capacitorReading.Band1)
-				info2 := GetColorInfo(m.capacitorReading.Band2)
-				info3 := GetColorInfo(m.capacitorReading.Band3)
-				capacitancePF := float64(info1.Digit*10+info2.Digit) * info3.Multiplier
-
-				validationErr = ValidateBand4(color, capacitancePF)
-				if validationErr == nil {
-					m.capacitorReading.Band4 = color
-				}
-			case 5:
-				validationErr = ValidateBand5(color, m.capacitorReading.CapType, m.capacitorReading.BandCount)
-				if validationErr == nil {
-					m.capacitorReading.Band5 = color
-				}
-			}
-		} else if m.componentType == ComponentResistor {
-			bandCount = m.resistorReading.BandCount
-			// Validate resistor bands
-			switch m.currentBand {
-			case 1:
-				validationErr = ValidateResistorBand1(color)
-				if validationErr == nil {
-					m.resistorReading.Band1 = color
-				}
-			case 2:
-				validationErr = ValidateResistorBand2(color)
-				if validationErr == nil {
-					m.resistorReading.Band2 = color
-				}
-			case 3:
-				// For 4-band resistors, band 3 is the multiplier
-				// For 5/6-band resistors, band 3 is the third digit
-				if bandCount == 4 {
-					validationErr = ValidateResistorMultiplier(color, 3)
-					if validationErr == nil {
-						m.resistorReading.Band3 = color
-					}
-				} else {
-					validationErr = ValidateResistorBand3(color)
-					if validationErr == nil {
-						m.resistorReading.Band3 = color
-					}
-				}
-			case 4:
-				// For 4-band resistors, band 4 is tolerance
-				// For 5/6-band resistors, band 4 is multiplier
-				if bandCount == 4 {
-					validationErr = ValidateResistorTolerance(color, 4)
-					if validationErr == nil {
-						m.resistorReading.Band4 = color
-					}
-				} else {
-					validationErr = ValidateResistorMultiplier(color, 4)
-					if validationErr == nil {
-						m.resistorReading.Band4 = color
-					}
-				}
-			case 5:
-				validationErr = ValidateResistorTolerance(color, 5)
-				if validationErr == nil {
-					m.resistorReading.Band5 = color
-				}
-			case 6:
-				validationErr = ValidateResistorTempCoeff(color)
-				if validationErr == nil {
-					m.resistorReading.Band6 = color
-				}
-			}
-		}
+// solveCapacitorBandsFromValue finds the capacitor bands for the typed value
+func (m model) solveCapacitorBandsFromValue() model {
+	pF, capType, err := decoder.ParseCapacitanceWithType(m.input)
+	if err == nil {
+		var reading decoder.CapacitorReading
+		reading, err = decoder.CapacitorBandsFromValue(pF, capType, m.reverseBandCount)
+		m.capBandsFromValue = &reading
+	}
+	if err != nil {
+		m.err = err
+		m.capBandsFromValue = nil
+		return m
+	}
+	m.err = nil
+	return m
+}
 
-		if validationErr != nil {
-			m.err = validationErr
+func (m model) handleSMDInput(key string) (tea.Model, tea.Cmd) {
+	if m.keys.Matches(key, ActionSubmit) && m.input != "" {
+		result, err := decoder.DecodeSMDResistor(m.input)
+		if err != nil {
+			m.err = err
+			m.smdResult = nil
 			return m, nil
 		}
-
-		// Move to next band or review screen
-		if m.currentBand < bandCount {
-			m.currentBand++
-			m.input = ""
-			m.suggestion = "" // Clear suggestion
-			m.err = nil
-		} else {
-			// All bands entered, go to review
-			m.screen = screenReview
-			m.input = ""
-			m.suggestion = "" // Clear suggestion
-			m.err = nil
-		}
+		m.smdCode = strings.ToUpper(strings.TrimSpace(m.input))
+		m.smdResult = result
+		m.input = ""
+		m.err = nil
+	} else if m.keys.Matches(key, ActionCancel) {
+		m.screen = screenComponentSelection
+		m.input = ""
+		m.smdResult = nil
+		m.err = nil
 	} else if key == "backspace" || key == "delete" {
 		if len(m.input) > 0 {
-			m.input = m.input[:len(m.input)-1]
-			// Update suggestion after deleting character
-			m.suggestion = GetColorSuggestion(m.input, m.currentBand)
+			m.input = trimLastRune(m.input)
 		}
 	} else if len(key) == 1 {
 		m.input += key
-		// Update suggestion after adding character
-		m.suggestion = GetColorSuggestion(m.input, m.currentBand)
 	}
+
 	return m, nil
 }
 
-func (m model) handleReviewInput(key string) (tea.Model, tea.Cmd) {
-	lowerKey := strings.ToLower(key)
-
-	if lowerKey == "enter" || lowerKey == " " {
-		// Calculate and show results based on component type
-		if m.componentType == ComponentCapacitor {
-			result, err := Calculate(m.capacitorReading)
-			if err != nil {
-				m.err = err
-				return m, nil
-			}
-			m.capacitorResult = result
-			m.resistorResult = nil
-		} else if m.componentType == ComponentResistor {
-			result, err := CalculateResistor(m.resistorReading)
-			if err != nil {
-				m.err = err
-				return m, nil
-			}
-			m.resistorResult = result
-			m.capacitorResult = nil
+func (m model) handleCapacitanceLookupInput(key string) (tea.Model, tea.Cmd) {
+	if m.keys.Matches(key, ActionSubmit) && m.input != "" {
+		pF, err := decoder.ParseCapacitance(m.input)
+		if err == nil {
+			var lookup decoder.CapacitanceLookup
+			lookup, err = decoder.LookupCapacitance(pF)
+			m.lookup = &lookup
+		}
+		if err != nil {
+			m.err = fmt.Errorf("invalid capacitance: %v", err)
+			m.lookup = nil
+			return m, nil
 		}
-		m.screen = screenResults
 		m.err = nil
-	} else if lowerKey == "c" {
-		// Go to edit mode
-		m.screen = screenEdit
+	} else if m.keys.Matches(key, ActionCancel) {
+		m.screen = screenWelcome
 		m.input = ""
+		m.lookup = nil
 		m.err = nil
-	} else if lowerKey == "q" {
-		m.quitting = true
-		return m, tea.Quit
+	} else if key == "backspace" || key == "delete" {
+		if len(m.input) > 0 {
+			m.input = trimLastRune(m.input)
+		}
+	} else if len(key) == 1 || key == "µ" {
+		m.input += key
 	}
 
 	return m, nil
 }
 
-func (m model) handleResultsInput(key string) (tea.Model, tea.Cmd) {
-	lowerKey := strings.ToLower(key)
+func (m model) handleReferenceInput(key string) (tea.Model, tea.Cmd) {
+	maxOffset := len(ReferenceChartLines(m.palette)) - m.referenceVisibleLines()
+	if maxOffset < 0 {
+		maxOffset = 0
+	}
 
-	if lowerKey == "d" {
-		// Decode another - reset to component selection
-		m.screen = screenComponentSelection
-		m.input = ""
-		m.currentBand = 1
-		m.err = nil
-		m.successMsg = ""
-		m.capacitorReading = CapacitorReading{BandCount: 5}
-		m.resistorReading = ResistorReading{BandCount: 4}
-		m.capacitorResult = nil
-		m.resistorResult = nil
-		m.currentNote = ""
-	} else if lowerKey == "e" {
-		// Edit current - go to edit mode
-		m.screen = screenEdit
-		m.input = ""
-		m.err = nil
-		m.successMsg = ""
-	} else if lowerKey == "n" {
-		// Add/Edit note - save to history first if not already saved
-		if m.currentNote == "" && len(m.history) == 0 ||
-			(len(m.history) > 0 && m.history[len(m.history)-1].Note != m.currentNote) {
-			// Add current result to history
-			entry := ComponentEntry{
-				ComponentType:   m.componentType,
-				CapacitorResult: m.capacitorResult,
-				ResistorResult:  m.resistorResult,
-				Note:            m.currentNote,
-			}
-			m.history = append(m.history, entry)
+	switch {
+	case m.keys.Matches(key, ActionScrollUp):
+		if m.scrollOffset > 0 {
+			m.scrollOffset--
 		}
-		m.screen = screenNoteInput
-		m.input = m.currentNote // Pre-fill with existing note
-		m.err = nil
-		m.successMsg = ""
-	} else if lowerKey == "x" {
-		// Add current result to history if not already there
-		if len(m.history) == 0 ||
-			(len(m.history) > 0 && (m.history[len(m.history)-1].CapacitorResult != m.capacitorResult ||
-				m.history[len(m.history)-1].ResistorResult != m.resistorResult)) {
-			entry := ComponentEntry{
-				ComponentType:   m.componentType,
-				CapacitorResult: m.capacitorResult,
-				ResistorResult:  m.resistorResult,
-				Note:            m.currentNote,
-			}
-			m.history = append(m.history, entry)
+	case m.keys.Matches(key, ActionScrollDown):
+		if m.scrollOffset < maxOffset {
+			m.scrollOffset++
 		}
-
-		// Check if there's data to export
-		if len(m.history) == 0 {
-			m.err = fmt.Errorf("no data to export (decode at least one component first)")
+	case m.keys.Matches(key, ActionPageUp):
+		m.scrollOffset -= m.referenceVisibleLines()
+		if m.scrollOffset < 0 {
+			m.scrollOffset = 0
+		}
+	case m.keys.Matches(key, ActionPageDown):
+		m.scrollOffset += m.referenceVisibleLines()
+		if m.scrollOffset > maxOffset {
+			m.scrollOffset = maxOffset
+		}
+	case m.keys.Matches(key, ActionExport):
+		// Save a printable HTML chart next to CSV exports
+		path := filepath.Join(m.filepicker.CurrentDirectory, referenceChartFile)
+		if err := ExportReferenceChart(ReferenceHTML, path, m.palette); err != nil {
+			m.err = fmt.Errorf("chart export failed: %v", err)
 			m.successMsg = ""
 		} else {
-			// Navigate to file picker
-			m.screen = screenFilePicker
 			m.err = nil
-			m.successMsg = ""
+			m.successMsg = "✓ Saved reference chart to " + path
 		}
-	} else if lowerKey == "q" {
-		m.quitting = true
-		return m, tea.Quit
+	case m.keys.Matches(key, ActionQuit), m.keys.Matches(key, ActionCancel):
+		m.screen = screenWelcome
+		m.scrollOffset = 0
+		m.err = nil
+		m.successMsg = ""
 	}
-
 	return m, nil
 }
 
-func (m model) handleEditInput(key string) (tea.Model, tea.Cmd) {
-	// Accept single key press without Enter
-	var maxBand int
-	if m.componentType == ComponentCapacitor {
-		maxBand = m.capacitorReading.BandCount
-	} else {
-		maxBand = m.resistorReading.BandCount
+func (m model) handleHistoryInput(key string) (tea.Model, tea.Cmd) {
+	maxOffset := len(m.history) - m.historyVisibleLines()
+	if maxOffset < 0 {
+		maxOffset = 0
 	}
 
-	// Parse band number to edit
-	if key == "1" {
-		m.editBandIndex = 1
-		m.currentBand = 1
-		m.screen = screenBandInput
-		m.input = ""
-		m.err = nil
-	} else if key == "2" {
-		m.editBandIndex = 2
-		m.currentBand = 2
-		m.screen = screenBandInput
-		m.input = ""
-		m.err = nil
-	} else if key == "3" {
-		m.editBandIndex = 3
-		m.currentBand = 3
-		m.screen = screenBandInput
-		m.input = ""
-		m.err = nil
-	} else if key == "4" {
-		m.editBandIndex = 4
-		m.currentBand = 4
-		m.screen = screenBandInput
-		m.input = ""
-		m.err = nil
-	} else if key == "5" && maxBand >= 5 {
-		m.editBandIndex = 5
-		m.currentBand = 5
-		m.screen = screenBandInput
-		m.input = ""
-		m.err = nil
-	} else if key == "6" && maxBand >= 6 {
-		m.editBandIndex = 6
-		m.currentBand = 6
-		m.screen = screenBandInput
-		m.input = ""
+	visible := m.historyVisibleLines()
+	switch {
+	case m.keys.Matches(key, ActionScrollUp):
+		if m.historyCursor > 0 {
+			m.historyCursor--
+		}
+	case m.keys.Matches(key, ActionScrollDown):
+		if m.historyCursor < len(m.history)-1 {
+			m.historyCursor++
+		}
+	case m.keys.Matches(key, ActionPageUp):
+		m.scrollOffset = max(m.scrollOffset-visible, 0)
+		m.historyCursor = max(m.historyCursor-visible, 0)
+	case m.keys.Matches(key, ActionPageDown):
+		m.scrollOffset = min(m.scrollOffset+visible, maxOffset)
+		m.historyCursor = max(min(m.historyCursor+visible, len(m.history)-1), 0)
+	case m.keys.Matches(key, ActionEdit) && len(m.history) > 0:
+		return m.redecodeEntry(m.historyCursor)
+	case m.keys.Matches(key, ActionSummary):
+		m.historySummary = !m.historySummary
+	case m.keys.Matches(key, ActionPin) && len(m.history) > 0:
+		var pinned bool
+		m.favorites, pinned = toggleFavorite(m.favorites, m.history[m.historyCursor])
+		m.successMsg = "✓ Unpinned from favorites"
+		if pinned {
+			m.successMsg = "✓ Pinned to favorites"
+		}
 		m.err = nil
-	} else if strings.ToLower(key) == "q" {
-		// Cancel edit, go back to review
-		m.screen = screenReview
-		m.input = ""
+		return m.saveFavorites()
+	case m.keys.Matches(key, ActionQuit), m.keys.Matches(key, ActionCancel), m.keys.Matches(key, ActionHistory):
+		m.screen = screenResults
+		m.scrollOffset = 0
+		m.historySummary = false
+		m.redecodeIndex = -1
 		m.err = nil
+		m.successMsg = ""
+		return m, nil
 	}
 
+	// Keep the selected entry in view
+	if m.historyCursor < m.scrollOffset {
+		m.scrollOffset = m.historyCursor
+	} else if m.historyCursor >= m.scrollOffset+visible {
+		m.scrollOffset = m.historyCursor - visible + 1
+	}
 	return m, nil
 }
 
-func (m model) handleNoteInputInput(key string) (tea.Model, tea.Cmd) {
-	if key == "enter" {
-		// Save note and update/add to history
-		m.currentNote = m.input
+// redecodeEntry enters the bands of a history entry again, each prefilled
+// with its old color, so the result replaces the entry in place
+func (m model) redecodeEntry(index int) (tea.Model, tea.Cmd) {
+	entry := m.history[index]
+	switch {
+	case entry.ComponentType == decoder.ComponentCapacitor && entry.CapacitorResult != nil:
+		m.capacitorReading = entry.CapacitorResult.Reading
+	case entry.ComponentType == decoder.ComponentResistor && entry.ResistorResult != nil &&
+		decoder.ValidateResistorBandCount(entry.ResistorResult.Reading.BandCount) == nil:
+		m.resistorReading = entry.ResistorResult.Reading
+	default:
+		// e.g. a decoded SMD code
+		m.err = fmt.Errorf("entry %d has no bands to edit", index+1)
+		return m, nil
+	}
 
-		// Check if we need to update existing entry or add new one
-		if len(m.history) > 0 {
-			// Update last entry's note if it matches current result
-			lastEntry := &m.history[len(m.history)-1]
-			if lastEntry.CapacitorResult == m.capacitorResult &&
-				lastEntry.ResistorResult == m.resistorResult {
-				lastEntry.Note = m.currentNote
-			} else {
-				// Add new entry
-				entry := ComponentEntry{
-					ComponentType:   m.componentType,
-					CapacitorResult: m.capacitorResult,
-					ResistorResult:  m.resistorResult,
-					Note:            m.currentNote,
-				}
-				m.history = append(m.history, entry)
-			}
-		} else {
-			// Add first entry
-			entry := ComponentEntry{
-				ComponentType:   m.componentType,
-				CapacitorResult: m.capacitorResult,
-				ResistorResult:  m.resistorResult,
-				Note:            m.currentNote,
-			}
-			m.history = append(m.history, entry)
+	m.componentType = entry.ComponentType
+	m.currentNote = entry.Note
+	m.currentPackage = entry.Package
+	m.currentQuantity = entry.Quantity
+	m.currentMeasured = entry.MeasuredValue
+	m.hasMeasurement = entry.Measured
+	m.operatingPoint = ""
+	m.specTarget = ""
+	m.reversed = false
+	m.autoBandCount = false
+	m.confirmBandCount = false
+	m.editBandIndex = 0
+	m.redecodeIndex = index
+	m.backStack = []screenType{screenHistory}
+	m.screen = screenBandInput
+	m.currentBand = 1
+	m = m.prefillBand()
+	m.err = nil
+	m.successMsg = ""
+	return m, nil
+}
+
+// prefillBand fills the input with the current band's old color while a
+// history entry is re-decoded; Enter keeps it and typing replaces it
+func (m model) prefillBand() model {
+	if m.redecodeIndex < 0 {
+		return m
+	}
+	colors := m.resistorReading.Colors()
+	if m.componentType == decoder.ComponentCapacitor {
+		colors = m.capacitorReading.Colors()
+	}
+	if m.currentBand >= 1 && m.currentBand <= len(colors) {
+		m.input = m.palette.ColorName(colors[m.currentBand-1])
+		m.replaceOnType = true
+		m.suggestion = ""
+	}
+	return m
+}
+
+func (m model) handleHelpInput(key string) (tea.Model, tea.Cmd) {
+	lines := len(m.helpLines())
+	maxOffset := lines - m.helpVisibleLines()
+	if maxOffset < 0 {
+		maxOffset = 0
+	}
+
+	switch {
+	case m.keys.Matches(key, ActionHelp), m.keys.Matches(key, ActionCancel), m.keys.Matches(key, ActionQuit):
+		m.screen = m.helpReturn
+		m.helpOffset = 0
+	case m.keys.Matches(key, ActionScrollUp):
+		if m.helpOffset > 0 {
+			m.helpOffset--
+		}
+	case m.keys.Matches(key, ActionScrollDown):
+		if m.helpOffset < maxOffset {
+			m.helpOffset++
+		}
+	case m.keys.Matches(key, ActionPageUp):
+		m.helpOffset -= m.helpVisibleLines()
+		if m.helpOffset < 0 {
+			m.helpOffset = 0
 		}
+	case m.keys.Matches(key, ActionPageDown):
+		m.helpOffset += m.helpVisibleLines()
+		if m.helpOffset > maxOffset {
+			m.helpOffset = maxOffset
+		}
+	}
+	return m, nil
+}
 
-		// Go back to results screen
+// helpVisibleLines returns how many help lines fit in the terminal
+func (m model) helpVisibleLines() int {
+	const chrome = 8 // header, blank lines, config hint and help text
+	if m.height <= chrome {
+		return len(m.helpLines())
+	}
+	return m.height - chrome
+}
+
+func (m model) handleCombineInput(key string) (tea.Model, tea.Cmd) {
+	resistors := m.combineEntries()
+
+	switch {
+	case m.keys.Matches(key, ActionScrollUp):
+		if m.combineCursor > 0 {
+			m.combineCursor--
+		}
+	case m.keys.Matches(key, ActionScrollDown):
+		if m.combineCursor < len(resistors)-1 {
+			m.combineCursor++
+		}
+	case m.keys.Matches(key, ActionSubmit) && len(resistors) > 0:
+		// Pick or drop the highlighted resistor
+		index := resistors[m.combineCursor]
+		if m.combineSelected[index] {
+			delete(m.combineSelected, index)
+		} else {
+			m.combineSelected[index] = true
+		}
+	case m.keys.Matches(key, ActionQuit), m.keys.Matches(key, ActionCancel), m.keys.Matches(key, ActionCombine):
 		m.screen = screenResults
-		m.input = ""
-		m.err = nil
-	} else if key == "esc" {
-		// Cancel note input, go back to results
+		m.combineSelected = nil
+	}
+	return m, nil
+}
+
+func (m model) handleDividerInput(key string) (tea.Model, tea.Cmd) {
+	if m.keys.Matches(key, ActionSubmit) {
+		// The result is shown as soon as the input is complete; Enter
+		// explains what is missing
+		_, _, _, err := ParseDividerInput(m.input, m.dividerResistances())
+		m.err = err
+	} else if m.keys.Matches(key, ActionCancel) {
 		m.screen = screenResults
 		m.input = ""
 		m.err = nil
 	} else if key == "backspace" || key == "delete" {
 		if len(m.input) > 0 {
-			m.input = m.input[:len(m.input)-1]
+			m.input = trimLastRune(m.input)
 		}
+		m.err = nil
 	} else if len(key) == 1 {
-		// Limit note to 200 characters
-		if len(m.input) < 200 {
-			m.input += key
-		} else {
-			m.err = fmt.Errorf("note too long (max 200 characters)")
+		m.input += key
+		m.err = nil
+	}
+	return m, nil
+}
+
+// dividerResistances returns the resistances in Ω of the resistors in
+// history, in the order the divider screen numbers them
+func (m model) dividerResistances() []float64 {
+	var ohms []float64
+	for _, index := range m.historyIndexes(decoder.ComponentResistor) {
+		ohms = append(ohms, m.history[index].ResistorResult.ResistanceOhms)
+	}
+	return ohms
+}
+
+// combineEntries returns the history indexes of the decoded parts of the
+// current component type, the entries the combine screen lists
+func (m model) combineEntries() []int {
+	return m.historyIndexes(m.componentType)
+}
+
+// historyIndexes returns the history indexes of the decoded parts of a
+// component type
+func (m model) historyIndexes(componentType decoder.ComponentType) []int {
+	var indexes []int
+	for i, entry := range m.history {
+		switch {
+		case componentType == decoder.ComponentResistor && entry.ComponentType == decoder.ComponentResistor && entry.ResistorResult != nil,
+			componentType == decoder.ComponentCapacitor && entry.ComponentType == decoder.ComponentCapacitor && entry.CapacitorResult != nil:
+			indexes = append(indexes, i)
 		}
 	}
+	return indexes
+}
 
-	return m, nil
+// historyVisibleLines returns how many history entries fit in the terminal
+func (m model) historyVisibleLines() int {
+	const chrome = 7 // header, blank lines, status and help text
+	if m.height <= chrome {
+		return len(m.history)
+	}
+	return m.height - chrome
 }
 
-func (m model) View() string {
-	if m.quitting {
-		return successStyle.Render("\n✓ Thanks for using Tropical Fish Decoder!\n\n")
+// renderExportPreview renders the CSV header and a scrollable window of the
+// rows an export is about to write
+func (m model) renderExportPreview() string {
+	var b strings.Builder
+
+	rows := m.exportPreview[1:]
+	verb := "Write"
+	if m.exportPreviewAdd {
+		verb = "Append"
 	}
+	b.WriteString(valueStyle.Render(fmt.Sprintf("%s %d row%s to %s:", verb, len(rows),
+		map[bool]string{true: "", false: "s"}[len(rows) == 1], filepath.Base(m.exportPreviewPath))))
+	b.WriteString("\n\n")
 
-	switch m.screen {
-	case screenWelcome:
-		return m.renderWelcome()
-	case screenComponentSelection:
-		return m.renderComponentSelection()
-	case screenTypeSelection:
-		return m.renderTypeSelection()
-	case screenBandCountSelection:
-		return m.renderBandCountSelection()
-	case screenBandInput:
-		return m.renderBandInput()
-	case screenReview:
-		return m.renderReview()
-	case screenResults:
-		return m.renderResults()
-	case screenNoteInput:
-		return m.renderNoteInput()
-	case screenEdit:
+	start := min(m.scrollOffset, len(rows))
+	end := min(start+m.exportPreviewVisibleLines(), len(rows))
+	b.WriteString(labelStyle.Render(csvLine(m.exportPreview[0])))
+	b.WriteString("\n")
+	for _, row := range rows[start:end] {
+		b.WriteString(csvLine(row))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	if end-start < len(rows) {
+		b.WriteString(helpStyle.Render(fmt.Sprintf("Rows %d-%d of %d  |  ↑/↓: Scroll  |  ENTER: %s file  |  ESC: Choose another file  |  Ctrl+C: Quit",
+			start+1, end, len(rows), strings.ToLower(verb))))
+	} else {
+		b.WriteString(helpStyle.Render(fmt.Sprintf("ENTER: %s file  |  ESC: Choose another file  |  Ctrl+C: Quit", strings.ToLower(verb))))
+	}
+	b.WriteString("\n")
+
+	return b.String()
+}
+
+// referenceChartFile is the file name the reference screen saves its chart to
+const referenceChartFile = "tropical-fish-reference.html"
+
+// referenceVisibleLines returns how many chart lines fit in the terminal
+func (m model) referenceVisibleLines() int {
+	const chrome = 8 // header, blank lines, status and help text
+	if m.height <= chrome {
+		return len(ReferenceChartLines(m.palette))
+	}
+	return m.height - chrome
+}
+
+func (m model) handleComponentSelectionInput(key string) (tea.Model, tea.Cmd) {
+	// Accept single key press without Enter, or Enter for the last used type
+	submit := m.keys.Matches(key, ActionSubmit)
+	if m.keys.Matches(key, ActionCapacitor) || submit && m.componentType == decoder.ComponentCapacitor {
+		m.componentType = decoder.ComponentCapacitor
+		m = m.pushScreen(screenTypeSelection)
+		m.input = ""
+		m.err = nil
+	} else if m.keys.Matches(key, ActionResistor) || submit && m.componentType == decoder.ComponentResistor {
+		m.componentType = decoder.ComponentResistor
+		m = m.pushScreen(screenBandCountSelection)
+		m.input = ""
+		m.err = nil
+	} else if m.keys.Matches(key, ActionSMD) {
+		m.screen = screenSMDInput
+		m.input = ""
+		m.smdCode = ""
+		m.smdResult = nil
+		m.err = nil
+	} else if m.keys.Matches(key, ActionQuit) {
+		m.quitting = true
+		return m, tea.Quit
+	}
+
+	return m, nil
+}
+
+func (m model) handleTypeSelectionInput(key string) (tea.Model, tea.Cmd) {
+	if key == "tab" {
+		// Accept autocomplete suggestion
+		if m.suggestion != "" {
+			m.input = GetFullColorFromInput(m.input, m.suggestion)
+			m.suggestion = ""
+		}
+	} else if m.keys.Matches(key, ActionSubmit) && m.input != "" {
+		// Accept the type letter or a (partial) type name
+		capType, valid := decoder.ParseCapacitorType(m.input)
+		if !valid {
+			m.err = fmt.Errorf("unknown or ambiguous capacitor type: '%s'", m.input)
+			return m, nil
+		}
+		m.capacitorReading.CapType = capType
+		m.capacitorReading.BandCount = decoder.CapacitorBandCount(capType, m.capacitorBands)
+		m = m.pushScreen(screenBandCountSelection)
+		m.input = ""
+		m.suggestion = ""
+		m.err = nil
+	} else if key == "backspace" || key == "delete" {
+		if len(m.input) > 0 {
+			m.input = trimLastRune(m.input)
+			m.suggestion = GetCapacitorTypeSuggestion(m.input)
+		}
+	} else if m.keys.Matches(key, ActionQuit) && m.input == "" {
+		m.quitting = true
+		return m, tea.Quit
+	} else if m.keys.Matches(key, ActionVoltageTable) && m.input == "" {
+		// No type name starts with v, so it only opens the table
+		m.voltageTableType = m.capacitorReading.CapType
+		if m.voltageTableType == "" {
+			m.voltageTableType = decoder.TypeJ
+		}
+		m.screen = screenVoltageTable
+		m.err = nil
+	} else if len(key) == 1 {
+		m.input += key
+		m.suggestion = GetCapacitorTypeSuggestion(m.input)
+	}
+	return m, nil
+}
+
+// handleVoltageTableInput switches the type shown with its letter and takes
+// a voltage to search for; Enter decodes with the type shown
+func (m model) handleVoltageTableInput(key string) (tea.Model, tea.Cmd) {
+	if m.keys.Matches(key, ActionQuit) || m.keys.Matches(key, ActionCancel) {
+		m.screen = screenTypeSelection
+		m.input = ""
+		m.suggestion = ""
+	} else if m.keys.Matches(key, ActionSubmit) {
+		m.capacitorReading.CapType = m.voltageTableType
+		m.capacitorReading.BandCount = decoder.CapacitorBandCount(m.voltageTableType, m.capacitorBands)
+		m.screen = screenTypeSelection
+		m = m.pushScreen(screenBandCountSelection)
+		m.input = ""
+		m.suggestion = ""
+	} else if key == "backspace" || key == "delete" {
+		if len(m.input) > 0 {
+			m.input = trimLastRune(m.input)
+		}
+	} else if len(key) == 1 && strings.Contains("0123456789.", key) {
+		m.input += key
+	} else if slices.Contains(decoder.AllCapacitorTypes(), strings.ToUpper(key)) {
+		m.voltageTableType = decoder.CapacitorType(strings.ToUpper(key))
+	}
+	return m, nil
+}
+
+func (m model) handleBandCountInput(key string) (tea.Model, tea.Cmd) {
+	// Accept single key press without Enter, or Enter for the preselected count
+	if m.keys.Matches(key, ActionSubmit) {
+		m = m.pushScreen(screenBandInput)
+		m.autoBandCount = false
+		m.resistorReading.Military = false
+		m.currentBand = 1
+		m.input = ""
+		m.err = nil
+	} else if m.keys.Matches(key, ActionAutoBands) {
+		if m.componentType != decoder.ComponentResistor {
+			m.err = fmt.Errorf("auto band count is only available for resistors: press 3, 4, or 5")
+			return m, nil
+		}
+		m = m.pushScreen(screenBandInput)
+		m.autoBandCount = true
+		m.confirmBandCount = false
+		m.resistorReading.Military = false
+		m.currentBand = 1
+		m.input = ""
+		m.err = nil
+	} else if m.keys.Matches(key, ActionMilitary) {
+		if m.componentType != decoder.ComponentResistor {
+			m.err = fmt.Errorf("military bands are only available for resistors: press 3, 4, or 5")
+			return m, nil
+		}
+		m = m.pushScreen(screenBandInput)
+		m.autoBandCount = false
+		m.resistorReading.BandCount = 5
+		m.resistorReading.Military = true
+		m.currentBand = 1
+		m.input = ""
+		m.err = nil
+	} else if key == "3" || key == "4" || key == "5" || key == "6" {
+		bandCount := 0
+		if key == "3" {
+			bandCount = 3
+		} else if key == "4" {
+			bandCount = 4
+		} else if key == "5" {
+			bandCount = 5
+		} else if key == "6" {
+			bandCount = 6
+		}
+
+		// Validate band count based on component type
+		if m.componentType == decoder.ComponentCapacitor {
+			if bandCount < 3 || bandCount > 5 {
+				m.err = fmt.Errorf("invalid band count for capacitor: press 3, 4, or 5")
+				return m, nil
+			}
+			m.capacitorReading.BandCount = bandCount
+			if info, _ := decoder.GetTypeInfo(m.capacitorReading.CapType); info.DefaultBandCount == 0 {
+				m.capacitorBands = bandCount
+			}
+		} else if m.componentType == decoder.ComponentResistor {
+			if bandCount < 4 || bandCount > 6 {
+				m.err = fmt.Errorf("invalid band count for resistor: press 4, 5, or 6")
+				return m, nil
+			}
+			m.resistorReading.BandCount = bandCount
+			m.resistorReading.Military = false
+		}
+
+		m = m.pushScreen(screenBandInput)
+		m.autoBandCount = false
+		m.currentBand = 1
+		m.input = ""
+		m.err = nil
+	} else if m.keys.Matches(key, ActionQuit) {
+		m.quitting = true
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+func (m model) handleBandInputInput(key string) (tea.Model, tea.Cmd) {
+	if key == "tab" {
+		// Accept autocomplete suggestion
+		if m.suggestion != "" {
+			m.input = GetFullColorFromInput(m.input, m.suggestion)
+			m.suggestion = "" // Clear suggestion after accepting
+		}
+		return m, nil
+	} else if m.keys.Matches(key, ActionSubmit) && m.input != "" {
+		color, valid := m.palette.ParseColor(m.input)
+		if !valid {
+			m.err = fmt.Errorf("invalid color: '%s' - please enter a valid color name", m.input)
+			m.replaceOnType = true
+			return m, nil
+		}
+
+		// Validate and store based on component type
+		bandCount := m.resistorReading.BandCount
+		if m.componentType == decoder.ComponentCapacitor {
+			bandCount = m.capacitorReading.BandCount
+		} else if m.autoBandCount {
+			bandCount = 6
+		}
+		if err := m.validateBandColor(color); err != nil {
+			m.err = err
+			m.replaceOnType = true
+			return m, nil
+		}
+		m = m.setCurrentBand(color)
+		m.confirmBandCount = false
+
+		// Move to next band or review screen
+		if m.editBandIndex > 0 {
+			return m.finishBandEdit(), nil
+		} else if m.autoBandCount && m.currentBand == bandCount {
+			return m.inferBandCount(bandCount)
+		} else if m.currentBand < bandCount {
+			m.currentBand++
+			m.input = ""
+			m.suggestion = "" // Clear suggestion
+			m.err = nil
+			m = m.prefillBand()
+		} else {
+			// All bands entered, go to review
+			m = m.pushScreen(screenReview)
+			m.input = ""
+			m.suggestion = "" // Clear suggestion
+			m.err = nil
+		}
+	} else if m.keys.Matches(key, ActionSubmit) && m.autoBandCount && m.currentBand > 1 {
+		// An empty Enter ends the bands
+		return m.inferBandCount(m.currentBand - 1)
+	} else if m.keys.Matches(key, ActionUndo) {
+		// Step back a band and clear it; a no-op on band 1
+		if m.currentBand > 1 {
+			m.confirmBandCount = false
+			m.currentBand--
+			m = m.setCurrentBand(decoder.ColorBlack) // The zero value, as in a fresh reading
+			m.input = ""
+			m.suggestion = ""
+			m.replaceOnType = false
+			m.err = nil
+		}
+	} else if key == "backspace" || key == "delete" {
+		// Backspace keeps the rejected input for partial edits
+		m.replaceOnType = false
+		if len(m.input) > 0 {
+			m.input = trimLastRune(m.input)
+			// Update suggestion after deleting character
+			m.suggestion = GetColorSuggestion(m.input, m.currentBand, m.palette)
+		}
+	} else if utf8.RuneCountInString(key) == 1 {
+		m.confirmBandCount = false
+		// Overtype the rejected input after an error
+		if m.replaceOnType {
+			m.input = ""
+			m.replaceOnType = false
+		}
+		m.input += key
+		// Update suggestion after adding character
+		m.suggestion = GetColorSuggestion(m.input, m.currentBand, m.palette)
+	}
+	return m, nil
+}
+
+// pasteBands enters a pasted band list such as "Brown Black Red Gold" or
+// "brown, black, red, gold" from the current band on, as if each color were
+// typed and confirmed with Enter. Nothing is entered if the list doesn't
+// parse or has more bands than are left; a single word that isn't a color is
+// typed as is, so a partial name can still be completed.
+func (m model) pasteBands(text string) (tea.Model, tea.Cmd) {
+	colors, err := m.palette.ParseBandSequence(text)
+	if err != nil {
+		if fields := strings.Fields(strings.Trim(text, ", \t\r\n")); len(fields) == 1 {
+			for _, r := range fields[0] {
+				updated, _ := m.handleBandInputInput(string(r))
+				m = updated.(model)
+			}
+			return m, nil
+		}
+		m.err = fmt.Errorf("couldn't read the pasted bands: %v", err)
+		return m, nil
+	}
+
+	bandCount := m.resistorReading.BandCount
+	if m.componentType == decoder.ComponentCapacitor {
+		bandCount = m.capacitorReading.BandCount
+	} else if m.autoBandCount {
+		bandCount = 6
+	}
+	left := bandCount - m.currentBand + 1
+	if m.editBandIndex > 0 {
+		left = 1
+	}
+	if len(colors) > left {
+		m.err = fmt.Errorf("pasted %d bands, but only %d band%s left to enter", len(colors), left,
+			map[bool]string{true: "", false: "s"}[left == 1])
+		return m, nil
+	}
+
+	submit := m.keys[ActionSubmit][0]
+	var cmd tea.Cmd
+	for _, color := range colors {
+		m.input = decoder.GetColorInfo(color).Name
+		m.suggestion = ""
+		var updated tea.Model
+		updated, cmd = m.handleBandInputInput(submit)
+		m = updated.(model)
+		if m.err != nil || m.screen != screenBandInput {
+			break
+		}
+	}
+	return m, cmd
+}
+
+// finishBandEdit returns to review after a single band was changed from the
+// edit screen. The whole reading is checked again, as the other bands may
+// depend on the edited one: band 4 of a capacitor on the value of bands 1-3,
+// band 5 on the type.
+func (m model) finishBandEdit() model {
+	m.editBandIndex = 0
+	m = m.pushScreen(screenReview)
+	m.reviewProblems = m.readingProblems()
+	m.input = ""
+	m.suggestion = ""
+	m.err = nil
+	return m
+}
+
+// readingProblems validates every band of the reading being decoded
+func (m model) readingProblems() []*decoder.ValidationError {
+	if m.componentType == decoder.ComponentCapacitor {
+		return decoder.ReadingProblems(&m.capacitorReading)
+	}
+	return decoder.ResistorReadingProblems(&m.resistorReading)
+}
+
+// inferBandCount sets the band count of auto-counted resistor bands from the
+// first count bands entered and moves on to review. An ambiguous count is
+// only taken after a second empty Enter confirms it.
+func (m model) inferBandCount(count int) (tea.Model, tea.Cmd) {
+	reading := m.resistorReading
+	reading.BandCount = count
+	inferred, err := decoder.InferResistorBandCount(reading.Colors())
+	if err != nil && !(errors.Is(err, decoder.ErrAmbiguousBandCount) && m.confirmBandCount) {
+		if errors.Is(err, decoder.ErrAmbiguousBandCount) {
+			m.confirmBandCount = true
+			err = fmt.Errorf("%v; press Enter again to use %d bands", err, inferred)
+		}
+		m.currentBand = count + 1
+		m.input = ""
+		if count == 6 {
+			// No seventh band; keep the last one up for fixing
+			m.currentBand = count
+			m.input = m.palette.ColorName(m.resistorReading.Band6)
+			m.replaceOnType = true
+		}
+		m.suggestion = ""
+		m.err = err
+		return m, nil
+	}
+
+	m.resistorReading.BandCount = inferred
+	m.autoBandCount = false
+	m.confirmBandCount = false
+	m.currentBand = inferred
+	m = m.pushScreen(screenReview)
+	m.input = ""
+	m.suggestion = ""
+	m.err = nil
+	return m, nil
+}
+
+// validateBandColor checks a color against the role of the band being entered
+func (m model) validateBandColor(color decoder.Color) error {
+	if m.componentType == decoder.ComponentCapacitor {
+		switch m.currentBand {
+		case 1:
+			return decoder.ValidateBand1(color)
+		case 2:
+			return decoder.ValidateBand2(color)
+		case 3:
+			return decoder.ValidateBand3(color)
+		case 4:
+			// Calculate capacitance for validation
+			info1 := decoder.GetColorInfo(m.capacitorReading.Band1)
+			info2 := decoder.GetColorInfo(m.capacitorReading.Band2)
+			info3 := decoder.GetColorInfo(m.capacitorReading.Band3)
+			capacitancePF := float64(info1.Digit*10+info2.Digit) * info3.Multiplier
+			return decoder.ValidateBand4(color, capacitancePF)
+		case 5:
+			return decoder.ValidateBand5(color, m.capacitorReading.CapType, m.capacitorReading.BandCount)
+		}
+		return nil
+	}
+
+	// Auto-counted bands past the digits are checked once the count is known
+	if m.autoBandCount && m.currentBand > 2 {
+		return nil
+	}
+
+	bandCount := m.resistorReading.BandCount
+	if m.resistorReading.Military {
+		// A 4-band layout followed by the reliability band
+		if m.currentBand == 5 {
+			return decoder.ValidateResistorReliability(color)
+		}
+		bandCount = 4
+	}
+	switch m.currentBand {
+	case 1:
+		return decoder.ValidateResistorBand1(color)
+	case 2:
+		return decoder.ValidateResistorBand2(color)
+	case 3:
+		// For 4-band resistors, band 3 is the multiplier
+		// For 5/6-band resistors, band 3 is the third digit
+		if bandCount == 4 {
+			return decoder.ValidateResistorMultiplier(color, 3)
+		}
+		return decoder.ValidateResistorBand3(color)
+	case 4:
+		// For 4-band resistors, band 4 is tolerance
+		// For 5/6-band resistors, band 4 is multiplier
+		if bandCount == 4 {
+			return decoder.ValidateResistorTolerance(color, 4)
+		}
+		return decoder.ValidateResistorMultiplier(color, 4)
+	case 5:
+		return decoder.ValidateResistorTolerance(color, 5)
+	case 6:
+		return decoder.ValidateResistorTempCoeff(color)
+	}
+	return nil
+}
+
+// setCurrentBand stores a color in the band being entered
+func (m model) setCurrentBand(color decoder.Color) model {
+	if m.componentType == decoder.ComponentCapacitor {
+		bands := []*decoder.Color{&m.capacitorReading.Band1, &m.capacitorReading.Band2, &m.capacitorReading.Band3,
+			&m.capacitorReading.Band4, &m.capacitorReading.Band5}
+		if m.currentBand >= 1 && m.currentBand <= len(bands) {
+			*bands[m.currentBand-1] = color
+		}
+		return m
+	}
+	bands := []*decoder.Color{&m.resistorReading.Band1, &m.resistorReading.Band2, &m.resistorReading.Band3,
+		&m.resistorReading.Band4, &m.resistorReading.Band5, &m.resistorReading.Band6}
+	if m.currentBand >= 1 && m.currentBand <= len(bands) {
+		*bands[m.currentBand-1] = color
+	}
+	return m
+}
+
+// inputIndicator returns a check or cross once the typed text is a complete
+// color name, showing whether it fits the current band, or "" while the
+// text is empty or still a prefix
+func (m model) inputIndicator() string {
+	color, ok := m.palette.ParseColor(m.input)
+	if !ok {
+		return ""
+	}
+	if err := m.validateBandColor(color); err != nil {
+		return errorStyle.Render(" ✗")
+	}
+	// Spell out what an abbreviation such as "bn" was read as
+	if _, abbrev := m.palette.ParseColorAbbrev(m.input); abbrev && m.suggestion == "" {
+		return successStyle.Render(" ✓") + mutedStyle.Render(" "+m.palette.ColorNames()[color])
+	}
+	return successStyle.Render(" ✓")
+}
+
+func (m model) handleReviewInput(key string) (tea.Model, tea.Cmd) {
+	if m.keys.Matches(key, ActionContinue) {
+		// Recheck the whole reading and list every problem before calculating
+		m.reviewProblems = m.readingProblems()
+		m.editBandIndex = 0
+		if len(m.reviewProblems) > 0 {
+			m.err = nil
+			return m, nil
+		}
+
+		// Calculate and show results based on component type
+		if m.componentType == decoder.ComponentCapacitor {
+			result, err := decoder.Calculate(m.capacitorReading)
+			if err != nil {
+				m.err = err
+				return m, nil
+			}
+			m.capacitorResult = result
+			m.resistorResult = nil
+		} else if m.componentType == decoder.ComponentResistor {
+			result, err := decoder.CalculateResistor(m.resistorReading)
+			if err != nil {
+				m.err = err
+				return m, nil
+			}
+			m.resistorResult = result
+			m.capacitorResult = nil
+		}
+		// A new result is saved as a new entry; a re-decoded one replaces
+		// its history entry
+		m.currentEntryIndex = -1
+		m.successMsg = ""
+		if m.redecodeIndex >= 0 && m.redecodeIndex < len(m.history) {
+			m.currentEntryIndex = m.redecodeIndex
+			m = m.saveCurrentEntry()
+			m.successMsg = fmt.Sprintf("✓ Updated history entry %d", m.redecodeIndex+1)
+		}
+		m.redecodeIndex = -1
+		m.screen = screenResults
+		m.backStack = nil
+		m.err = nil
+	} else if m.keys.Matches(key, ActionCorrect) {
+		// Go to edit mode
+		m = m.pushScreen(screenEdit)
+		m.input = ""
+		m.err = nil
+		m.reviewProblems = nil
+	} else if m.keys.Matches(key, ActionFix) && len(m.reviewProblems) > 0 {
+		// Jump straight into editing the first bad band
+		m.editBandIndex = m.reviewProblems[0].BandNumber
+		m.currentBand = m.editBandIndex
+		m = m.pushScreen(screenBandInput)
+		m.input = ""
+		m.suggestion = ""
+		m.err = nil
+		m.reviewProblems = nil
+	} else if m.keys.Matches(key, ActionQuit) {
+		m.quitting = true
+		return m, tea.Quit
+	}
+
+	return m, nil
+}
+
+func (m model) handleResultsInput(key string) (tea.Model, tea.Cmd) {
+	if m.keys.Matches(key, ActionDecode) {
+		// Decode another - reset to component selection
+		m.screen = screenComponentSelection
+		m.backStack = []screenType{screenWelcome}
+		m.input = ""
+		m.currentBand = 1
+		m.err = nil
+		m.successMsg = ""
+		// The last used band counts stay preselected
+		m.capacitorReading = decoder.CapacitorReading{BandCount: m.capacitorReading.BandCount}
+		m.resistorReading = decoder.ResistorReading{BandCount: m.resistorReading.BandCount}
+		m.capacitorResult = nil
+		m.resistorResult = nil
+		m.currentNote = ""
+		m.currentPackage = ""
+		m.currentQuantity = 0
+		m.currentMeasured = 0
+		m.hasMeasurement = false
+		m.operatingPoint = ""
+		m.specTarget = ""
+		m.reversed = false
+		m.currentEntryIndex = -1
+	} else if m.keys.Matches(key, ActionAgain) {
+		// Decode again - keep component type, capacitor type and band count,
+		// only reset the band colors and result
+		m.screen = screenBandInput
+		m.backStack = m.decodeChain()
+		m.input = ""
+		m.suggestion = ""
+		m.currentBand = 1
+		m.err = nil
+		m.successMsg = ""
+		m.capacitorReading = decoder.CapacitorReading{
+			BandCount: m.capacitorReading.BandCount,
+			CapType:   m.capacitorReading.CapType,
+		}
+		m.resistorReading = decoder.ResistorReading{
+			BandCount: m.resistorReading.BandCount,
+			Military:  m.resistorReading.Military,
+		}
+		m.capacitorResult = nil
+		m.resistorResult = nil
+		m.currentNote = ""
+		m.currentPackage = ""
+		m.currentQuantity = 0
+		m.currentMeasured = 0
+		m.hasMeasurement = false
+		m.operatingPoint = ""
+		m.specTarget = ""
+		m.reversed = false
+		m.currentEntryIndex = -1
+	} else if m.keys.Matches(key, ActionReverse) {
+		// Reverse the band order and recompute, for a part read from the wrong end.
+		// History is left alone; N or X saves the reversed result as a new entry.
+		if m.componentType == decoder.ComponentCapacitor {
+			reading, err := decoder.ReverseReading(m.capacitorReading)
+			if err == nil {
+				err = decoder.ValidateReading(&reading)
+			}
+			if err != nil {
+				m.err = fmt.Errorf("cannot reverse reading: %v", err)
+				m.successMsg = ""
+				return m, nil
+			}
+			result, err := decoder.Calculate(reading)
+			if err != nil {
+				m.err = fmt.Errorf("cannot reverse reading: %v", err)
+				m.successMsg = ""
+				return m, nil
+			}
+			m.capacitorReading = reading
+			m.capacitorResult = result
+		} else {
+			reading, err := decoder.ReverseResistorReading(m.resistorReading)
+			if err == nil {
+				err = decoder.ValidateResistorReading(&reading)
+			}
+			if err != nil {
+				m.err = fmt.Errorf("cannot reverse reading: %v", err)
+				m.successMsg = ""
+				return m, nil
+			}
+			result, err := decoder.CalculateResistor(reading)
+			if err != nil {
+				m.err = fmt.Errorf("cannot reverse reading: %v", err)
+				m.successMsg = ""
+				return m, nil
+			}
+			m.resistorReading = reading
+			m.resistorResult = result
+		}
+		m.reversed = !m.reversed
+		m.currentEntryIndex = -1
+		m.err = nil
+		m.successMsg = ""
+	} else if m.keys.Matches(key, ActionHistory) {
+		// Browse everything decoded this session, newest at the bottom
+		m.screen = screenHistory
+		m.historyCursor = len(m.history) - 1
+		m.scrollOffset = 0
+		if visible := m.historyVisibleLines(); len(m.history) > visible {
+			m.scrollOffset = len(m.history) - visible
+		}
+		m.err = nil
+		m.successMsg = ""
+	} else if m.keys.Matches(key, ActionCombine) {
+		// Pick parts of this type from history to combine in series and parallel
+		m.screen = screenCombine
+		m.combineCursor = 0
+		m.combineSelected = map[int]bool{}
+		m.err = nil
+		m.successMsg = ""
+	} else if m.keys.Matches(key, ActionDivider) {
+		// Pick R1, R2 and an input voltage for a voltage divider
+		m.screen = screenDivider
+		m.input = ""
+		m.err = nil
+		m.successMsg = ""
+	} else if m.keys.Matches(key, ActionCopy) {
+		// Copy the value text, e.g. to paste into a chat or notebook
+		if summary := ResultSummaryLine(m.currentEntry()); summary != "" {
+			return m, copyCmd(summary, "result")
+		}
+	} else if m.keys.Matches(key, ActionWorking) {
+		// Toggle the step-by-step calculation
+		m.showWorking = !m.showWorking
+	} else if m.keys.Matches(key, ActionCompact) {
+		// Toggle the one-line result
+		m.compact = !m.compact
+	} else if m.keys.Matches(key, ActionUnits) {
+		// Toggle the equivalent base-unit value display
+		m.showBaseUnit = !m.showBaseUnit
+	} else if m.keys.Matches(key, ActionLockUnit) {
+		// Cycle the fixed unit for this component type, back to auto-scaling
+		if m.componentType == decoder.ComponentResistor {
+			m.resistanceUnit = nextDisplayUnit(decoder.ResistanceDisplayUnits, m.resistanceUnit)
+		} else {
+			m.capacitanceUnit = nextDisplayUnit(decoder.CapacitanceDisplayUnits, m.capacitanceUnit)
+		}
+	} else if m.keys.Matches(key, ActionFrequency) {
+		// Set the design frequency used for reactance
+		m.screen = screenFrequencyInput
+		m.input = ""
+		if m.frequencyHz > 0 {
+			m.input = fmt.Sprintf("%g", m.frequencyHz)
+		}
+		m.err = nil
+		m.successMsg = ""
+	} else if m.keys.Matches(key, ActionEdit) {
+		// Edit current - go to edit mode
+		m.screen = screenEdit
+		m.input = ""
+		m.err = nil
+		m.successMsg = ""
+	} else if m.keys.Matches(key, ActionNote) {
+		// Add/Edit note - save to history first if not already saved
+		if m.currentEntryIndex < 0 {
+			m = m.saveCurrentEntry()
+		}
+		m.screen = screenNoteInput
+		m.input = m.currentNote // Pre-fill with existing note
+		m.err = nil
+		m.successMsg = ""
+	} else if m.keys.Matches(key, ActionMeasure) {
+		// Log a measured value for incoming inspection
+		m.screen = screenMeasuredInput
+		m.input = ""
+		if m.hasMeasurement {
+			m.input = FormatMeasuredValue(m.currentEntry(), m.currentMeasured)
+		}
+		m.err = nil
+		m.successMsg = ""
+	} else if m.keys.Matches(key, ActionSpec) {
+		// Check the part against a target value and tolerance
+		m.screen = screenSpecInput
+		m.input = m.specTarget
+		m.err = nil
+		m.successMsg = ""
+	} else if m.keys.Matches(key, ActionPower) && m.componentType == decoder.ComponentResistor {
+		// Enter an operating voltage or current for the power dissipated
+		m.screen = screenPowerInput
+		m.input = m.operatingPoint
+		m.err = nil
+		m.successMsg = ""
+	} else if m.keys.Matches(key, ActionBOM) {
+		// Enter package and quantity, then copy the BOM row
+		m.screen = screenBOMInput
+		m.input = formatPackageQuantity(m.currentPackage, m.currentQuantity)
+		m.err = nil
+		m.successMsg = ""
+	} else if m.keys.Matches(key, ActionPin) {
+		// Pin or unpin the current result in favorites
+		var pinned bool
+		m.favorites, pinned = toggleFavorite(m.favorites, m.currentEntry())
+		m.err = nil
+		m.successMsg = "✓ Unpinned from favorites"
+		if pinned {
+			m.successMsg = "✓ Pinned to favorites"
+		}
+		return m.saveFavorites()
+	} else if m.keys.Matches(key, ActionExport) || m.keys.Matches(key, ActionBOMExport) {
+		if m.exporting {
+			// One export at a time
+			return m, nil
+		}
+
+		// Add current result to history if not already there
+		if m.currentEntryIndex < 0 {
+			m = m.saveCurrentEntry()
+		}
+
+		// Check if there's data to export
+		if len(m.history) == 0 {
+			m.err = fmt.Errorf("no data to export (decode at least one component first)")
+			m.successMsg = ""
+		} else {
+			// Navigate to file picker
+			m.screen = screenFilePicker
+			m.exportBOM = m.keys.Matches(key, ActionBOMExport)
+			// History can also be exported as JSON or Markdown; a BOM is CSV only
+			m.filepicker.AllowedTypes = []string{".csv", ".json", ".md"}
+			if m.exportBOM {
+				m.filepicker.AllowedTypes = []string{".csv"}
+			}
+			m.err = nil
+			m.successMsg = ""
+		}
+	} else if m.keys.Matches(key, ActionQuit) {
+		m.quitting = true
+		return m, tea.Quit
+	}
+
+	return m, nil
+}
+
+// currentEntry returns the current result as a history entry
+func (m model) currentEntry() ComponentEntry {
+	return ComponentEntry{
+		ComponentType:   m.componentType,
+		CapacitorResult: m.capacitorResult,
+		ResistorResult:  m.resistorResult,
+		Note:            m.currentNote,
+		Package:         m.currentPackage,
+		Quantity:        m.currentQuantity,
+		MeasuredValue:   m.currentMeasured,
+		Measured:        m.hasMeasurement,
+	}
+}
+
+// saveCurrentEntry updates the history entry holding the current result,
+// or adds the current result to history the first time it is saved
+func (m model) saveCurrentEntry() model {
+	if m.currentEntryIndex >= 0 && m.currentEntryIndex < len(m.history) {
+		m.history[m.currentEntryIndex] = m.currentEntry()
+		return m
+	}
+	m = appendHistory(m, m.currentEntry())
+	m.currentEntryIndex = len(m.history) - 1
+	return m
+}
+
+func (m model) handleBOMInput(key string) (tea.Model, tea.Cmd) {
+	if m.keys.Matches(key, ActionSubmit) {
+		pkg, quantity, err := ParsePackageQuantity(m.input)
+		if err != nil {
+			m.err = err
+			return m, nil
+		}
+		m.currentPackage = pkg
+		m.currentQuantity = quantity
+		m = m.saveCurrentEntry()
+
+		m.screen = screenResults
+		m.input = ""
+		m.err = nil
+		return m, copyCmd(BOMRowText(m.currentEntry()), "BOM row")
+	} else if m.keys.Matches(key, ActionCancel) {
+		m.screen = screenResults
+		m.input = ""
+		m.err = nil
+	} else if key == "backspace" || key == "delete" {
+		if len(m.input) > 0 {
+			m.input = trimLastRune(m.input)
+		}
+	} else if len(key) == 1 || key == "×" {
+		m.input += key
+	}
+
+	return m, nil
+}
+
+func (m model) handleMeasuredInput(key string) (tea.Model, tea.Cmd) {
+	if m.keys.Matches(key, ActionSubmit) {
+		// Empty input clears the measurement
+		if strings.TrimSpace(m.input) == "" {
+			m.currentMeasured = 0
+			m.hasMeasurement = false
+		} else {
+			value, err := ParseMeasuredValue(m.currentEntry(), m.input)
+			if err != nil {
+				m.err = fmt.Errorf("invalid measured value: %v", err)
+				return m, nil
+			}
+			m.currentMeasured = value
+			m.hasMeasurement = true
+		}
+		m = m.saveCurrentEntry()
+		m.screen = screenResults
+		m.input = ""
+		m.err = nil
+	} else if m.keys.Matches(key, ActionCancel) {
+		m.screen = screenResults
+		m.input = ""
+		m.err = nil
+	} else if key == "backspace" || key == "delete" {
+		if len(m.input) > 0 {
+			m.input = trimLastRune(m.input)
+		}
+	} else if len(key) == 1 || key == "µ" || key == "Ω" {
+		m.input += key
+	}
+
+	return m, nil
+}
+
+func (m model) handleSpecInput(key string) (tea.Model, tea.Cmd) {
+	if m.keys.Matches(key, ActionSubmit) {
+		// Empty input clears the target
+		if strings.TrimSpace(m.input) != "" {
+			if _, _, err := ParseSpec(m.currentEntry(), m.input); err != nil {
+				m.err = fmt.Errorf("invalid target: %v", err)
+				return m, nil
+			}
+		}
+		m.specTarget = strings.TrimSpace(m.input)
+		m.screen = screenResults
+		m.input = ""
+		m.err = nil
+	} else if m.keys.Matches(key, ActionCancel) {
+		m.screen = screenResults
+		m.input = ""
+		m.err = nil
+	} else if key == "backspace" || key == "delete" {
+		if len(m.input) > 0 {
+			m.input = trimLastRune(m.input)
+		}
+	} else if len(key) == 1 || key == "µ" || key == "±" {
+		m.input += key
+	}
+
+	return m, nil
+}
+
+func (m model) handlePowerInput(key string) (tea.Model, tea.Cmd) {
+	if m.keys.Matches(key, ActionSubmit) {
+		// Empty input clears the operating point
+		if strings.TrimSpace(m.input) != "" {
+			volts, amps, err := decoder.ParseOperatingPoint(m.input)
+			if err == nil {
+				_, _, err = decoder.ResistorPower(m.resistorResult.ResistanceOhms, volts, amps)
+			}
+			if err != nil {
+				m.err = fmt.Errorf("invalid voltage or current: %v", err)
+				return m, nil
+			}
+		}
+		m.operatingPoint = strings.TrimSpace(m.input)
+		m.screen = screenResults
+		m.input = ""
+		m.err = nil
+	} else if m.keys.Matches(key, ActionCancel) {
+		m.screen = screenResults
+		m.input = ""
+		m.err = nil
+	} else if key == "backspace" || key == "delete" {
+		if len(m.input) > 0 {
+			m.input = trimLastRune(m.input)
+		}
+	} else if len(key) == 1 || key == "µ" {
+		m.input += key
+	}
+
+	return m, nil
+}
+
+func (m model) handleFrequencyInput(key string) (tea.Model, tea.Cmd) {
+	if m.keys.Matches(key, ActionSubmit) {
+		// Empty input clears the frequency
+		if strings.TrimSpace(m.input) == "" {
+			m.frequencyHz = 0
+		} else {
+			hz, err := decoder.ParseFrequency(m.input)
+			if err != nil {
+				m.err = fmt.Errorf("invalid frequency: %v", err)
+				return m, nil
+			}
+			m.frequencyHz = hz
+		}
+		m.screen = screenResults
+		m.input = ""
+		m.err = nil
+	} else if m.keys.Matches(key, ActionCancel) {
+		m.screen = screenResults
+		m.input = ""
+		m.err = nil
+	} else if key == "backspace" || key == "delete" {
+		if len(m.input) > 0 {
+			m.input = trimLastRune(m.input)
+		}
+	} else if len(key) == 1 {
+		m.input += key
+	}
+
+	return m, nil
+}
+
+func (m model) handleEditInput(key string) (tea.Model, tea.Cmd) {
+	// Accept single key press without Enter
+	var maxBand int
+	if m.componentType == decoder.ComponentCapacitor {
+		maxBand = m.capacitorReading.BandCount
+	} else {
+		maxBand = m.resistorReading.BandCount
+	}
+
+	// Parse band number to edit
+	if key == "1" {
+		m.editBandIndex = 1
+		m.currentBand = 1
+		m = m.pushScreen(screenBandInput)
+		m.input = ""
+		m.err = nil
+	} else if key == "2" {
+		m.editBandIndex = 2
+		m.currentBand = 2
+		m = m.pushScreen(screenBandInput)
+		m.input = ""
+		m.err = nil
+	} else if key == "3" {
+		m.editBandIndex = 3
+		m.currentBand = 3
+		m = m.pushScreen(screenBandInput)
+		m.input = ""
+		m.err = nil
+	} else if key == "4" {
+		m.editBandIndex = 4
+		m.currentBand = 4
+		m = m.pushScreen(screenBandInput)
+		m.input = ""
+		m.err = nil
+	} else if key == "5" && maxBand >= 5 {
+		m.editBandIndex = 5
+		m.currentBand = 5
+		m = m.pushScreen(screenBandInput)
+		m.input = ""
+		m.err = nil
+	} else if key == "6" && maxBand >= 6 {
+		m.editBandIndex = 6
+		m.currentBand = 6
+		m = m.pushScreen(screenBandInput)
+		m.input = ""
+		m.err = nil
+	} else if m.keys.Matches(key, ActionQuit) || m.keys.Matches(key, ActionCancel) {
+		// Cancel edit, go back to review
+		m.screen = screenReview
+		m.editBandIndex = 0
+		m.input = ""
+		m.err = nil
+	}
+
+	return m, nil
+}
+
+func (m model) handleNoteInputInput(key string) (tea.Model, tea.Cmd) {
+	if m.keys.Matches(key, ActionSubmit) {
+		// Save note and update/add to history
+		m.currentNote = m.input
+
+		m = m.saveCurrentEntry()
+
+		// Go back to results screen
+		m.screen = screenResults
+		m.input = ""
+		m.err = nil
+	} else if m.keys.Matches(key, ActionCancel) {
+		// Cancel note input, go back to results
+		m.screen = screenResults
+		m.input = ""
+		m.err = nil
+	} else if key == "backspace" || key == "delete" {
+		if len(m.input) > 0 {
+			m.input = trimLastRune(m.input)
+		}
+	} else if len(key) == 1 {
+		// Limit note to 200 characters
+		if len(m.input) < 200 {
+			m.input += key
+		} else {
+			m.err = fmt.Errorf("note too long (max 200 characters)")
+		}
+	}
+
+	return m, nil
+}
+
+// View renders the current screen, led by any pending OSC 52 clipboard write
+// so that the renderer writes it to the program's output
+func (m model) View() string {
+	return m.clipboardOSC + m.renderScreen()
+}
+
+// renderScreen renders the current screen
+func (m model) renderScreen() string {
+	if m.quitting {
+		return successStyle.Render("\n✓ Thanks for using Tropical Fish Decoder!\n\n")
+	}
+	switch m.screen {
+	case screenWelcome:
+		return m.renderWelcome()
+	case screenHelp:
+		return m.renderHelp()
+	case screenComponentSelection:
+		return m.renderComponentSelection()
+	case screenTypeSelection:
+		return m.renderTypeSelection()
+	case screenBandCountSelection:
+		return m.renderBandCountSelection()
+	case screenBandInput:
+		return m.renderBandInput()
+	case screenReview:
+		return m.renderReview()
+	case screenResults:
+		return m.renderResults()
+	case screenNoteInput:
+		return m.renderNoteInput()
+	case screenEdit:
 		return m.renderEdit()
 	case screenFilePicker:
 		return m.renderFilePicker()
+	case screenReference:
+		return m.renderReference()
+	case screenFrequencyInput:
+		return m.renderFrequencyInput()
+	case screenCapacitanceLookup:
+		return m.renderCapacitanceLookup()
+	case screenAbout:
+		return m.renderAbout()
+	case screenFavorites:
+		return m.renderFavorites()
+	case screenReverseResistor:
+		return m.renderReverseResistor()
+	case screenReverseCapacitor:
+		return m.renderReverseCapacitor()
+	case screenSMDInput:
+		return m.renderSMDInput()
+	case screenHistory:
+		return m.renderHistory()
+	case screenBOMInput:
+		return m.renderBOMInput()
+	case screenMeasuredInput:
+		return m.renderMeasuredInput()
+	case screenSpecInput:
+		return m.renderSpecInput()
+	case screenPowerInput:
+		return m.renderPowerInput()
+	case screenCombine:
+		return m.renderCombine()
+	case screenDivider:
+		return m.renderDivider()
+	case screenVoltageTable:
+		return m.renderVoltageTable()
+	}
+
+	return "Unknown screen\n"
+}
+
+func (m model) renderWelcome() string {
+	var b strings.Builder
+
+	b.WriteString("\n")
+	b.WriteString(headerStyle.Render("═══════════════════════════════════════════════════════════════"))
+	b.WriteString("\n")
+	b.WriteString(titleStyle.Render("    TROPICAL FISH COMPONENT COLOR CODE DECODER"))
+	b.WriteString("\n")
+	b.WriteString(subtitleStyle.Render("            Welcome Screen"))
+	b.WriteString("\n")
+	b.WriteString(headerStyle.Render("═══════════════════════════════════════════════════════════════"))
+	b.WriteString("\n\n")
+
+	b.WriteString(valueStyle.Render("Decode color bands from capacitors and resistors to determine"))
+	b.WriteString("\n")
+	b.WriteString(valueStyle.Render("values, tolerances, and ratings."))
+	b.WriteString("\n\n")
+
+	b.WriteString(labelStyle.Render("Supported components:"))
+	b.WriteString("\n")
+	b.WriteString(valueStyle.Render("  • Capacitors - IEC 60062 Standard (3/4/5 bands)"))
+	b.WriteString("\n")
+	b.WriteString(valueStyle.Render("  • Resistors - EIA Standard (4/5/6 bands)"))
+	b.WriteString("\n\n")
+
+	b.WriteString(RenderSeparator(contentWidth(m.width)))
+	b.WriteString("\n\n")
+
+	b.WriteString(promptStyle.Render("Press ENTER to begin, R for reference chart, L for capacitor lookup, F for favorites,"))
+	b.WriteString("\n")
+	b.WriteString(promptStyle.Render("B or V for resistor or capacitor bands from a value, A for about, or Q to quit"))
+	b.WriteString("\n")
+	b.WriteString(mutedStyle.Render("Press " + m.keys.Describe(ActionHelp) + " on any menu for keyboard shortcuts"))
+	b.WriteString("\n")
+
+	// Show startup import summary
+	if m.successMsg != "" {
+		b.WriteString("\n")
+		b.WriteString(successStyle.Render(m.successMsg))
+		b.WriteString("\n")
+	}
+
+	// Version footer, so bug reports can say which build they are from
+	b.WriteString("\n")
+	b.WriteString(mutedStyle.Render("tropical-fish " + CurrentBuildInfo().Version))
+	b.WriteString("\n")
+
+	return b.String()
+}
+
+func (m model) renderComponentSelection() string {
+	var b strings.Builder
+
+	b.WriteString("\n")
+	b.WriteString(headerStyle.Render(" STEP 1: SELECT COMPONENT TYPE "))
+	b.WriteString("\n\n")
+
+	b.WriteString(valueStyle.Render("What would you like to decode?"))
+	b.WriteString("\n\n")
+
+	options := m.componentRows()
+	b.WriteString(renderComponentOption(m.componentType == decoder.ComponentCapacitor, options[0].text))
+	b.WriteString("\n")
+	b.WriteString(renderComponentOption(m.componentType == decoder.ComponentResistor, options[1].text))
+	b.WriteString("\n")
+	b.WriteString(renderComponentOption(false, options[2].text))
+	b.WriteString("\n\n")
+
+	last := "Capacitor"
+	if m.componentType == decoder.ComponentResistor {
+		last = "Resistor"
+	}
+	b.WriteString(promptStyle.Render(fmt.Sprintf("Press %s for Capacitor, %s for Resistor, %s for SMD Resistor, Enter for %s, or %s to quit",
+		strings.ToUpper(m.keys.Describe(ActionCapacitor)), strings.ToUpper(m.keys.Describe(ActionResistor)),
+		strings.ToUpper(m.keys.Describe(ActionSMD)), last, strings.ToUpper(m.keys.Describe(ActionQuit)))))
+	b.WriteString("\n")
+
+	if m.err != nil {
+		b.WriteString("\n")
+		b.WriteString(errorStyle.Render("✗ " + m.err.Error()))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(helpStyle.Render("Press B or ESC to go back, Ctrl+C to quit"))
+	b.WriteString("\n")
+
+	return b.String()
+}
+
+func (m model) renderSMDInput() string {
+	var b strings.Builder
+
+	b.WriteString("\n")
+	b.WriteString(headerStyle.Render(" SMD RESISTOR CODE "))
+	b.WriteString("\n\n")
+
+	b.WriteString(valueStyle.Render("Enter the code printed on the resistor."))
+	b.WriteString("\n")
+	b.WriteString(mutedStyle.Render("472 = 47 × 10² Ω (±5%), 1002 = 100 × 10² Ω (±1%), R marks the decimal point: 4R7, R100"))
+	b.WriteString("\n\n")
+
+	b.WriteString(promptStyle.Render("Code: "))
+	b.WriteString(inputStyle.Render(m.input))
+	b.WriteString("\n\n")
+
+	if result := m.smdResult; result != nil {
+		b.WriteString(resultLabelStyle.Render("Code:"))
+		b.WriteString("  ")
+		b.WriteString(resultValueStyle.Render(m.smdCode))
+		b.WriteString("\n")
+
+		b.WriteString(resultLabelStyle.Render("Value:"))
+		b.WriteString("  ")
+		b.WriteString(resultValueStyle.Render(decoder.FormatResistanceWithOhms(result.ResistanceValue, result.ResistanceUnit, result.ResistanceOhms)))
+		b.WriteString("\n")
+
+		b.WriteString(resultLabelStyle.Render("Tolerance:"))
+		b.WriteString("  ")
+		b.WriteString(resultValueStyle.Render(fmt.Sprintf("±%.0f%% (typical)", result.TolerancePercent)))
+		b.WriteString("\n")
+
+		b.WriteString(resultLabelStyle.Render("Range:"))
+		b.WriteString("  ")
+		b.WriteString(resultValueStyle.Render(decoder.FormatResistorToleranceRange(result)))
+		b.WriteString("\n\n")
+	}
+
+	b.WriteString(helpStyle.Render("Press ENTER to decode, ESC to go back, Ctrl+C to quit"))
+	b.WriteString("\n")
+
+	if m.err != nil {
+		b.WriteString("\n")
+		b.WriteString(errorStyle.Render("✗ " + m.err.Error()))
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+func (m model) renderTypeSelection() string {
+	var b strings.Builder
+
+	b.WriteString("\n")
+	b.WriteString(headerStyle.Render(" STEP 2: SELECT CAPACITOR TYPE "))
+	b.WriteString("\n\n")
+
+	b.WriteString(valueStyle.Render("Available types:"))
+	b.WriteString("\n")
+	b.WriteString(valueStyle.Render("  J = Dipped Tantalum"))
+	b.WriteString("\n")
+	b.WriteString(valueStyle.Render("  K = Mica"))
+	b.WriteString("\n")
+	b.WriteString(valueStyle.Render("  L = Polyester / Polystyrene"))
+	b.WriteString("\n")
+	b.WriteString(valueStyle.Render("  M = Electrolytic (4-band)"))
+	b.WriteString("\n")
+	b.WriteString(valueStyle.Render("  N = Electrolytic (3-band)"))
+	b.WriteString("\n\n")
+
+	b.WriteString(promptStyle.Render("Enter type letter or name: "))
+	b.WriteString(inputStyle.Render(m.input))
+	if m.suggestion != "" {
+		b.WriteString(mutedStyle.Render(m.suggestion))
+	}
+	b.WriteString("\n")
+
+	// What the typed type is, for beginners unsure which they hold
+	if capType, ok := decoder.ParseCapacitorType(m.input); ok {
+		info, _ := decoder.GetTypeInfo(capType)
+		b.WriteString("\n")
+		b.WriteString(mutedStyle.Render("  " + info.Description + ": " + info.Dielectric))
+		b.WriteString("\n")
+		b.WriteString(mutedStyle.Render("  Typical use: " + info.TypicalUse))
+		b.WriteString("\n")
+		b.WriteString(mutedStyle.Render("  Temperature stability: " + info.TempStability))
+		b.WriteString("\n")
+	}
+
+	if m.err != nil {
+		b.WriteString("\n")
+		b.WriteString(errorStyle.Render("✗ " + m.err.Error()))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(helpStyle.Render("Type J, K, L, M, N or a name (e.g. mica), Tab to autocomplete, Enter to select, Esc to go back, Q to quit"))
+	b.WriteString("\n")
+	b.WriteString(helpStyle.Render("Press V for the voltage codes of each type"))
+	b.WriteString("\n")
+
+	return b.String()
+}
+
+func (m model) renderVoltageTable() string {
+	var b strings.Builder
+
+	b.WriteString("\n")
+	b.WriteString(headerStyle.Render(" CAPACITOR VOLTAGE CODES "))
+	b.WriteString("\n\n")
+
+	typeInfo, _ := decoder.GetTypeInfo(m.voltageTableType)
+	b.WriteString(valueStyle.Render("Band 5 on " + typeInfo.Description + ":"))
+	b.WriteString("\n\n")
+
+	volts, err := strconv.ParseFloat(m.input, 64)
+	if err != nil {
+		volts = 0
+	}
+	b.WriteString(RenderVoltageTable(m.voltageTableType, volts, m.palette))
+	b.WriteString("\n\n")
+
+	b.WriteString(promptStyle.Render("Find a voltage: "))
+	b.WriteString(inputStyle.Render(m.input))
+	b.WriteString("\n")
+	if m.input != "" {
+		code, ok := decoder.VoltageCodeAtLeast(m.voltageTableType, volts)
+		rating := strconv.FormatFloat(volts, 'f', -1, 64) + " V"
+		switch {
+		case err != nil || volts <= 0:
+			b.WriteString(errorStyle.Render("✗ Enter a voltage, e.g. 6.3"))
+		case ok && code.Volts == volts:
+			b.WriteString(successStyle.Render(fmt.Sprintf("✓ %s marks %s on Type %s", m.palette.ColorName(code.Color), rating, m.voltageTableType)))
+		case ok:
+			b.WriteString(warningStyle.Render(fmt.Sprintf("No Type %s code for %s; the next rating up is %s V (%s)",
+				m.voltageTableType, rating, strconv.FormatFloat(code.Volts, 'f', -1, 64), m.palette.ColorName(code.Color))))
+		default:
+			b.WriteString(errorStyle.Render(fmt.Sprintf("✗ No Type %s code is rated for %s or more", m.voltageTableType, rating)))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(helpStyle.Render("J/K/L/M/N: switch type  |  Type a voltage to find its code  |  Enter: decode this type  |  Q/ESC: Back"))
+	b.WriteString("\n")
+
+	return b.String()
+}
+
+func (m model) renderBandCountSelection() string {
+	var b strings.Builder
+
+	stepNum := "STEP 2"
+	if m.componentType == decoder.ComponentResistor {
+		stepNum = "STEP 2" // Still step 2 for resistors
+	} else {
+		stepNum = "STEP 3" // Step 3 for capacitors (after type selection)
+	}
+
+	b.WriteString("\n")
+	b.WriteString(headerStyle.Render(fmt.Sprintf(" %s: SELECT BAND COUNT ", stepNum)))
+	b.WriteString("\n\n")
+
+	if m.componentType == decoder.ComponentCapacitor {
+		typeInfo, _ := decoder.GetTypeInfo(m.capacitorReading.CapType)
+		b.WriteString(labelStyle.Render("Capacitor Type: "))
+		b.WriteString(valueStyle.Render(string(m.capacitorReading.CapType) + " (" + typeInfo.Description + ")"))
+		b.WriteString("\n\n")
+
+		b.WriteString(valueStyle.Render("How many color bands does your capacitor have?"))
+		b.WriteString("\n")
+		b.WriteString(renderBandCountOption(3, m.capacitorReading.BandCount, "3-band (value + multiplier, ±20% tolerance implied)"))
+		b.WriteString("\n")
+		b.WriteString(renderBandCountOption(4, m.capacitorReading.BandCount, "4-band (value + multiplier + tolerance + voltage)"))
+		b.WriteString("\n")
+		b.WriteString(renderBandCountOption(5, m.capacitorReading.BandCount, "5-band (value + multiplier + tolerance + voltage, + temp coeff on Type K)"))
+		b.WriteString("\n\n")
+
+		b.WriteString(promptStyle.Render(fmt.Sprintf("Press 3, 4, or 5 to select band count, Enter for %d, or Q to quit",
+			m.capacitorReading.BandCount)))
+	} else if m.componentType == decoder.ComponentResistor {
+		b.WriteString(labelStyle.Render("Component: "))
+		b.WriteString(valueStyle.Render("Resistor"))
+		b.WriteString("\n\n")
+
+		b.WriteString(valueStyle.Render("How many color bands does your resistor have?"))
+		b.WriteString("\n")
+		b.WriteString(renderBandCountOption(4, m.resistorReading.BandCount, "4-band (standard, ±5% or ±10% tolerance)"))
+		b.WriteString("\n")
+		b.WriteString(renderBandCountOption(5, m.resistorReading.BandCount, "5-band (precision, ±1% or ±2% tolerance)"))
+		b.WriteString("\n")
+		b.WriteString(renderBandCountOption(6, m.resistorReading.BandCount, "6-band (precision + temperature coefficient)"))
+		b.WriteString("\n")
+		military := strings.ToUpper(m.keys.Describe(ActionMilitary))
+		b.WriteString(valueStyle.Render("  " + military + " = 5-band military (value + multiplier + tolerance + failure rate)"))
+		b.WriteString("\n")
+		auto := strings.ToUpper(m.keys.Describe(ActionAutoBands))
+		b.WriteString(valueStyle.Render("  " + auto + " = auto (enter bands, then Enter on an empty field to infer the count)"))
+		b.WriteString("\n\n")
+
+		b.WriteString(promptStyle.Render(fmt.Sprintf("Press 4, 5, 6, %s, or %s to select band count, Enter for %d, or Q to quit",
+			military, auto, m.resistorReading.BandCount)))
+	}
+
+	b.WriteString("\n")
+
+	if m.err != nil {
+		b.WriteString("\n")
+		b.WriteString(errorStyle.Render("✗ " + m.err.Error()))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(helpStyle.Render("Press B or ESC to go back, Ctrl+C to quit"))
+	b.WriteString("\n")
+
+	return b.String()
+}
+
+// componentRows returns the component choices, one clickable option each
+func (m model) componentRows() []clickRow {
+	return []clickRow{
+		m.keys.promptRow(actionOption{ActionCapacitor, "Capacitor - IEC 60062 Standard (3/4/5 bands)"}),
+		m.keys.promptRow(actionOption{ActionResistor, "Resistor - EIA Standard (4/5/6 bands)"}),
+		m.keys.promptRow(actionOption{ActionSMD, "SMD Resistor - 3/4-character marking code (472, 1002, 4R7)"}),
+	}
+}
+
+// renderComponentOption renders one component choice, marking the preselected one
+func renderComponentOption(selected bool, description string) string {
+	if selected {
+		return successStyle.Render("▶ " + description)
+	}
+	return valueStyle.Render("  " + description)
+}
+
+// renderBandCountOption renders one band count choice, marking the preselected one
+func renderBandCountOption(count, selected int, description string) string {
+	if count == selected {
+		return successStyle.Render(fmt.Sprintf("▶ %d = %s", count, description))
+	}
+	return valueStyle.Render(fmt.Sprintf("  %d = %s", count, description))
+}
+
+func (m model) renderBandInput() string {
+	var b strings.Builder
+
+	var bandCount int
+	var bandName string
+	var bandDescription string
+
+	if m.componentType == decoder.ComponentCapacitor {
+		bandCount = m.capacitorReading.BandCount
+		bandName = decoder.GetBandName(m.currentBand)
+		bandDescription = decoder.GetBandDescription(m.currentBand, bandCount)
+	} else {
+		reading := m.resistorReading
+		if m.autoBandCount {
+			reading = decoder.ResistorReading{} // Band roles are unknown until the count is inferred
+		}
+		bandCount = reading.BandCount
+		bandName = reading.BandName(m.currentBand)
+		bandDescription = reading.BandDescription(m.currentBand)
+	}
+
+	b.WriteString("\n")
+	if m.autoBandCount {
+		b.WriteString(headerStyle.Render(fmt.Sprintf(" BAND INPUT (%d, count inferred) ", m.currentBand)))
+	} else {
+		b.WriteString(headerStyle.Render(fmt.Sprintf(" BAND INPUT (%d of %d) ", m.currentBand, bandCount)))
+	}
+	b.WriteString("\n\n")
+
+	if m.componentType == decoder.ComponentCapacitor {
+		typeInfo, _ := decoder.GetTypeInfo(m.capacitorReading.CapType)
+		b.WriteString(labelStyle.Render("Type: "))
+		b.WriteString(valueStyle.Render(string(m.capacitorReading.CapType) + " (" + typeInfo.Name + ")"))
+		b.WriteString("  ")
+	} else {
+		b.WriteString(labelStyle.Render("Component: "))
+		b.WriteString(valueStyle.Render("Resistor"))
+		b.WriteString("  ")
+	}
+
+	b.WriteString(labelStyle.Render("Bands: "))
+	if m.autoBandCount {
+		b.WriteString(valueStyle.Render("auto"))
+	} else if m.componentType == decoder.ComponentResistor && m.resistorReading.Military {
+		b.WriteString(valueStyle.Render(fmt.Sprintf("%d (military)", bandCount)))
+	} else {
+		b.WriteString(valueStyle.Render(fmt.Sprintf("%d", bandCount)))
+	}
+	b.WriteString("\n\n")
+
+	names := m.palette.ColorNames()
+	b.WriteString(mutedStyle.Render("Valid colors: " + strings.Join(names[:7], ", ") + ","))
+	b.WriteString("\n")
+	b.WriteString(mutedStyle.Render("              " + strings.Join(names[7:], ", ")))
+	b.WriteString("\n\n")
+
+	// Show previously entered bands
+	if m.currentBand > 1 {
+		b.WriteString(labelStyle.Render("Already entered:"))
+		b.WriteString("\n")
+		for i := 1; i < m.currentBand; i++ {
+			var color decoder.Color
+			if m.componentType == decoder.ComponentCapacitor {
+				switch i {
+				case 1:
+					color = m.capacitorReading.Band1
+				case 2:
+					color = m.capacitorReading.Band2
+				case 3:
+					color = m.capacitorReading.Band3
+				case 4:
+					color = m.capacitorReading.Band4
+				case 5:
+					color = m.capacitorReading.Band5
+				}
+			} else {
+				switch i {
+				case 1:
+					color = m.resistorReading.Band1
+				case 2:
+					color = m.resistorReading.Band2
+				case 3:
+					color = m.resistorReading.Band3
+				case 4:
+					color = m.resistorReading.Band4
+				case 5:
+					color = m.resistorReading.Band5
+				case 6:
+					color = m.resistorReading.Band6
+				}
+			}
+			b.WriteString(confirmStyle.Render(fmt.Sprintf("  ✓ Band %d: ", i)))
+			if m.autoBandCount && i > 2 {
+				// The band's role is not known until the count is inferred
+				b.WriteString(renderSwatch(color, " "+m.palette.ColorName(color)+" "))
+			} else if m.componentType == decoder.ComponentResistor {
+				b.WriteString(RenderResistorReadingBand(m.resistorReading, i, m.palette))
+			} else {
+				b.WriteString(RenderColorBand(color, i, m.palette))
+			}
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+	}
+
+	// Compact legend of every band's role, current band highlighted
+	for i := 1; i <= bandCount; i++ {
+		if i > 1 {
+			b.WriteString(mutedStyle.Render(" · "))
+		}
+		var name string
+		if m.componentType == decoder.ComponentCapacitor {
+			name = decoder.GetBandName(i)
+		} else {
+			name = m.resistorReading.BandName(i)
+		}
+		legend := fmt.Sprintf("%d %s", i, name)
+		if i == m.currentBand {
+			b.WriteString(labelStyle.Render(legend))
+		} else {
+			b.WriteString(mutedStyle.Render(legend))
+		}
+	}
+	b.WriteString("\n\n")
+
+	// Current band input
+	b.WriteString(bandHeaderStyle.Render(fmt.Sprintf("─ BAND %d (%s) ", m.currentBand, bandName)))
+	b.WriteString("\n")
+	b.WriteString(mutedStyle.Render(bandDescription))
+	b.WriteString("\n\n")
+
+	// Voltage codes differ by type, so list this type's colors
+	if m.componentType == decoder.ComponentCapacitor && m.currentBand == 5 {
+		b.WriteString(labelStyle.Render(fmt.Sprintf("Voltage codes for Type %s:", m.capacitorReading.CapType)))
+		b.WriteString("\n")
+		b.WriteString(RenderVoltageCodeTable(m.capacitorReading.CapType, m.palette))
+		b.WriteString("\n\n")
+	}
+
+	b.WriteString(promptStyle.Render(fmt.Sprintf("Enter Band %d color: ", m.currentBand)))
+	if m.replaceOnType {
+		// Highlight rejected input; typing replaces it
+		b.WriteString(invalidInputStyle.Render(m.input))
+	} else {
+		b.WriteString(inputStyle.Render(m.input))
+	}
+
+	// Show autocomplete suggestion in grey
+	if m.suggestion != "" {
+		b.WriteString(mutedStyle.Render(m.suggestion))
+	}
+	if !m.replaceOnType {
+		b.WriteString(m.inputIndicator())
+	}
+	b.WriteString("\n")
+
+	if m.err != nil {
+		b.WriteString("\n")
+		b.WriteString(errorStyle.Render("✗ " + m.err.Error()))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+
+	// Show hint for autocomplete
+	if m.replaceOnType {
+		b.WriteString(helpStyle.Render("Type to replace, Backspace to edit, ESC to go back, Ctrl+C to quit"))
+	} else if m.suggestion != "" {
+		b.WriteString(helpStyle.Render("Press Tab to autocomplete, Enter to submit, ESC to go back, Ctrl+C to quit"))
+	} else {
+		b.WriteString(helpStyle.Render("Press Enter to submit, ESC to go back, Ctrl+C to quit"))
+	}
+	b.WriteString("\n")
+	if m.autoBandCount && m.currentBand > 4 && m.input == "" {
+		b.WriteString(helpStyle.Render("Press Enter on an empty field after the last band"))
+		b.WriteString("\n")
+	}
+	if m.currentBand > 1 {
+		b.WriteString(helpStyle.Render(fmt.Sprintf("Press %s to redo band %d", m.keys.Describe(ActionUndo), m.currentBand-1)))
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+func (m model) renderReview() string {
+	var b strings.Builder
+
+	b.WriteString("\n")
+	b.WriteString(headerStyle.Render(" REVIEW YOUR INPUT "))
+	b.WriteString("\n\n")
+
+	if m.componentType == decoder.ComponentCapacitor {
+		b.WriteString(RenderBandStrip(m.capacitorReading.Colors()))
+	} else {
+		b.WriteString(RenderBandStrip(m.resistorReading.Colors()))
+	}
+	b.WriteString("\n\n")
+
+	if m.componentType == decoder.ComponentCapacitor {
+		typeInfo, _ := decoder.GetTypeInfo(m.capacitorReading.CapType)
+		b.WriteString(labelStyle.Render("Capacitor Type: "))
+		b.WriteString(valueStyle.Render(string(m.capacitorReading.CapType) + " (" + typeInfo.Description + ")"))
+		b.WriteString("\n")
+
+		b.WriteString(labelStyle.Render("Band Count: "))
+		b.WriteString(valueStyle.Render(fmt.Sprintf("%d", m.capacitorReading.BandCount)))
+		b.WriteString("\n\n")
+
+		b.WriteString(labelStyle.Render("Bands entered:"))
+		b.WriteString("\n")
+		b.WriteString(valueStyle.Render("  Band 1: "))
+		b.WriteString(RenderColorBand(m.capacitorReading.Band1, 1, m.palette))
+		b.WriteString("\n")
+		b.WriteString(valueStyle.Render("  Band 2: "))
+		b.WriteString(RenderColorBand(m.capacitorReading.Band2, 2, m.palette))
+		b.WriteString("\n")
+		b.WriteString(valueStyle.Render("  Band 3: "))
+		b.WriteString(RenderColorBand(m.capacitorReading.Band3, 3, m.palette))
+		b.WriteString("\n")
+		if m.capacitorReading.BandCount >= 4 {
+			b.WriteString(valueStyle.Render("  Band 4: "))
+			b.WriteString(RenderColorBand(m.capacitorReading.Band4, 4, m.palette))
+			b.WriteString("\n")
+		}
+		if m.capacitorReading.BandCount == 5 {
+			b.WriteString(valueStyle.Render("  Band 5: "))
+			b.WriteString(RenderColorBand(m.capacitorReading.Band5, 5, m.palette))
+			b.WriteString("\n")
+		}
+	} else {
+		b.WriteString(labelStyle.Render("Component: "))
+		b.WriteString(valueStyle.Render("Resistor"))
+		b.WriteString("\n")
+
+		b.WriteString(labelStyle.Render("Band Count: "))
+		if m.resistorReading.Military {
+			b.WriteString(valueStyle.Render(fmt.Sprintf("%d (military)", m.resistorReading.BandCount)))
+		} else {
+			b.WriteString(valueStyle.Render(fmt.Sprintf("%d", m.resistorReading.BandCount)))
+		}
+		b.WriteString("\n\n")
+
+		b.WriteString(labelStyle.Render("Bands entered:"))
+		b.WriteString("\n")
+		b.WriteString(valueStyle.Render("  Band 1: "))
+		b.WriteString(RenderResistorReadingBand(m.resistorReading, 1, m.palette))
+		b.WriteString("\n")
+		b.WriteString(valueStyle.Render("  Band 2: "))
+		b.WriteString(RenderResistorReadingBand(m.resistorReading, 2, m.palette))
+		b.WriteString("\n")
+		b.WriteString(valueStyle.Render("  Band 3: "))
+		b.WriteString(RenderResistorReadingBand(m.resistorReading, 3, m.palette))
+		b.WriteString("\n")
+		b.WriteString(valueStyle.Render("  Band 4: "))
+		b.WriteString(RenderResistorReadingBand(m.resistorReading, 4, m.palette))
+		b.WriteString("\n")
+		if m.resistorReading.BandCount >= 5 {
+			b.WriteString(valueStyle.Render("  Band 5: "))
+			b.WriteString(RenderResistorReadingBand(m.resistorReading, 5, m.palette))
+			b.WriteString("\n")
+		}
+		if m.resistorReading.BandCount == 6 {
+			b.WriteString(valueStyle.Render("  Band 6: "))
+			b.WriteString(RenderResistorReadingBand(m.resistorReading, 6, m.palette))
+			b.WriteString("\n")
+		}
+	}
+
+	b.WriteString("\n")
+	b.WriteString(RenderSeparator(contentWidth(m.width)))
+	b.WriteString("\n\n")
+
+	if len(m.reviewProblems) > 0 {
+		b.WriteString(errorStyle.Render(fmt.Sprintf("✗ %d problem(s) must be fixed before calculating:", len(m.reviewProblems))))
+		b.WriteString("\n")
+		for _, problem := range m.reviewProblems {
+			b.WriteString(errorStyle.Render("  • " + problem.Error()))
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString(promptStyle.Render(m.reviewPromptRow().text))
+	b.WriteString("\n")
+
+	if m.err != nil {
+		b.WriteString("\n")
+		b.WriteString(errorStyle.Render("✗ " + m.err.Error()))
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+// reviewPromptRow returns the review actions, leading with a fix for the
+// first bad band when there are problems and calculate otherwise
+func (m model) reviewPromptRow() clickRow {
+	first := actionOption{ActionContinue, "calculate"}
+	if len(m.reviewProblems) > 0 {
+		first = actionOption{ActionFix, fmt.Sprintf("Fix band %d", m.reviewProblems[0].BandNumber)}
+	}
+	return m.keys.promptRow(first,
+		actionOption{ActionCorrect, "Correct a band"},
+		actionOption{ActionBack, "Back"},
+		actionOption{ActionQuit, "Quit"})
+}
+
+func (m model) renderResults() string {
+	if m.capacitorResult == nil && m.resistorResult == nil {
+		return errorStyle.Render("\n✗ No calculation results available\n")
+	}
+
+	var b strings.Builder
+	entry := m.currentEntry()
+
+	var strip string
+	if m.componentType == decoder.ComponentResistor && m.resistorResult != nil {
+		strip = RenderBandStrip(m.resistorResult.Reading.Colors())
+	} else if m.capacitorResult != nil {
+		strip = RenderBandStrip(m.capacitorResult.Reading.Colors())
+	}
+	if strip != "" {
+		b.WriteString("\n")
+		b.WriteString(strip)
+		b.WriteString("\n")
+	}
+
+	if m.compact {
+		b.WriteString("\n")
+		b.WriteString(resultValueStyle.Render(RenderCompactResult(entry)))
+		b.WriteString("\n\n")
+	} else {
+		b.WriteString(RenderResultsBox(m.capacitorResult, m.resistorResult, ResultsView{
+			ShowBaseUnit:    m.showBaseUnit,
+			CapacitanceUnit: m.capacitanceUnit,
+			ResistanceUnit:  m.resistanceUnit,
+			FrequencyHz:     m.frequencyHz,
+			Note:            m.currentNote,
+			Width:           contentWidth(m.width),
+		}))
+		b.WriteString("\n")
+	}
+
+	if m.showWorking {
+		steps := decoder.ExplainCapacitor(m.capacitorResult)
+		if m.componentType == decoder.ComponentResistor {
+			steps = decoder.ExplainResistor(m.resistorResult)
+		}
+		b.WriteString(labelStyle.Render("WORKING:"))
+		b.WriteString("\n")
+		for _, step := range steps {
+			b.WriteString(valueStyle.Render("  " + step))
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+	}
+
+	if m.hasMeasurement {
+		b.WriteString(resultLabelStyle.Render("Measured:"))
+		b.WriteString("  ")
+		b.WriteString(resultValueStyle.Render(FormatMeasuredValue(entry, m.currentMeasured)))
+		b.WriteString("  ")
+		if within, _ := WithinTolerance(entry, m.currentMeasured); within {
+			b.WriteString(successStyle.Render("✓ within tolerance"))
+		} else {
+			b.WriteString(errorStyle.Render("✗ out of tolerance"))
+		}
+		b.WriteString("\n\n")
+	}
+
+	if m.specTarget != "" {
+		target, tolPct, _ := ParseSpec(entry, m.specTarget)
+		if meets, why, ok := CheckSpec(entry, target, tolPct); ok {
+			b.WriteString(resultLabelStyle.Render("Target " + m.specTarget + ":"))
+			b.WriteString("  ")
+			if meets {
+				b.WriteString(successStyle.Render("✓ meets spec"))
+			} else {
+				b.WriteString(errorStyle.Render("✗ does not meet spec"))
+			}
+			b.WriteString("\n")
+			b.WriteString(mutedStyle.Render("  " + why))
+			b.WriteString("\n\n")
+		}
+	}
+
+	var powerWarning string
+	if m.operatingPoint != "" && m.resistorResult != nil {
+		volts, amps, _ := decoder.ParseOperatingPoint(m.operatingPoint)
+		if watts, rating, err := decoder.ResistorPower(m.resistorResult.ResistanceOhms, volts, amps); err == nil {
+			b.WriteString(resultLabelStyle.Render("Power @ " + m.operatingPoint + ":"))
+			b.WriteString("  ")
+			b.WriteString(resultValueStyle.Render(decoder.FormatPower(watts)))
+			b.WriteString("  ")
+			if rating > 0 {
+				b.WriteString(mutedStyle.Render("use a " + decoder.FormatPowerRating(rating) + " part or larger"))
+			} else {
+				b.WriteString(mutedStyle.Render("above 5 W, use a power resistor"))
+			}
+			b.WriteString("\n\n")
+			if watts > decoder.TypicalPowerRating {
+				powerWarning = fmt.Sprintf("Dissipates %s, more than a typical 1/4 W part", decoder.FormatPower(watts))
+			}
+		}
+	}
+
+	// Advisory only: the value is still shown and can be saved
+	warnings := PlausibilityCheck(entry, m.palette)
+	if powerWarning != "" {
+		warnings = append(warnings, powerWarning)
+	}
+	if m.componentType == decoder.ComponentResistor {
+		if warning := ReversedReadingWarning(m.resistorReading); warning != "" {
+			warnings = append(warnings, warning)
+		}
+	}
+	if len(warnings) > 0 {
+		for _, warning := range warnings {
+			b.WriteString(warningStyle.Render("⚠ " + warning))
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+	}
+
+	// Show export progress and success message
+	if m.exporting {
+		b.WriteString(m.spinner.View() + " " + mutedStyle.Render(fmt.Sprintf("Exporting %d component(s) to %s…", len(m.history), m.selectedFile)))
+		b.WriteString("\n\n")
+	} else if m.successMsg != "" {
+		b.WriteString(successStyle.Render(m.successMsg))
+		b.WriteString("\n\n")
+	}
+
+	// Show export, reverse and copy errors
+	if m.err != nil {
+		if strings.Contains(m.err.Error(), "export") || strings.Contains(m.err.Error(), "reverse") ||
+			strings.Contains(m.err.Error(), "copy") || strings.Contains(m.err.Error(), "favorites") {
+			b.WriteString(errorStyle.Render("✗ " + m.err.Error()))
+			b.WriteString("\n\n")
+		}
+	}
+
+	if m.reversed {
+		b.WriteString(warningStyle.Render("↔ Bands read in reverse (right to left from how they were entered)"))
+	} else {
+		b.WriteString(mutedStyle.Render("Bands read as entered (left to right); press " + strings.ToUpper(m.keys.Describe(ActionReverse)) + " to reverse"))
+	}
+	b.WriteString("\n\n")
+
+	for _, row := range m.resultsPromptRows() {
+		b.WriteString(promptStyle.Render(row.text))
+		b.WriteString("\n")
+	}
+	historyLine := fmt.Sprintf("Decoded components in history: %d", len(m.history))
+	if m.historyTrimmed > 0 {
+		historyLine += fmt.Sprintf(" (%d oldest trimmed, limit %d)", m.historyTrimmed, m.historyLimit)
+	}
+	b.WriteString(mutedStyle.Render(historyLine))
+	b.WriteString("\n")
+	// Combined values, for building a bank from several parts
+	totals := ComputeHistoryTotals(m.history)
+	if totals.Capacitors >= 2 {
+		series, seriesUnit := decoder.ScaleCapacitance(totals.SeriesPF)
+		parallel, parallelUnit := decoder.ScaleCapacitance(totals.ParallelPF)
+		b.WriteString(mutedStyle.Render(fmt.Sprintf("Σ %d capacitors: series %s, parallel %s",
+			totals.Capacitors, decoder.FormatCapacitance(series, seriesUnit), decoder.FormatCapacitance(parallel, parallelUnit))))
+		b.WriteString("\n")
+	}
+	if totals.Resistors >= 2 {
+		series, seriesUnit := decoder.ScaleResistance(totals.SeriesOhms)
+		parallel, parallelUnit := decoder.ScaleResistance(totals.ParallelOhms)
+		b.WriteString(mutedStyle.Render(fmt.Sprintf("Σ %d resistors: series %s, parallel %s",
+			totals.Resistors, decoder.FormatResistance(series, seriesUnit), decoder.FormatResistance(parallel, parallelUnit))))
+		b.WriteString("\n")
+	}
+	// RC filter of the latest resistor and capacitor
+	if ohms, pF, ok := LatestRCPair(m.history); ok {
+		farads := pF * 1e-12
+		b.WriteString(mutedStyle.Render(fmt.Sprintf("RC of %s and %s: τ = %s, cutoff %s",
+			decoder.FormatResistance(decoder.ScaleResistance(ohms)), decoder.FormatCapacitance(decoder.ScaleCapacitance(pF)),
+			FormatTimeConstant(RCTimeConstant(ohms, farads)), FormatCutoffFrequency(RCCutoffFrequency(ohms, farads)))))
+		b.WriteString("\n")
+	}
+	if m.historyFullUnexported() {
+		b.WriteString(warningStyle.Render(fmt.Sprintf("⚠ History is full (%d); adding another entry drops the oldest unexported one. Press %s to export first.",
+			m.historyLimit, strings.ToUpper(m.keys.Describe(ActionExport)))))
+		b.WriteString("\n")
+	}
+	b.WriteString(RenderSeparator(contentWidth(m.width)))
+	b.WriteString("\n")
+
+	return b.String()
+}
+
+// resultsPromptRows returns the results screen actions, three lines of them
+func (m model) resultsPromptRows() []clickRow {
+	return []clickRow{
+		m.keys.promptRow(
+			actionOption{ActionDecode, "Decode"},
+			actionOption{ActionAgain, "Again"},
+			actionOption{ActionEdit, "Edit"},
+			actionOption{ActionNote, "Note"},
+			actionOption{ActionExport, "export"},
+			actionOption{ActionQuit, "Quit"}),
+		m.keys.promptRow(
+			actionOption{ActionUnits, "Units"},
+			actionOption{ActionLockUnit, "Lock unit"},
+			actionOption{ActionFrequency, "Freq"},
+			actionOption{ActionReverse, "Reverse"},
+			actionOption{ActionCompact, "Compact"},
+			actionOption{ActionHistory, "History"},
+			actionOption{ActionWorking, "Working"},
+			actionOption{ActionCopy, "copy"}),
+		m.keys.promptRow(
+			actionOption{ActionMeasure, "Value measured"},
+			actionOption{ActionBOM, "BOM row"},
+			actionOption{ActionBOMExport, "BOM export"},
+			actionOption{ActionPin, "Pin"},
+			actionOption{ActionPower, "power"},
+			actionOption{ActionCombine, "Total"},
+			actionOption{ActionDivider, "divider"},
+			actionOption{ActionSpec, "target"}),
+	}
+}
+
+func (m model) renderEdit() string {
+	var b strings.Builder
+
+	b.WriteString("\n")
+	b.WriteString(headerStyle.Render(" EDIT BAND "))
+	b.WriteString("\n\n")
+
+	b.WriteString(valueStyle.Render("Which band do you want to correct?"))
+	b.WriteString("\n\n")
+
+	var bandCount int
+	// Show all bands
+	if m.componentType == decoder.ComponentCapacitor {
+		bandCount = m.capacitorReading.BandCount
+		for i := 1; i <= bandCount; i++ {
+			var color decoder.Color
+			switch i {
+			case 1:
+				color = m.capacitorReading.Band1
+			case 2:
+				color = m.capacitorReading.Band2
+			case 3:
+				color = m.capacitorReading.Band3
+			case 4:
+				color = m.capacitorReading.Band4
+			case 5:
+				color = m.capacitorReading.Band5
+			}
+
+			b.WriteString(valueStyle.Render(fmt.Sprintf("  %d = ", i)))
+			b.WriteString(RenderColorBand(color, i, m.palette))
+			b.WriteString("\n")
+		}
+	} else {
+		bandCount = m.resistorReading.BandCount
+		for i := 1; i <= bandCount; i++ {
+			b.WriteString(valueStyle.Render(fmt.Sprintf("  %d = ", i)))
+			b.WriteString(RenderResistorReadingBand(m.resistorReading, i, m.palette))
+			b.WriteString("\n")
+		}
+	}
+
+	b.WriteString(valueStyle.Render("  " + m.editCancelRow().text))
+	b.WriteString("\n\n")
+
+	b.WriteString(promptStyle.Render(fmt.Sprintf("Press 1-%d to select band, or %s to cancel", bandCount, strings.ToUpper(m.keys.Describe(ActionQuit)))))
+	b.WriteString("\n")
+
+	if m.err != nil {
+		b.WriteString("\n")
+		b.WriteString(errorStyle.Render("✗ " + m.err.Error()))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(helpStyle.Render("Press Ctrl+C to quit"))
+	b.WriteString("\n")
+
+	return b.String()
+}
+
+// editCancelRow returns the clickable line that leaves the edit list
+func (m model) editCancelRow() clickRow {
+	return clickRow{
+		text:    strings.ToUpper(m.keys.Describe(ActionQuit)) + " = Cancel (keep current values)",
+		actions: []Action{ActionQuit},
+	}
+}
+
+func (m model) renderNoteInput() string {
+	var b strings.Builder
+
+	b.WriteString("\n")
+	b.WriteString(headerStyle.Render(" ADD / EDIT NOTE "))
+	b.WriteString("\n\n")
+
+	componentName := "capacitor"
+	if m.componentType == decoder.ComponentResistor {
+		componentName = "resistor"
+	}
+	b.WriteString(valueStyle.Render(fmt.Sprintf("Add a note to this %s reading (max 200 characters):", componentName)))
+	b.WriteString("\n\n")
+
+	b.WriteString(promptStyle.Render("Note: "))
+	b.WriteString(inputStyle.Render(m.input))
+	b.WriteString("\n")
+
+	// Character count
+	charCount := len(m.input)
+	charCountStr := fmt.Sprintf("%d/200 characters", charCount)
+	if charCount > 200 {
+		b.WriteString(errorStyle.Render(charCountStr))
+	} else if charCount > 180 {
+		b.WriteString(mutedStyle.Render(charCountStr))
+	} else {
+		b.WriteString(mutedStyle.Render(charCountStr))
+	}
+	b.WriteString("\n\n")
+
+	b.WriteString(helpStyle.Render("Press ENTER to save, ESC to cancel, Ctrl+C to quit"))
+	b.WriteString("\n")
+
+	if m.err != nil {
+		b.WriteString("\n")
+		b.WriteString(errorStyle.Render("✗ " + m.err.Error()))
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+func (m model) renderFilePicker() string {
+	var b strings.Builder
+
+	b.WriteString("\n")
+	if m.exportBOM {
+		b.WriteString(headerStyle.Render(" EXPORT BOM - SELECT FILE LOCATION "))
+		b.WriteString("\n\n")
+	} else {
+		b.WriteString(headerStyle.Render(" EXPORT HISTORY (CSV, JSON OR MARKDOWN) - SELECT FILE LOCATION "))
+		b.WriteString("\n\n")
+		toggle := m.keys.Describe(ActionAggregate)
+		if m.exportAggregate {
+			b.WriteString(valueStyle.Render("Mode: identical parts grouped with a Qty count"))
+			b.WriteString(mutedStyle.Render("  (" + toggle + ": one row per decode)"))
+		} else {
+			b.WriteString(valueStyle.Render("Mode: one row per decode"))
+			b.WriteString(mutedStyle.Render("  (" + toggle + ": group identical parts)"))
+		}
+		b.WriteString("\n\n")
+	}
+
+	if m.exportPreview != nil {
+		b.WriteString(m.renderExportPreview())
+	} else if m.exportPending != "" {
+		b.WriteString(valueStyle.Render(filepath.Base(m.exportPending) + " already exists."))
+		b.WriteString("\n\n")
+		if m.exportCanAppend {
+			b.WriteString(fmt.Sprintf("  (%s) Append rows after the existing ones\n", strings.ToUpper(m.keys.Describe(ActionAppend))))
+		} else {
+			b.WriteString(mutedStyle.Render("  Its columns differ from this export, so rows can't be appended."))
+			b.WriteString("\n")
+		}
+		b.WriteString(fmt.Sprintf("  (%s) Overwrite it\n\n", strings.ToUpper(m.keys.Describe(ActionOverwrite))))
+		b.WriteString(helpStyle.Render("ESC: Choose another file  |  Ctrl+C: Quit"))
+		b.WriteString("\n")
+	} else {
+		b.WriteString(m.filepicker.View())
+		b.WriteString("\n")
+
+		b.WriteString(helpStyle.Render("Navigate: ↑/↓/←/→  |  ENTER: Select  |  q: Cancel  |  Ctrl+C: Quit"))
+		b.WriteString("\n")
 	}
 
-	return "Unknown screen\n"
+	if m.err != nil {
+		b.WriteString("\n")
+		b.WriteString(errorStyle.Render("✗ " + m.err.Error()))
+		b.WriteString("\n")
+	}
+
+	return b.String()
 }
 
-func (m model) renderWelcome() string {
+func (m model) renderReference() string {
 	var b strings.Builder
 
 	b.WriteString("\n")
-	b.WriteString(headerStyle.Render("═══════════════════════════════════════════════════════════════"))
+	b.WriteString(headerStyle.Render(" COLOR CODE REFERENCE "))
+	b.WriteString("\n\n")
+
+	lines := ReferenceChartLines(m.palette)
+	start := m.scrollOffset
+	end := start + m.referenceVisibleLines()
+	if end > len(lines) {
+		end = len(lines)
+	}
+	if start > end {
+		start = end
+	}
+
+	for _, line := range lines[start:end] {
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+
 	b.WriteString("\n")
-	b.WriteString(titleStyle.Render("    TROPICAL FISH COMPONENT COLOR CODE DECODER"))
+	b.WriteString(mutedStyle.Render("(C) = capacitor, (R) = resistor"))
 	b.WriteString("\n")
-	b.WriteString(subtitleStyle.Render("            Welcome Screen"))
+	if m.err != nil {
+		b.WriteString(errorStyle.Render("✗ " + m.err.Error()))
+		b.WriteString("\n")
+	} else if m.successMsg != "" {
+		b.WriteString(successStyle.Render(m.successMsg))
+		b.WriteString("\n")
+	}
+	if end-start < len(lines) {
+		b.WriteString(helpStyle.Render(fmt.Sprintf("Lines %d-%d of %d  |  ↑/↓: Scroll  |  X: Save HTML  |  Q/ESC: Back", start+1, end, len(lines))))
+	} else {
+		b.WriteString(helpStyle.Render("Press X to save as HTML, Q or ESC to go back, Ctrl+C to quit"))
+	}
 	b.WriteString("\n")
-	b.WriteString(headerStyle.Render("═══════════════════════════════════════════════════════════════"))
-	b.WriteString("\n\n")
 
-	b.WriteString(valueStyle.Render("Decode color bands from capacitors and resistors to determine"))
+	return b.String()
+}
+
+func (m model) renderFrequencyInput() string {
+	var b strings.Builder
+
 	b.WriteString("\n")
-	b.WriteString(valueStyle.Render("values, tolerances, and ratings."))
+	b.WriteString(headerStyle.Render(" SET DESIGN FREQUENCY "))
 	b.WriteString("\n\n")
 
-	b.WriteString(labelStyle.Render("Supported components:"))
+	b.WriteString(valueStyle.Render("Capacitive reactance is shown and exported at this frequency."))
 	b.WriteString("\n")
-	b.WriteString(valueStyle.Render("  • Capacitors - IEC 60062 Standard (3/4/5 bands)"))
-	b.WriteString("\n")
-	b.WriteString(valueStyle.Render("  • Resistors - EIA Standard (4/5/6 bands)"))
+	b.WriteString(mutedStyle.Render("Examples: 1000, 10k, 1.5 MHz  (leave empty to clear)"))
 	b.WriteString("\n\n")
 
-	b.WriteString(RenderSeparator(64))
+	b.WriteString(promptStyle.Render("Frequency: "))
+	b.WriteString(inputStyle.Render(m.input))
 	b.WriteString("\n\n")
 
-	b.WriteString(promptStyle.Render("Press ENTER to begin, or Q to quit"))
+	b.WriteString(helpStyle.Render("Press ENTER to save, ESC to cancel, Ctrl+C to quit"))
 	b.WriteString("\n")
 
+	if m.err != nil {
+		b.WriteString("\n")
+		b.WriteString(errorStyle.Render("✗ " + m.err.Error()))
+		b.WriteString("\n")
+	}
+
 	return b.String()
 }
 
-func (m model) renderComponentSelection() string {
+func (m model) renderCapacitanceLookup() string {
 	var b strings.Builder
 
 	b.WriteString("\n")
-	b.WriteString(headerStyle.Render(" STEP 1: SELECT COMPONENT TYPE "))
+	b.WriteString(headerStyle.Render(" CAPACITOR VALUE LOOKUP "))
 	b.WriteString("\n\n")
 
-	b.WriteString(valueStyle.Render("What would you like to decode?"))
+	b.WriteString(valueStyle.Render("Enter a target capacitance to find the nearest standard (E12) part."))
+	b.WriteString("\n")
+	b.WriteString(mutedStyle.Render("Examples: 27n, 4.7uF, 100nF, 33 (pF)"))
 	b.WriteString("\n\n")
 
-	b.WriteString(valueStyle.Render("  (C) Capacitor - IEC 60062 Standard (3/4/5 bands)"))
-	b.WriteString("\n")
-	b.WriteString(valueStyle.Render("  (R) Resistor - EIA Standard (4/5/6 bands)"))
+	b.WriteString(promptStyle.Render("Capacitance: "))
+	b.WriteString(inputStyle.Render(m.input))
 	b.WriteString("\n\n")
 
-	b.WriteString(promptStyle.Render("Press C for Capacitor, R for Resistor, or Q to quit"))
+	if m.lookup != nil {
+		lookup := m.lookup
+
+		b.WriteString(resultLabelStyle.Render("Requested:"))
+		b.WriteString("  ")
+		b.WriteString(resultValueStyle.Render(decoder.FormatCapacitanceValue(lookup.RequestedPF)))
+		b.WriteString("\n")
+
+		b.WriteString(resultLabelStyle.Render("Nearest standard:"))
+		b.WriteString("  ")
+		b.WriteString(resultValueStyle.Render(decoder.FormatCapacitanceValue(lookup.StandardPF)))
+		b.WriteString("\n")
+
+		b.WriteString(resultLabelStyle.Render("Deviation:"))
+		b.WriteString("  ")
+		if math.Abs(lookup.DeviationPercent) < 0.005 {
+			b.WriteString(successStyle.Render("exact match"))
+		} else {
+			b.WriteString(warningStyle.Render(fmt.Sprintf("%+.2f%%", lookup.DeviationPercent)))
+		}
+		b.WriteString("\n")
+
+		b.WriteString(resultLabelStyle.Render("Marking code:"))
+		b.WriteString("  ")
+		b.WriteString(resultValueStyle.Render(lookup.Code))
+		b.WriteString("\n\n")
+
+		b.WriteString(labelStyle.Render("Bands:"))
+		b.WriteString("\n")
+		for i, color := range lookup.Bands {
+			b.WriteString(valueStyle.Render(fmt.Sprintf("  Band %d: ", i+1)))
+			b.WriteString(RenderColorBand(color, i+1, m.palette))
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString(helpStyle.Render("Press ENTER to look up, ESC to go back, Ctrl+C to quit"))
 	b.WriteString("\n")
 
 	if m.err != nil {
@@ -686,34 +3681,65 @@ func (m model) renderComponentSelection() string {
 		b.WriteString("\n")
 	}
 
-	b.WriteString("\n")
-	b.WriteString(helpStyle.Render("Press Ctrl+C to quit"))
-	b.WriteString("\n")
-
 	return b.String()
 }
 
-func (m model) renderTypeSelection() string {
+func (m model) renderReverseResistor() string {
 	var b strings.Builder
 
 	b.WriteString("\n")
-	b.WriteString(headerStyle.Render(" STEP 2: SELECT CAPACITOR TYPE "))
+	b.WriteString(headerStyle.Render(" RESISTOR BANDS FROM VALUE "))
 	b.WriteString("\n\n")
 
-	b.WriteString(valueStyle.Render("Available types:"))
-	b.WriteString("\n")
-	b.WriteString(valueStyle.Render("  J = Dipped Tantalum"))
-	b.WriteString("\n")
-	b.WriteString(valueStyle.Render("  K = Mica"))
-	b.WriteString("\n")
-	b.WriteString(valueStyle.Render("  L = Polyester / Polystyrene"))
+	b.WriteString(valueStyle.Render("Enter a resistance and tolerance to see which color bands to look for."))
 	b.WriteString("\n")
-	b.WriteString(valueStyle.Render("  M = Electrolytic (4-band)"))
+	b.WriteString(mutedStyle.Render("Examples: 4.7k 5%, 220 ±1%, 0.47 10%, 1M (±5% if omitted)"))
+	b.WriteString("\n\n")
+
+	b.WriteString(labelStyle.Render("Bands: "))
+	b.WriteString(valueStyle.Render(fmt.Sprint(m.reverseBandCount)))
+	b.WriteString(mutedStyle.Render("  (Tab to change)"))
 	b.WriteString("\n")
-	b.WriteString(valueStyle.Render("  N = Electrolytic (3-band)"))
+	b.WriteString(promptStyle.Render("Value: "))
+	b.WriteString(inputStyle.Render(m.input))
 	b.WriteString("\n\n")
 
-	b.WriteString(promptStyle.Render("Press J, K, L, M, N to select type, or Q to quit"))
+	if reading := m.bandsFromValue; reading != nil {
+		colors := []decoder.Color{reading.Band1, reading.Band2, reading.Band3, reading.Band4, reading.Band5, reading.Band6}
+		colors = colors[:reading.BandCount]
+
+		// The band strip as it appears on the part
+		for i, color := range colors {
+			if i > 0 {
+				b.WriteString(" ")
+			}
+			b.WriteString(renderSwatch(color, m.palette.ColorName(color)))
+		}
+		b.WriteString("\n\n")
+
+		if reading.BandCount == 1 {
+			b.WriteString(valueStyle.Render("Zero-ohm link: a single Black band"))
+			b.WriteString("\n\n")
+		} else {
+			for i, color := range colors {
+				b.WriteString(valueStyle.Render(fmt.Sprintf("  Band %d: ", i+1)))
+				b.WriteString(RenderResistorColorBand(color, i+1, reading.BandCount, m.palette))
+				b.WriteString("\n")
+			}
+			b.WriteString("\n")
+
+			// Representable values can still be unusual, e.g. not in E24
+			if result, err := decoder.CalculateResistor(*reading); err == nil {
+				for _, warning := range PlausibilityCheck(ComponentEntry{ComponentType: decoder.ComponentResistor, ResistorResult: result}, m.palette) {
+					b.WriteString(warningStyle.Render("⚠ " + warning))
+					b.WriteString("\n")
+				}
+				b.WriteString(renderAllMarkings(decoder.AllResistorReadingsFor(result.ResistanceOhms, reading.BandCount), m.palette))
+			}
+		}
+	}
+
+	b.WriteString(helpStyle.Render("Press ENTER to find bands, TAB to change band count, ESC to go back"))
 	b.WriteString("\n")
 
 	if m.err != nil {
@@ -722,60 +3748,61 @@ func (m model) renderTypeSelection() string {
 		b.WriteString("\n")
 	}
 
-	b.WriteString("\n")
-	b.WriteString(helpStyle.Render("Press Ctrl+C to quit"))
-	b.WriteString("\n")
-
 	return b.String()
 }
 
-func (m model) renderBandCountSelection() string {
+func (m model) renderReverseCapacitor() string {
 	var b strings.Builder
 
-	stepNum := "STEP 2"
-	if m.componentType == ComponentResistor {
-		stepNum = "STEP 2" // Still step 2 for resistors
-	} else {
-		stepNum = "STEP 3" // Step 3 for capacitors (after type selection)
-	}
+	b.WriteString("\n")
+	b.WriteString(headerStyle.Render(" CAPACITOR BANDS FROM VALUE "))
+	b.WriteString("\n\n")
 
+	b.WriteString(valueStyle.Render("Enter a capacitance to see which color bands encode it."))
 	b.WriteString("\n")
-	b.WriteString(headerStyle.Render(fmt.Sprintf(" %s: SELECT BAND COUNT ", stepNum)))
+	b.WriteString(mutedStyle.Render("Examples: 27nF, 100p, 4.7pF, 1µF N (type K if omitted)"))
 	b.WriteString("\n\n")
 
-	if m.componentType == ComponentCapacitor {
-		typeInfo, _ := GetTypeInfo(m.capacitorReading.CapType)
-		b.WriteString(labelStyle.Render("Capacitor Type: "))
-		b.WriteString(valueStyle.Render(string(m.capacitorReading.CapType) + " (" + typeInfo.Description + ")"))
-		b.WriteString("\n\n")
+	b.WriteString(labelStyle.Render("Bands: "))
+	b.WriteString(valueStyle.Render(fmt.Sprint(m.reverseBandCount)))
+	b.WriteString(mutedStyle.Render("  (Tab to change)"))
+	b.WriteString("\n")
+	b.WriteString(promptStyle.Render("Capacitance: "))
+	b.WriteString(inputStyle.Render(m.input))
+	b.WriteString("\n\n")
 
-		b.WriteString(valueStyle.Render("How many color bands does your capacitor have?"))
-		b.WriteString("\n")
-		b.WriteString(valueStyle.Render("  3 = 3-band (value + multiplier + tolerance)"))
-		b.WriteString("\n")
-		b.WriteString(valueStyle.Render("  4 = 4-band (value + multiplier + tolerance + voltage)"))
-		b.WriteString("\n")
-		b.WriteString(valueStyle.Render("  5 = 5-band (value + multiplier + tolerance + voltage + temp coeff)"))
-		b.WriteString("\n\n")
+	if reading := m.capBandsFromValue; reading != nil {
+		colors := []decoder.Color{reading.Band1, reading.Band2, reading.Band3}
 
-		b.WriteString(promptStyle.Render("Press 3, 4, or 5 to select band count, or Q to quit"))
-	} else if m.componentType == ComponentResistor {
-		b.WriteString(labelStyle.Render("Component: "))
-		b.WriteString(valueStyle.Render("Resistor"))
+		// The value bands as they appear on the part
+		for i, color := range colors {
+			if i > 0 {
+				b.WriteString(" ")
+			}
+			b.WriteString(renderSwatch(color, m.palette.ColorName(color)))
+		}
 		b.WriteString("\n\n")
 
-		b.WriteString(valueStyle.Render("How many color bands does your resistor have?"))
-		b.WriteString("\n")
-		b.WriteString(valueStyle.Render("  4 = 4-band (standard, ±5% or ±10% tolerance)"))
-		b.WriteString("\n")
-		b.WriteString(valueStyle.Render("  5 = 5-band (precision, ±1% or ±2% tolerance)"))
+		for i, color := range colors {
+			b.WriteString(valueStyle.Render(fmt.Sprintf("  Band %d: ", i+1)))
+			b.WriteString(RenderColorBand(color, i+1, m.palette))
+			b.WriteString("\n")
+		}
+		if reading.BandCount >= 4 {
+			b.WriteString(mutedStyle.Render("  Band 4: tolerance, as marked on the part"))
+			b.WriteString("\n")
+		}
+		if reading.BandCount == 5 && decoder.HasTempCoeffBand(reading.CapType) {
+			b.WriteString(mutedStyle.Render("  Band 5: voltage and temp coefficient, as marked on the part"))
+			b.WriteString("\n")
+		} else if reading.BandCount == 5 {
+			b.WriteString(mutedStyle.Render("  Band 5: voltage, as marked on the part"))
+			b.WriteString("\n")
+		}
 		b.WriteString("\n")
-		b.WriteString(valueStyle.Render("  6 = 6-band (precision + temperature coefficient)"))
-		b.WriteString("\n\n")
-
-		b.WriteString(promptStyle.Render("Press 4, 5, or 6 to select band count, or Q to quit"))
 	}
 
+	b.WriteString(helpStyle.Render("Press ENTER to find bands, TAB to change band count, ESC to go back"))
 	b.WriteString("\n")
 
 	if m.err != nil {
@@ -784,436 +3811,377 @@ func (m model) renderBandCountSelection() string {
 		b.WriteString("\n")
 	}
 
-	b.WriteString("\n")
-	b.WriteString(helpStyle.Render("Press Ctrl+C to quit"))
-	b.WriteString("\n")
-
 	return b.String()
 }
 
-func (m model) renderBandInput() string {
+func (m model) renderAbout() string {
 	var b strings.Builder
-
-	var bandCount int
-	var bandName string
-
-	if m.componentType == ComponentCapacitor {
-		bandCount = m.capacitorReading.BandCount
-		bandName = GetBandName(m.currentBand)
-	} else {
-		bandCount = m.resistorReading.BandCount
-		bandName = GetResistorBandName(m.currentBand, bandCount)
-	}
+	info := CurrentBuildInfo()
 
 	b.WriteString("\n")
-	b.WriteString(headerStyle.Render(fmt.Sprintf(" BAND INPUT (%d of %d) ", m.currentBand, bandCount)))
+	b.WriteString(headerStyle.Render(" ABOUT "))
 	b.WriteString("\n\n")
 
-	if m.componentType == ComponentCapacitor {
-		typeInfo, _ := GetTypeInfo(m.capacitorReading.CapType)
-		b.WriteString(labelStyle.Render("Type: "))
-		b.WriteString(valueStyle.Render(string(m.capacitorReading.CapType) + " (" + typeInfo.Name + ")"))
-		b.WriteString("  ")
-	} else {
-		b.WriteString(labelStyle.Render("Component: "))
-		b.WriteString(valueStyle.Render("Resistor"))
+	b.WriteString(titleStyle.Render("TROPICAL FISH COMPONENT COLOR CODE DECODER"))
+	b.WriteString("\n\n")
+
+	for _, row := range [][2]string{
+		{"Version:", info.Version},
+		{"Build date:", info.BuildDate},
+		{"Go version:", info.GoVersion},
+	} {
+		b.WriteString(resultLabelStyle.Render(row[0]))
 		b.WriteString("  ")
+		b.WriteString(resultValueStyle.Render(row[1]))
+		b.WriteString("\n")
 	}
+	b.WriteString("\n")
 
-	b.WriteString(labelStyle.Render("Bands: "))
-	b.WriteString(valueStyle.Render(fmt.Sprintf("%d", bandCount)))
+	b.WriteString(mutedStyle.Render(credits))
 	b.WriteString("\n\n")
 
-	b.WriteString(mutedStyle.Render("Valid colors: Black, Brown, Red, Orange, Yellow, Green, Blue,"))
+	b.WriteString(helpStyle.Render("Press any key to return"))
+	b.WriteString("\n")
+
+	return b.String()
+}
+
+func (m model) renderFavorites() string {
+	var b strings.Builder
+
 	b.WriteString("\n")
-	b.WriteString(mutedStyle.Render("              Violet, Grey, White, Gold, Silver"))
+	b.WriteString(headerStyle.Render(" FAVORITES "))
 	b.WriteString("\n\n")
 
-	// Show previously entered bands
-	if m.currentBand > 1 {
-		b.WriteString(labelStyle.Render("Already entered:"))
-		b.WriteString("\n")
-		for i := 1; i < m.currentBand; i++ {
-			var color Color
-			if m.componentType == ComponentCapacitor {
-				switch i {
-				case 1:
-					color = m.capacitorReading.Band1
-				case 2:
-					color = m.capacitorReading.Band2
-				case 3:
-					color = m.capacitorReading.Band3
-				case 4:
-					color = m.capacitorReading.Band4
-				case 5:
-					color = m.capacitorReading.Band5
-				}
+	if len(m.favorites) == 0 {
+		b.WriteString(mutedStyle.Render("No favorites yet. Press P on a result to pin it here."))
+		b.WriteString("\n\n")
+	} else {
+		for i, entry := range m.favorites {
+			line := RenderCompactResult(entry)
+			if entry.Note != "" {
+				line += "  " + mutedStyle.Render("("+entry.Note+")")
+			}
+			if i == m.favoriteCursor {
+				b.WriteString(successStyle.Render("▶ "))
 			} else {
-				switch i {
-				case 1:
-					color = m.resistorReading.Band1
-				case 2:
-					color = m.resistorReading.Band2
-				case 3:
-					color = m.resistorReading.Band3
-				case 4:
-					color = m.resistorReading.Band4
-				case 5:
-					color = m.resistorReading.Band5
-				case 6:
-					color = m.resistorReading.Band6
-				}
+				b.WriteString("  ")
 			}
-			b.WriteString(confirmStyle.Render(fmt.Sprintf("  ✓ Band %d: ", i)))
-			b.WriteString(RenderColorBand(color, i))
+			b.WriteString(line)
 			b.WriteString("\n")
 		}
 		b.WriteString("\n")
 	}
 
-	// Current band input
-	b.WriteString(bandHeaderStyle.Render(fmt.Sprintf("─ BAND %d (%s) ", m.currentBand, bandName)))
+	if m.err != nil {
+		b.WriteString(errorStyle.Render("✗ " + m.err.Error()))
+		b.WriteString("\n\n")
+	}
+
+	b.WriteString(helpStyle.Render("↑/↓: Select  |  ENTER: Add to history  |  P: Unpin  |  Q/ESC: Back"))
+	b.WriteString("\n")
+
+	return b.String()
+}
+
+func (m model) renderDivider() string {
+	var b strings.Builder
+
+	b.WriteString("\n")
+	b.WriteString(headerStyle.Render(" VOLTAGE DIVIDER "))
 	b.WriteString("\n\n")
 
-	b.WriteString(promptStyle.Render(fmt.Sprintf("Enter Band %d color: ", m.currentBand)))
-	b.WriteString(inputStyle.Render(m.input))
+	resistances := m.dividerResistances()
+	if len(resistances) < 2 {
+		b.WriteString(mutedStyle.Render("Decode at least two resistors to build a divider."))
+		b.WriteString("\n\n")
+		b.WriteString(helpStyle.Render("Press ESC to go back, Ctrl+C to quit"))
+		b.WriteString("\n")
+		return b.String()
+	}
 
-	// Show autocomplete suggestion in grey
-	if m.suggestion != "" {
-		b.WriteString(mutedStyle.Render(m.suggestion))
+	for i, index := range m.historyIndexes(decoder.ComponentResistor) {
+		b.WriteString(mutedStyle.Render(fmt.Sprintf("%2d. ", i+1)))
+		b.WriteString(RenderCompactResult(m.history[index]))
+		b.WriteString("\n")
 	}
 	b.WriteString("\n")
+	b.WriteString(valueStyle.Render("Vin ─ R1 ─┬─ Vout"))
+	b.WriteString("\n")
+	b.WriteString(valueStyle.Render("          R2"))
+	b.WriteString("\n")
+	b.WriteString(valueStyle.Render("          ┴ 0 V"))
+	b.WriteString("\n\n")
+
+	b.WriteString(promptStyle.Render("R1 R2 Vin: "))
+	b.WriteString(inputStyle.Render(m.input))
+	b.WriteString("\n\n")
 
-	if m.err != nil {
+	if r1, r2, vin, err := ParseDividerInput(m.input, resistances); err == nil {
+		vout, current := VoltageDivider(r1, r2, vin)
+		b.WriteString(resultLabelStyle.Render("Vout:     "))
+		b.WriteString(resultValueStyle.Render(FormatVolts(vout)))
 		b.WriteString("\n")
-		b.WriteString(errorStyle.Render("✗ " + m.err.Error()))
+		b.WriteString(resultLabelStyle.Render("Current:  "))
+		b.WriteString(resultValueStyle.Render(FormatCurrent(current)))
+		b.WriteString("\n")
+		if r1+r2 == 0 {
+			b.WriteString(warningStyle.Render("⚠ R1 and R2 are both 0 Ω: the divider shorts the input"))
+			b.WriteString("\n")
+		}
 		b.WriteString("\n")
 	}
 
-	b.WriteString("\n")
-
-	// Show hint for autocomplete
-	if m.suggestion != "" {
-		b.WriteString(helpStyle.Render("Press Tab to autocomplete, Enter to submit, Ctrl+C to quit"))
-	} else {
-		b.WriteString(helpStyle.Render("Press Enter to submit, Ctrl+C to quit"))
+	if m.err != nil {
+		b.WriteString(errorStyle.Render("✗ " + m.err.Error()))
+		b.WriteString("\n\n")
 	}
+
+	b.WriteString(helpStyle.Render("Type the numbers of R1 (top) and R2 (bottom) and Vin, e.g. 1 2 12V  |  ESC: Back"))
 	b.WriteString("\n")
 
 	return b.String()
 }
 
-func (m model) renderReview() string {
+func (m model) renderCombine() string {
 	var b strings.Builder
 
 	b.WriteString("\n")
-	b.WriteString(headerStyle.Render(" REVIEW YOUR INPUT "))
+	b.WriteString(headerStyle.Render(" SERIES AND PARALLEL "))
 	b.WriteString("\n\n")
 
-	if m.componentType == ComponentCapacitor {
-		typeInfo, _ := GetTypeInfo(m.capacitorReading.CapType)
-		b.WriteString(labelStyle.Render("Capacitor Type: "))
-		b.WriteString(valueStyle.Render(string(m.capacitorReading.CapType) + " (" + typeInfo.Description + ")"))
-		b.WriteString("\n")
-
-		b.WriteString(labelStyle.Render("Band Count: "))
-		b.WriteString(valueStyle.Render(fmt.Sprintf("%d", m.capacitorReading.BandCount)))
+	parts := "resistors"
+	if m.componentType == decoder.ComponentCapacitor {
+		parts = "capacitors"
+	}
+	entries := m.combineEntries()
+	if len(entries) < 2 {
+		b.WriteString(mutedStyle.Render("Decode at least two " + parts + " to combine them."))
 		b.WriteString("\n\n")
-
-		b.WriteString(labelStyle.Render("Bands entered:"))
-		b.WriteString("\n")
-		b.WriteString(valueStyle.Render("  Band 1: "))
-		b.WriteString(RenderColorBand(m.capacitorReading.Band1, 1))
-		b.WriteString("\n")
-		b.WriteString(valueStyle.Render("  Band 2: "))
-		b.WriteString(RenderColorBand(m.capacitorReading.Band2, 2))
-		b.WriteString("\n")
-		b.WriteString(valueStyle.Render("  Band 3: "))
-		b.WriteString(RenderColorBand(m.capacitorReading.Band3, 3))
+		b.WriteString(helpStyle.Render("Press Q or ESC to go back, Ctrl+C to quit"))
 		b.WriteString("\n")
-		b.WriteString(valueStyle.Render("  Band 4: "))
-		b.WriteString(RenderColorBand(m.capacitorReading.Band4, 4))
-		b.WriteString("\n")
-		if m.capacitorReading.BandCount >= 4 {
-			b.WriteString(valueStyle.Render("  Band 5: "))
-			b.WriteString(RenderColorBand(m.capacitorReading.Band5, 5))
-			b.WriteString("\n")
+		return b.String()
+	}
+
+	// Values in Ω or pF
+	var picked []float64
+	for i, index := range entries {
+		entry := m.history[index]
+		if i == m.combineCursor {
+			b.WriteString(successStyle.Render("▶ "))
+		} else {
+			b.WriteString("  ")
 		}
-	} else {
-		b.WriteString(labelStyle.Render("Component: "))
-		b.WriteString(valueStyle.Render("Resistor"))
+		if m.combineSelected[index] {
+			b.WriteString(successStyle.Render("[x] "))
+			if entry.ResistorResult != nil {
+				picked = append(picked, entry.ResistorResult.ResistanceOhms)
+			} else {
+				picked = append(picked, entry.CapacitorResult.CapacitancePF)
+			}
+		} else {
+			b.WriteString(mutedStyle.Render("[ ] "))
+		}
+		b.WriteString(RenderCompactResult(entry))
 		b.WriteString("\n")
+	}
+	b.WriteString("\n")
 
-		b.WriteString(labelStyle.Render("Band Count: "))
-		b.WriteString(valueStyle.Render(fmt.Sprintf("%d", m.resistorReading.BandCount)))
-		b.WriteString("\n\n")
-
-		b.WriteString(labelStyle.Render("Bands entered:"))
-		b.WriteString("\n")
-		b.WriteString(valueStyle.Render("  Band 1: "))
-		b.WriteString(RenderColorBand(m.resistorReading.Band1, 1))
+	combined := func(label string, value string, preferred string) {
+		b.WriteString(resultLabelStyle.Render(fmt.Sprintf("%-10s", label)))
+		b.WriteString(resultValueStyle.Render(value))
+		b.WriteString(mutedStyle.Render("  " + preferred))
 		b.WriteString("\n")
-		b.WriteString(valueStyle.Render("  Band 2: "))
-		b.WriteString(RenderColorBand(m.resistorReading.Band2, 2))
-		b.WriteString("\n")
-		b.WriteString(valueStyle.Render("  Band 3: "))
-		b.WriteString(RenderColorBand(m.resistorReading.Band3, 3))
-		b.WriteString("\n")
-		b.WriteString(valueStyle.Render("  Band 4: "))
-		b.WriteString(RenderColorBand(m.resistorReading.Band4, 4))
+	}
+	switch {
+	case len(picked) < 2:
+		b.WriteString(mutedStyle.Render(fmt.Sprintf("Pick two or more %s (%d picked).", parts, len(picked))))
 		b.WriteString("\n")
-		if m.resistorReading.BandCount >= 5 {
-			b.WriteString(valueStyle.Render("  Band 5: "))
-			b.WriteString(RenderColorBand(m.resistorReading.Band5, 5))
-			b.WriteString("\n")
+	case m.componentType == decoder.ComponentCapacitor:
+		for _, total := range []struct {
+			label string
+			pF    float64
+		}{
+			{"Series:", decoder.CombineCapacitorsSeries(picked)},
+			{"Parallel:", decoder.CombineCapacitorsParallel(picked)},
+		} {
+			value, unit := decoder.ScaleCapacitance(total.pF)
+			preferred, preferredUnit := decoder.ScaleCapacitance(decoder.NearestStandardCapacitance(total.pF))
+			combined(total.label, decoder.FormatCapacitanceWithUF(value, unit, total.pF),
+				"nearest E12: "+decoder.FormatCapacitance(preferred, preferredUnit))
 		}
-		if m.resistorReading.BandCount == 6 {
-			b.WriteString(valueStyle.Render("  Band 6: "))
-			b.WriteString(RenderColorBand(m.resistorReading.Band6, 6))
+	default:
+		for _, total := range []struct {
+			label string
+			ohms  float64
+		}{
+			{"Series:", decoder.CombineResistorsSeries(picked)},
+			{"Parallel:", decoder.CombineResistorsParallel(picked)},
+		} {
+			combined(total.label, decoder.FormatResistance(decoder.ScaleResistance(total.ohms)),
+				"nearest E24: "+decoder.FormatResistance(decoder.ScaleResistance(decoder.NearestPreferredValue(total.ohms, decoder.E24Series))))
+		}
+		if slices.Contains(picked, 0) {
+			b.WriteString(warningStyle.Render("⚠ A 0 Ω part shorts the others in parallel"))
 			b.WriteString("\n")
 		}
 	}
-
 	b.WriteString("\n")
-	b.WriteString(RenderSeparator(64))
-	b.WriteString("\n\n")
 
-	b.WriteString(promptStyle.Render("Press ENTER to calculate, (C)orrect a band, or (Q)uit"))
+	b.WriteString(helpStyle.Render("↑/↓: Select  |  ENTER: Pick / drop  |  Q/ESC: Back"))
 	b.WriteString("\n")
 
-	if m.err != nil {
-		b.WriteString("\n")
-		b.WriteString(errorStyle.Render("✗ " + m.err.Error()))
-		b.WriteString("\n")
-	}
-
 	return b.String()
 }
 
-func (m model) renderResults() string {
-	if m.capacitorResult == nil && m.resistorResult == nil {
-		return errorStyle.Render("\n✗ No calculation results available\n")
-	}
-
+func (m model) renderHistory() string {
 	var b strings.Builder
 
 	b.WriteString("\n")
-	b.WriteString(resultHeaderStyle.Width(64).Render("RESULTS"))
-	b.WriteString("\n")
-	b.WriteString(resultHeaderStyle.Width(64).Render("════════════════════════════════════════════════════════════════"))
+	b.WriteString(headerStyle.Render(" HISTORY "))
 	b.WriteString("\n\n")
 
-	if m.componentType == ComponentCapacitor && m.capacitorResult != nil {
-		result := m.capacitorResult
-		typeInfo, _ := GetTypeInfo(result.Reading.CapType)
-
-		// Type and configuration
-		b.WriteString(resultLabelStyle.Render("Component Type:"))
-		b.WriteString("  ")
-		b.WriteString(resultValueStyle.Render("Capacitor"))
-		b.WriteString("\n")
-
-		b.WriteString(resultLabelStyle.Render("Capacitor Type:"))
-		b.WriteString("  ")
-		b.WriteString(resultValueStyle.Render(string(result.Reading.CapType) + " (" + typeInfo.Name + ")"))
-		b.WriteString("\n")
-
-		b.WriteString(resultLabelStyle.Render("Configuration:"))
-		b.WriteString("  ")
-		b.WriteString(resultValueStyle.Render(fmt.Sprintf("%d-band", result.Reading.BandCount)))
+	if len(m.history) == 0 {
+		b.WriteString(mutedStyle.Render("Nothing decoded yet. Decoded components are listed here."))
 		b.WriteString("\n\n")
-
-		// Capacitance value
-		b.WriteString(labelStyle.Render("CAPACITANCE VALUE:"))
+		b.WriteString(helpStyle.Render("Press Q or ESC to go back, Ctrl+C to quit"))
 		b.WriteString("\n")
-		b.WriteString(resultLabelStyle.Render("Value:"))
-		b.WriteString("  ")
-		b.WriteString(resultValueStyle.Render(FormatCapacitanceWithUF(result.CapacitanceValue, result.CapacitanceUnit, result.CapacitancePF)))
-		b.WriteString("\n\n")
+		return b.String()
+	}
 
-		// Tolerance
-		b.WriteString(labelStyle.Render("TOLERANCE:"))
+	if m.historySummary {
+		b.WriteString(subtitleStyle.Render("Summary"))
 		b.WriteString("\n")
-		b.WriteString(resultLabelStyle.Render("Specification:"))
-		b.WriteString("  ")
-		tolStr := FormatTolerance(result)
-		if result.ToleranceType == "absolute" {
-			tolStr += " (absolute, value ≤ 10pF)"
-		} else {
-			tolStr += " (percentage-based, value > 10pF)"
+		for _, line := range HistorySummaryLines(SummarizeHistory(m.history)) {
+			b.WriteString(valueStyle.Render(line))
+			b.WriteString("\n")
 		}
-		b.WriteString(resultValueStyle.Render(tolStr))
 		b.WriteString("\n")
+		b.WriteString(helpStyle.Render("S: Back to the list  |  Q/ESC: Back"))
+		b.WriteString("\n")
+		return b.String()
+	}
 
-		b.WriteString(resultLabelStyle.Render("Range:"))
-		b.WriteString("  ")
-		b.WriteString(resultValueStyle.Render(FormatToleranceRange(result)))
-		b.WriteString("\n\n")
+	start := m.scrollOffset
+	end := start + m.historyVisibleLines()
+	if end > len(m.history) {
+		end = len(m.history)
+	}
+	if start > end {
+		start = end
+	}
 
-		// Voltage rating
-		if result.VoltageValid {
-			b.WriteString(labelStyle.Render("VOLTAGE RATING:"))
-			b.WriteString("\n")
-			b.WriteString(resultLabelStyle.Render("Voltage:"))
+	// Numbered from the oldest so entries keep their number while scrolling
+	width := len(fmt.Sprint(len(m.history)))
+	for i, entry := range m.history[start:end] {
+		if start+i == m.historyCursor {
+			b.WriteString(successStyle.Render("▶ "))
+		} else {
 			b.WriteString("  ")
-			b.WriteString(resultValueStyle.Render(FormatVoltage(result) + " (Type " + string(result.Reading.CapType) + " " + typeInfo.Name + ")"))
-			b.WriteString("\n\n")
 		}
-
-		// Temperature coefficient
-		if result.TempCoeffValid {
-			b.WriteString(labelStyle.Render("TEMPERATURE COEFFICIENT:"))
-			b.WriteString("\n")
-			b.WriteString(resultLabelStyle.Render("Coefficient:"))
-			b.WriteString("  ")
-			b.WriteString(resultValueStyle.Render(FormatTempCoefficient(result)))
-			b.WriteString("\n\n")
+		b.WriteString(mutedStyle.Render(fmt.Sprintf("%*d. ", width, start+i+1)))
+		b.WriteString(RenderCompactResult(entry))
+		if entry.Quantity > 1 {
+			b.WriteString(mutedStyle.Render(fmt.Sprintf("  ×%d", entry.Quantity)))
 		}
-	} else if m.componentType == ComponentResistor && m.resistorResult != nil {
-		result := m.resistorResult
-
-		// Type and configuration
-		b.WriteString(resultLabelStyle.Render("Component Type:"))
-		b.WriteString("  ")
-		b.WriteString(resultValueStyle.Render("Resistor"))
-		b.WriteString("\n")
-
-		b.WriteString(resultLabelStyle.Render("Configuration:"))
-		b.WriteString("  ")
-		b.WriteString(resultValueStyle.Render(fmt.Sprintf("%d-band", result.Reading.BandCount)))
-		b.WriteString("\n\n")
-
-		// Resistance value
-		b.WriteString(labelStyle.Render("RESISTANCE VALUE:"))
 		b.WriteString("\n")
-		b.WriteString(resultLabelStyle.Render("Value:"))
-		b.WriteString("  ")
-		b.WriteString(resultValueStyle.Render(FormatResistance(result.ResistanceValue, result.ResistanceUnit)))
-		b.WriteString("\n\n")
+	}
+	b.WriteString("\n")
 
-		// Tolerance
-		b.WriteString(labelStyle.Render("TOLERANCE:"))
+	if m.err != nil {
+		b.WriteString(errorStyle.Render("✗ " + m.err.Error()))
 		b.WriteString("\n")
-		b.WriteString(resultLabelStyle.Render("Specification:"))
-		b.WriteString("  ")
-		b.WriteString(resultValueStyle.Render(FormatResistorTolerance(result)))
+	} else if m.successMsg != "" {
+		b.WriteString(successStyle.Render(m.successMsg))
 		b.WriteString("\n")
+	}
+	if end-start < len(m.history) {
+		b.WriteString(helpStyle.Render(fmt.Sprintf("Entries %d-%d of %d  |  ↑/↓: Select  |  E: Edit bands  |  P: Pin  |  S: Summary  |  Q/ESC: Back", start+1, end, len(m.history))))
+	} else {
+		b.WriteString(helpStyle.Render("↑/↓ to select, E to edit the bands of an entry, P to pin or unpin it, S for a summary, Q or ESC to go back, Ctrl+C to quit"))
+	}
+	b.WriteString("\n")
 
-		b.WriteString(resultLabelStyle.Render("Range:"))
-		b.WriteString("  ")
-		b.WriteString(resultValueStyle.Render(FormatResistorToleranceRange(result)))
-		b.WriteString("\n\n")
+	return b.String()
+}
 
-		// Temperature coefficient (6-band only)
-		if result.TempCoeffValid {
-			b.WriteString(labelStyle.Render("TEMPERATURE COEFFICIENT:"))
-			b.WriteString("\n")
-			b.WriteString(resultLabelStyle.Render("Coefficient:"))
-			b.WriteString("  ")
-			b.WriteString(resultValueStyle.Render(FormatResistorTempCoefficient(result)))
-			b.WriteString("\n\n")
+// helpLines returns the help screen lines: the bindings of each group under
+// a group heading, with the keys from the active keymap
+func (m model) helpLines() []string {
+	var lines []string
+	for _, group := range helpGroups {
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, subtitleStyle.Render(group))
+		for _, binding := range defaultBindings {
+			if binding.group != group {
+				continue
+			}
+			lines = append(lines, labelStyle.Render(fmt.Sprintf("  %-16s", binding.action))+
+				resultValueStyle.Render(fmt.Sprintf("%-12s", m.keys.Describe(binding.action)))+
+				valueStyle.Render(binding.help))
 		}
 	}
+	return lines
+}
 
-	// Current note
-	if m.currentNote != "" {
-		b.WriteString(labelStyle.Render("NOTE:"))
-		b.WriteString("\n")
-		b.WriteString(resultLabelStyle.Render("User Note:"))
-		b.WriteString("  ")
-		b.WriteString(valueStyle.Render(m.currentNote))
-		b.WriteString("\n\n")
-	}
+func (m model) renderHelp() string {
+	var b strings.Builder
 
-	b.WriteString(resultHeaderStyle.Width(64).Render("════════════════════════════════════════════════════════════════"))
+	b.WriteString("\n")
+	b.WriteString(headerStyle.Render(" KEYBOARD SHORTCUTS "))
 	b.WriteString("\n\n")
 
-	// Show export success message
-	if m.successMsg != "" {
-		b.WriteString(successStyle.Render(m.successMsg))
-		b.WriteString("\n\n")
+	lines := m.helpLines()
+	start := m.helpOffset
+	end := start + m.helpVisibleLines()
+	if end > len(lines) {
+		end = len(lines)
 	}
-
-	// Show export error
-	if m.err != nil {
-		if strings.Contains(m.err.Error(), "export") {
-			b.WriteString(errorStyle.Render("✗ " + m.err.Error()))
-			b.WriteString("\n\n")
-		}
+	if start > end {
+		start = end
+	}
+	for _, line := range lines[start:end] {
+		b.WriteString(line)
+		b.WriteString("\n")
 	}
-
-	b.WriteString(promptStyle.Render("(D)ecode  |  (E)dit  |  (N)ote  |  e(X)port  |  (Q)uit"))
-	b.WriteString("\n")
-	b.WriteString(mutedStyle.Render(fmt.Sprintf("Decoded components in history: %d", len(m.history))))
 	b.WriteString("\n")
-	b.WriteString(RenderSeparator(64))
+
+	if m.configPath != "" {
+		b.WriteString(mutedStyle.Render("Rebind keys in the \"keymap\" section of " + m.configPath))
+		b.WriteString("\n")
+	}
+	help := m.keys.Describe(ActionHelp)
+	if end-start < len(lines) {
+		b.WriteString(helpStyle.Render(fmt.Sprintf("Lines %d-%d of %d  |  ↑/↓: Scroll  |  %s/ESC: Back", start+1, end, len(lines), help)))
+	} else {
+		b.WriteString(helpStyle.Render(fmt.Sprintf("Press %s or ESC to go back, Ctrl+C to quit", help)))
+	}
 	b.WriteString("\n")
 
 	return b.String()
 }
 
-func (m model) renderEdit() string {
+func (m model) renderBOMInput() string {
 	var b strings.Builder
 
 	b.WriteString("\n")
-	b.WriteString(headerStyle.Render(" EDIT BAND "))
+	b.WriteString(headerStyle.Render(" COPY BOM ROW "))
 	b.WriteString("\n\n")
 
-	b.WriteString(valueStyle.Render("Which band do you want to correct?"))
+	b.WriteString(valueStyle.Render("Enter the package and quantity (both optional), then press ENTER to"))
+	b.WriteString("\n")
+	b.WriteString(valueStyle.Render("copy a Value, Tolerance, Voltage/Power, Package, Quantity row."))
+	b.WriteString("\n")
+	b.WriteString(mutedStyle.Render("Examples: 0805 x4, TO-92, x10, axial"))
 	b.WriteString("\n\n")
 
-	var bandCount int
-	// Show all bands
-	if m.componentType == ComponentCapacitor {
-		bandCount = m.capacitorReading.BandCount
-		for i := 1; i <= bandCount; i++ {
-			var color Color
-			switch i {
-			case 1:
-				color = m.capacitorReading.Band1
-			case 2:
-				color = m.capacitorReading.Band2
-			case 3:
-				color = m.capacitorReading.Band3
-			case 4:
-				color = m.capacitorReading.Band4
-			case 5:
-				color = m.capacitorReading.Band5
-			}
-
-			b.WriteString(valueStyle.Render(fmt.Sprintf("  %d = ", i)))
-			b.WriteString(RenderColorBand(color, i))
-			b.WriteString("\n")
-		}
-	} else {
-		bandCount = m.resistorReading.BandCount
-		for i := 1; i <= bandCount; i++ {
-			var color Color
-			switch i {
-			case 1:
-				color = m.resistorReading.Band1
-			case 2:
-				color = m.resistorReading.Band2
-			case 3:
-				color = m.resistorReading.Band3
-			case 4:
-				color = m.resistorReading.Band4
-			case 5:
-				color = m.resistorReading.Band5
-			case 6:
-				color = m.resistorReading.Band6
-			}
-
-			b.WriteString(valueStyle.Render(fmt.Sprintf("  %d = ", i)))
-			b.WriteString(RenderColorBand(color, i))
-			b.WriteString("\n")
-		}
-	}
-
-	b.WriteString(valueStyle.Render("  Q = Cancel (keep current values)"))
+	b.WriteString(promptStyle.Render("Package / quantity: "))
+	b.WriteString(inputStyle.Render(m.input))
 	b.WriteString("\n\n")
 
-	b.WriteString(promptStyle.Render(fmt.Sprintf("Press 1-%d to select band, or Q to cancel", bandCount)))
+	b.WriteString(helpStyle.Render("Press ENTER to save and copy, ESC to cancel, Ctrl+C to quit"))
 	b.WriteString("\n")
 
 	if m.err != nil {
@@ -1222,41 +4190,59 @@ func (m model) renderEdit() string {
 		b.WriteString("\n")
 	}
 
-	b.WriteString("\n")
-	b.WriteString(helpStyle.Render("Press Ctrl+C to quit"))
-	b.WriteString("\n")
-
 	return b.String()
 }
 
-func (m model) renderNoteInput() string {
+func (m model) renderMeasuredInput() string {
 	var b strings.Builder
 
 	b.WriteString("\n")
-	b.WriteString(headerStyle.Render(" ADD / EDIT NOTE "))
+	b.WriteString(headerStyle.Render(" MEASURED VALUE "))
 	b.WriteString("\n\n")
 
-	componentName := "capacitor"
-	if m.componentType == ComponentResistor {
-		componentName = "resistor"
+	entry := m.currentEntry()
+	lo, hi, _ := toleranceBounds(entry)
+	b.WriteString(valueStyle.Render("Enter the value measured on your meter to log a pass/fail check."))
+	b.WriteString("\n")
+	b.WriteString(mutedStyle.Render("In tolerance: " + FormatMeasuredValue(entry, lo) + " – " + FormatMeasuredValue(entry, hi)))
+	b.WriteString("\n")
+	if m.componentType == decoder.ComponentCapacitor {
+		b.WriteString(mutedStyle.Render("Examples: 26.4n, 0.1µF, 470 (pF). Leave empty to clear."))
+	} else {
+		b.WriteString(mutedStyle.Render("Examples: 4.62k, 1M, 220 (Ω). Leave empty to clear."))
 	}
-	b.WriteString(valueStyle.Render(fmt.Sprintf("Add a note to this %s reading (max 200 characters):", componentName)))
 	b.WriteString("\n\n")
 
-	b.WriteString(promptStyle.Render("Note: "))
+	b.WriteString(promptStyle.Render("Measured: "))
 	b.WriteString(inputStyle.Render(m.input))
+	b.WriteString("\n\n")
+
+	b.WriteString(helpStyle.Render("Press ENTER to save, ESC to cancel, Ctrl+C to quit"))
 	b.WriteString("\n")
 
-	// Character count
-	charCount := len(m.input)
-	charCountStr := fmt.Sprintf("%d/200 characters", charCount)
-	if charCount > 200 {
-		b.WriteString(errorStyle.Render(charCountStr))
-	} else if charCount > 180 {
-		b.WriteString(mutedStyle.Render(charCountStr))
-	} else {
-		b.WriteString(mutedStyle.Render(charCountStr))
+	if m.err != nil {
+		b.WriteString("\n")
+		b.WriteString(errorStyle.Render("✗ " + m.err.Error()))
+		b.WriteString("\n")
 	}
+
+	return b.String()
+}
+
+func (m model) renderSpecInput() string {
+	var b strings.Builder
+
+	b.WriteString("\n")
+	b.WriteString(headerStyle.Render(" CHECK AGAINST A TARGET "))
+	b.WriteString("\n\n")
+
+	b.WriteString(valueStyle.Render("Enter the value you need and how far it may be off."))
+	b.WriteString("\n")
+	b.WriteString(mutedStyle.Render("The part passes only if its whole tolerance range fits. Examples: 4k7 1%, 100n ±10%. Leave empty to clear."))
+	b.WriteString("\n\n")
+
+	b.WriteString(promptStyle.Render("Target: "))
+	b.WriteString(inputStyle.Render(m.input))
 	b.WriteString("\n\n")
 
 	b.WriteString(helpStyle.Render("Press ENTER to save, ESC to cancel, Ctrl+C to quit"))
@@ -1271,17 +4257,23 @@ func (m model) renderNoteInput() string {
 	return b.String()
 }
 
-func (m model) renderFilePicker() string {
+func (m model) renderPowerInput() string {
 	var b strings.Builder
 
 	b.WriteString("\n")
-	b.WriteString(headerStyle.Render(" EXPORT TO CSV - SELECT FILE LOCATION "))
+	b.WriteString(headerStyle.Render(" POWER DISSIPATION "))
 	b.WriteString("\n\n")
 
-	b.WriteString(m.filepicker.View())
+	b.WriteString(valueStyle.Render("Enter the voltage across the resistor or the current through it."))
 	b.WriteString("\n")
+	b.WriteString(mutedStyle.Render("Examples: 5V, 3.3 V, 20mA. Leave empty to clear."))
+	b.WriteString("\n\n")
 
-	b.WriteString(helpStyle.Render("Navigate: ↑/↓/←/→  |  ENTER: Select  |  q: Cancel  |  Ctrl+C: Quit"))
+	b.WriteString(promptStyle.Render("Voltage or current: "))
+	b.WriteString(inputStyle.Render(m.input))
+	b.WriteString("\n\n")
+
+	b.WriteString(helpStyle.Render("Press ENTER to save, ESC to cancel, Ctrl+C to quit"))
 	b.WriteString("\n")
 
 	if m.err != nil {
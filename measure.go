@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"tropical-fish/decoder"
+)
+
+// ParseMeasuredValue parses a measurement for an entry, in pF for
+// capacitors and Ω for resistors
+func ParseMeasuredValue(entry ComponentEntry, input string) (float64, error) {
+	if entry.ComponentType == decoder.ComponentCapacitor {
+		return decoder.ParseCapacitance(input)
+	}
+	return decoder.ParseResistance(input)
+}
+
+// FormatMeasuredValue formats a measurement in the entry's units
+func FormatMeasuredValue(entry ComponentEntry, value float64) string {
+	if entry.ComponentType == decoder.ComponentCapacitor {
+		return decoder.FormatCapacitanceValue(value)
+	}
+	// More digits than FormatResistanceValue so the export round-trips
+	scaled, unit := decoder.ScaleResistance(value)
+	return strconv.FormatFloat(scaled, 'g', 6, 64) + " " + unit
+}
+
+// toleranceBounds returns the lowest and highest value allowed by an entry's
+// tolerance, in pF for capacitors and Ω for resistors
+func toleranceBounds(entry ComponentEntry) (float64, float64, bool) {
+	switch {
+	case entry.ComponentType == decoder.ComponentCapacitor && entry.CapacitorResult != nil:
+		lo, hi := entry.CapacitorResult.WorstCaseRange()
+		return lo, hi, true
+	case entry.ComponentType == decoder.ComponentResistor && entry.ResistorResult != nil:
+		lo, hi := entry.ResistorResult.WorstCaseRange()
+		return lo, hi, true
+	}
+	return 0, 0, false
+}
+
+// WithinTolerance reports whether a measured value (pF or Ω) lies inside the
+// entry's tolerance range. The second result is false if the entry has no result.
+func WithinTolerance(entry ComponentEntry, measured float64) (bool, bool) {
+	lo, hi, ok := toleranceBounds(entry)
+	if !ok {
+		return false, false
+	}
+	// Allow for rounding in the bounds themselves
+	const epsilon = 1e-9
+	return measured >= lo*(1-epsilon) && measured <= hi*(1+epsilon), true
+}
+
+// measurementColumns returns the "Measured Value" and "In Tolerance?"
+// export columns, blank when nothing was measured
+func measurementColumns(entry ComponentEntry) []string {
+	if !entry.Measured {
+		return []string{"", ""}
+	}
+	verdict := ""
+	if within, ok := WithinTolerance(entry, entry.MeasuredValue); ok {
+		verdict = map[bool]string{true: "yes", false: "no"}[within]
+	}
+	return []string{FormatMeasuredValue(entry, entry.MeasuredValue), verdict}
+}
+
+// ParseSpec parses a target value and the tolerance it may vary by, e.g.
+// "4k7 1%" or "100n ±10%", in pF for capacitors and Ω for resistors
+func ParseSpec(entry ComponentEntry, input string) (target, tolPct float64, err error) {
+	fields := strings.Fields(strings.ReplaceAll(input, "±", " "))
+	if len(fields) != 2 {
+		return 0, 0, fmt.Errorf("enter a target value and tolerance, e.g. 4.7k 1%%")
+	}
+	target, err = ParseMeasuredValue(entry, fields[0])
+	if err != nil {
+		return 0, 0, err
+	}
+	tolPct, err = strconv.ParseFloat(strings.TrimSuffix(fields[1], "%"), 64)
+	if err != nil || tolPct < 0 {
+		return 0, 0, fmt.Errorf("invalid tolerance: '%s'", fields[1])
+	}
+	return target, tolPct, nil
+}
+
+// CheckSpec reports whether an entry's worst-case range meets a target
+// window, with the explanation. The last result is false if the entry has
+// no result.
+func CheckSpec(entry ComponentEntry, target, tolPct float64) (bool, string, bool) {
+	switch {
+	case entry.ComponentType == decoder.ComponentCapacitor && entry.CapacitorResult != nil:
+		meets, why := decoder.MeetsSpecCapacitor(entry.CapacitorResult, target, tolPct)
+		return meets, why, true
+	case entry.ComponentType == decoder.ComponentResistor && entry.ResistorResult != nil:
+		meets, why := decoder.MeetsSpec(entry.ResistorResult, target, tolPct)
+		return meets, why, true
+	}
+	return false, "", false
+}
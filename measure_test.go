@@ -0,0 +1,187 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"tropical-fish/decoder"
+)
+
+// TestWithinTolerance tests pass/fail of measurements against the decoded range
+func TestWithinTolerance(t *testing.T) {
+	resistor := mustDecode(t, cliOptions{resistor: true, bands: "yellow,violet,red,gold"}, "")                        // 4.7kΩ ±5%
+	capacitor := mustDecode(t, cliOptions{capacitor: true, capType: "K", bands: "red,violet,orange,grey,orange"}, "") // 27nF +80/-20%
+	small := mustDecode(t, cliOptions{capacitor: true, capType: "K", bands: "brown,black,black,green,orange"}, "")    // 10pF ±0.5pF
+
+	tests := []struct {
+		name     string
+		entry    ComponentEntry
+		measured float64
+		expected bool
+	}{
+		{"Resistor nominal", resistor, 4700, true},
+		{"Resistor at upper bound", resistor, 4935, true},
+		{"Resistor too high", resistor, 4950, false},
+		{"Resistor too low", resistor, 4400, false},
+		{"Asymmetric high side", capacitor, 48000, true},
+		{"Asymmetric low side", capacitor, 21000, false},
+		{"Absolute tolerance inside", small, 10.4, true},
+		{"Absolute tolerance outside", small, 10.6, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := WithinTolerance(tt.entry, tt.measured)
+			if !ok {
+				t.Fatal("WithinTolerance() ok = false, want true")
+			}
+			if got != tt.expected {
+				t.Errorf("WithinTolerance(%v) = %v, want %v", tt.measured, got, tt.expected)
+			}
+		})
+	}
+
+	if _, ok := WithinTolerance(ComponentEntry{}, 1); ok {
+		t.Error("WithinTolerance() on an empty entry ok = true, want false")
+	}
+}
+
+// TestMeasurementExportRoundTrip tests the measurement columns are written and imported
+func TestMeasurementExportRoundTrip(t *testing.T) {
+	measured := mustDecode(t, cliOptions{resistor: true, bands: "yellow,violet,red,gold"}, "")
+	measured.MeasuredValue = 4623.5
+	measured.Measured = true
+	unmeasured := mustDecode(t, cliOptions{resistor: true, bands: "brown,black,red,gold"}, "")
+
+	if got := measurementColumns(measured); got[0] != "4.6235 kΩ" || got[1] != "yes" {
+		t.Errorf("measurementColumns(measured) = %q, want [4.6235 kΩ yes]", got)
+	}
+	if got := measurementColumns(unmeasured); got[0] != "" || got[1] != "" {
+		t.Errorf("measurementColumns(unmeasured) = %q, want blank columns", got)
+	}
+
+	path := filepath.Join(t.TempDir(), "inspection.csv")
+	if err := ExportToCSV([]ComponentEntry{measured, unmeasured}, path); err != nil {
+		t.Fatalf("ExportToCSV() error = %v", err)
+	}
+	imported, err := ImportFromCSV(path)
+	if err != nil {
+		t.Fatalf("ImportFromCSV() error = %v", err)
+	}
+	if len(imported) != 2 {
+		t.Fatalf("imported %d entries, want 2", len(imported))
+	}
+	if !imported[0].Measured || imported[0].MeasuredValue != 4623.5 {
+		t.Errorf("imported measurement = %v, %v, want 4623.5, true", imported[0].MeasuredValue, imported[0].Measured)
+	}
+	if imported[1].Measured {
+		t.Error("unmeasured entry imported with a measurement")
+	}
+}
+
+// TestParseSpec tests reading a target value and tolerance for either component
+func TestParseSpec(t *testing.T) {
+	resistor := mustDecode(t, cliOptions{resistor: true, bands: "yellow,violet,red,gold"}, "")                        // 4.7kΩ ±5%
+	capacitor := mustDecode(t, cliOptions{capacitor: true, capType: "K", bands: "red,violet,orange,grey,orange"}, "") // 27nF +80/-20%
+
+	tests := []struct {
+		name       string
+		entry      ComponentEntry
+		input      string
+		wantTarget float64
+		wantTol    float64
+		wantErr    bool
+	}{
+		{"resistor", resistor, "4k7 1%", 4700, 1, false},
+		{"plus-minus sign", resistor, "4.7k ±10%", 4700, 10, false},
+		{"capacitor in pF", capacitor, "27n 20", 27000, 20, false},
+		{"missing tolerance", resistor, "4k7", 0, 0, true},
+		{"bad value", resistor, "abc 5%", 0, 0, true},
+		{"negative tolerance", resistor, "4k7 -5%", 0, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target, tol, err := ParseSpec(tt.entry, tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseSpec(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if !tt.wantErr && (!decoder.ApproxEqual(target, tt.wantTarget) || tol != tt.wantTol) {
+				t.Errorf("ParseSpec(%q) = %v, %v, want %v, %v", tt.input, target, tol, tt.wantTarget, tt.wantTol)
+			}
+		})
+	}
+}
+
+// TestSpecOnResults tests entering a target on the results screen and the
+// verdict shown for it
+func TestSpecOnResults(t *testing.T) {
+	entry := mustDecode(t, cliOptions{resistor: true, bands: "yellow,violet,red,gold"}, "") // 4.7kΩ ±5%
+
+	tests := []struct {
+		name    string
+		target  string
+		verdict string
+	}{
+		{"looser window", "4k7 10%", "✓ meets spec"},
+		{"nominal matches, range too wide", "4k7 1%", "✗ does not meet spec"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := initialModel()
+			m.screen = screenResults
+			m.componentType = entry.ComponentType
+			m.resistorResult = entry.ResistorResult
+
+			m = pressKeys(m, "g")
+			if m.screen != screenSpecInput {
+				t.Fatalf("g went to screen %v, want the target input", m.screen)
+			}
+			m = pressKeys(m, strings.Split(tt.target, "")...)
+			m = pressKeys(m, "enter")
+			if m.screen != screenResults || m.specTarget != tt.target {
+				t.Fatalf("after enter screen = %v, target = %q, want results with %q", m.screen, m.specTarget, tt.target)
+			}
+			if view := m.View(); !strings.Contains(view, tt.verdict) {
+				t.Errorf("results view has no %q", tt.verdict)
+			}
+		})
+	}
+}
+
+// TestInputBackspace tests that Backspace removes a whole character from
+// input holding multi-byte characters such as µ
+func TestInputBackspace(t *testing.T) {
+	entry := mustDecode(t, cliOptions{resistor: true, bands: "yellow,violet,red,gold"}, "") // 4.7kΩ ±5%
+
+	tests := []struct {
+		name  string
+		open  string // Key that opens the input from results
+		typed string
+		want  string // Input after one Backspace
+	}{
+		{"measured value", "v", "4.7kΩ", "4.7k"},
+		{"target spec", "g", "10µF ±", "10µF "},
+		{"operating point", "o", "20µ", "20"},
+		{"BOM package and quantity", "b", "0805 ×", "0805 "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := initialModel()
+			m.screen = screenResults
+			m.componentType = entry.ComponentType
+			m.resistorResult = entry.ResistorResult
+
+			m = pressKeys(m, tt.open)
+			m = pressKeys(m, strings.Split(tt.typed, "")...)
+			m = pressKeys(m, "backspace")
+			if m.input != tt.want || !utf8.ValidString(m.input) {
+				t.Errorf("input after Backspace = %q, want %q", m.input, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// numberedScreens are the screens with a numbered list to click: band count
+// selection and the edit band list
+var numberedScreens = map[screenType]bool{
+	screenBandCountSelection: true,
+	screenEdit:               true,
+}
+
+// ansiPattern matches the SGR escape sequences lipgloss styles text with
+var ansiPattern = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// bandOptionPattern matches a line of a numbered list, e.g. "  2 = Red" or
+// "▶ 4 = 4-band"
+var bandOptionPattern = regexp.MustCompile(`^\s*(?:▶\s*)?([1-6]) = `)
+
+// promptSeparator separates the options of a clickable prompt line
+const promptSeparator = "  |  "
+
+// actionOption is one option of a clickable prompt line: an action and the
+// word its key is marked in
+type actionOption struct {
+	action Action
+	word   string
+}
+
+// clickRow is a line of a view whose "|"-separated options stand for actions
+type clickRow struct {
+	text    string   // The line as rendered, before styling
+	actions []Action // One per option, in order
+}
+
+// promptRow renders options as a clickable prompt line with each bound key
+// marked, e.g. "(D)ecode  |  (A)gain  |  e(X)port"
+func (k Keymap) promptRow(options ...actionOption) clickRow {
+	row := clickRow{actions: make([]Action, len(options))}
+	labels := make([]string, len(options))
+	for i, option := range options {
+		labels[i] = k.Label(option.action, option.word)
+		row.actions[i] = option.action
+	}
+	row.text = strings.Join(labels, promptSeparator)
+	return row
+}
+
+// clickRows returns the clickable action lines of the current screen
+func (m model) clickRows() []clickRow {
+	switch m.screen {
+	case screenComponentSelection:
+		return m.componentRows()
+	case screenEdit:
+		return []clickRow{m.editCancelRow()}
+	case screenReview:
+		return []clickRow{m.reviewPromptRow()}
+	case screenResults:
+		return m.resultsPromptRows()
+	}
+	return nil
+}
+
+// clickTarget returns what a click at column x, row y of a rendered view
+// stands for: the number of a numbered list line as a key, or the action of
+// the row option under the pointer, with options on one line separated by
+// "|". Views taller than the terminal are cut at the top, as the renderer does.
+func clickTarget(view string, x, y, height int, numbered bool, rows []clickRow) (string, Action, bool) {
+	lines := strings.Split(view, "\n")
+	if height > 0 && len(lines) > height {
+		lines = lines[len(lines)-height:]
+	}
+	if y < 0 || y >= len(lines) {
+		return "", "", false
+	}
+	line := ansiPattern.ReplaceAllString(lines[y], "")
+
+	if numbered {
+		if match := bandOptionPattern.FindStringSubmatch(line); match != nil {
+			return match[1], "", true
+		}
+	}
+
+	trimmed := strings.TrimSpace(strings.TrimLeft(line, "▶ "))
+	for _, row := range rows {
+		if trimmed != strings.TrimSpace(row.text) {
+			continue
+		}
+		// Find the "|"-separated option under the pointer
+		start := 0
+		for i, option := range strings.Split(line, "|") {
+			end := start + lipgloss.Width(option)
+			if x >= start && x < end && strings.TrimSpace(option) != "" {
+				return "", row.actions[i], true
+			}
+			start = end + 1 // The separator
+		}
+		return "", "", false
+	}
+	return "", "", false
+}
+
+// handleMouse turns a left click on an option into a key press: the number
+// clicked, or the first key bound to the clicked action, so clicks go through
+// the same handlers as the keyboard
+func (m model) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	if msg.Action != tea.MouseActionPress || msg.Button != tea.MouseButtonLeft {
+		return m, nil
+	}
+	key, action, ok := clickTarget(m.View(), msg.X, msg.Y, m.height, numberedScreens[m.screen], m.clickRows())
+	if !ok {
+		return m, nil
+	}
+	if action != "" {
+		key = m.keys.Keys(action)[0]
+	}
+	return m.handleKey(key, msg)
+}
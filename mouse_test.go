@@ -0,0 +1,119 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// TestClickTarget tests mapping a click on a rendered view to the numbered
+// key or action it stands for
+func TestClickTarget(t *testing.T) {
+	keys := DefaultKeymap()
+	rows := []clickRow{
+		keys.promptRow(actionOption{ActionCapacitor, "Capacitor - IEC 60062 Standard (3/4/5 bands)"}),
+		keys.promptRow(actionOption{ActionResistor, "Resistor - EIA Standard (4/5/6 bands)"}),
+		keys.promptRow(actionOption{ActionDecode, "Decode"}, actionOption{ActionAgain, "Again"},
+			actionOption{ActionEdit, "Edit"}, actionOption{ActionExport, "export"}),
+		{text: "Q = Cancel (keep current values)", actions: []Action{ActionQuit}},
+	}
+	view := strings.Join([]string{
+		"Select component:",
+		"▶ (C)apacitor - IEC 60062 Standard (3/4/5 bands)",
+		"  (R)esistor - EIA Standard (4/5/6 bands)",
+		"  2 = \x1b[31mRed\x1b[0m",
+		"  Q = Cancel (keep current values)",
+		"(D)ecode  |  (A)gain  |  (E)dit  |  e(X)port",
+		"(D)ecode  |  (A)gain",
+	}, "\n")
+
+	tests := []struct {
+		name       string
+		x, y       int
+		height     int
+		numbered   bool
+		wantKey    string
+		wantAction Action
+		wantOK     bool
+	}{
+		{"component option", 10, 1, 0, false, "", ActionCapacitor, true},
+		{"second component option", 0, 2, 0, false, "", ActionResistor, true},
+		{"edit band", 8, 3, 0, true, "2", "", true},
+		{"band outside a numbered screen", 8, 3, 0, false, "", "", false},
+		{"edit cancel", 3, 4, 0, true, "", ActionQuit, true},
+		{"first action", 2, 5, 0, false, "", ActionDecode, true},
+		{"middle action", 14, 5, 0, false, "", ActionAgain, true},
+		{"action with inner key", 38, 5, 0, false, "", ActionExport, true},
+		{"separator", 10, 5, 0, false, "", "", false},
+		{"line that isn't a prompt", 2, 6, 0, false, "", "", false},
+		{"title", 3, 0, 0, false, "", "", false},
+		{"below the view", 0, 7, 0, false, "", "", false},
+		{"view cut to the terminal height", 3, 1, 4, true, "", ActionQuit, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, action, ok := clickTarget(view, tt.x, tt.y, tt.height, tt.numbered, rows)
+			if key != tt.wantKey || action != tt.wantAction || ok != tt.wantOK {
+				t.Errorf("clickTarget(%d, %d) = %q, %q, %v, want %q, %q, %v",
+					tt.x, tt.y, key, action, ok, tt.wantKey, tt.wantAction, tt.wantOK)
+			}
+		})
+	}
+}
+
+// TestClickRemappedAction tests that the results prompt shows remapped keys
+// and that clicking an option runs its action, not the printed letter
+func TestClickRemappedAction(t *testing.T) {
+	m := pressKeys(initialModel(), "enter", "r", "4")
+	m = pressKeys(m, "brown", "enter", "black", "enter", "red", "enter", "gold", "enter", "enter")
+	m.keys, _ = NewKeymap(map[string][]string{"compact": {"z"}, "decode": {"c"}})
+
+	view := m.View()
+	y := -1
+	for i, line := range strings.Split(view, "\n") {
+		if strings.Contains(line, "(Z)") {
+			y = i
+		}
+	}
+	if y < 0 || !strings.Contains(view, "De(C)ode") {
+		t.Fatalf("results prompt does not show the remapped keys:\n%s", view)
+	}
+
+	x := strings.Index(ansiPattern.ReplaceAllString(strings.Split(view, "\n")[y], ""), "(Z)")
+	clicked, _ := m.Update(tea.MouseMsg{X: x, Y: y, Action: tea.MouseActionPress, Button: tea.MouseButtonLeft})
+	if got := clicked.(model); !got.compact || got.screen != screenResults {
+		t.Errorf("clicking (Z) Compact gave compact %t on screen %v, want compact results", got.compact, got.screen)
+	}
+}
+
+// TestMouseClickSelectsComponent tests that clicking an option acts like its key
+func TestMouseClickSelectsComponent(t *testing.T) {
+	m := initialModel()
+	m.screen = screenComponentSelection
+
+	y := -1
+	for i, line := range strings.Split(m.View(), "\n") {
+		if strings.Contains(line, "(R)esistor") {
+			y = i
+		}
+	}
+	if y < 0 {
+		t.Fatal("component selection has no (R)esistor option")
+	}
+
+	released, _ := m.Update(tea.MouseMsg{X: 5, Y: y, Action: tea.MouseActionRelease, Button: tea.MouseButtonLeft})
+	if released.(model).screen != screenComponentSelection {
+		t.Error("a mouse release changed the screen, want only presses to click")
+	}
+
+	clicked, _ := m.Update(tea.MouseMsg{X: 5, Y: y, Action: tea.MouseActionPress, Button: tea.MouseButtonLeft})
+	pressed, _ := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	if got, want := clicked.(model).screen, pressed.(model).screen; got != want {
+		t.Errorf("clicking (R) went to screen %v, want %v as with the r key", got, want)
+	}
+	if clicked.(model).screen == screenComponentSelection {
+		t.Error("clicking (R) stayed on component selection")
+	}
+}
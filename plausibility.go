@@ -0,0 +1,147 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"tropical-fish/decoder"
+)
+
+// valueRange is the span of values a part family is normally made in
+type valueRange struct {
+	Min float64
+	Max float64
+}
+
+// PlausibilitySettings controls the advisory checks shown on results
+type PlausibilitySettings struct {
+	Enabled         bool
+	CapacitorRanges map[decoder.CapacitorType]valueRange // In pF
+	ResistorRange   valueRange                           // In Ω
+}
+
+// defaultPlausibility returns the built-in ranges for each part family
+func defaultPlausibility() PlausibilitySettings {
+	return PlausibilitySettings{
+		Enabled: true,
+		CapacitorRanges: map[decoder.CapacitorType]valueRange{
+			decoder.TypeJ: {100e3, 1e9},  // Dipped tantalum: 0.1 µF – 1000 µF
+			decoder.TypeK: {1, 100e3},    // Mica: 1 pF – 100 nF
+			decoder.TypeL: {10, 10e6},    // Polyester / polystyrene: 10 pF – 10 µF
+			decoder.TypeM: {100e3, 1e10}, // Electrolytic: 0.1 µF – 10000 µF
+			decoder.TypeN: {100e3, 1e10},
+		},
+		ResistorRange: valueRange{0.1, 100e6}, // 0.1 Ω – 100 MΩ
+	}
+}
+
+// plausibility is the active settings used by PlausibilityCheck
+var plausibility = defaultPlausibility()
+
+// SetPlausibility replaces the active settings from the config file.
+// Ranges are keyed by capacitor type letter (in pF) or "resistor" (in Ω).
+func SetPlausibility(cfg PlausibilityConfig) error {
+	settings := defaultPlausibility()
+	if cfg.Enabled != nil {
+		settings.Enabled = *cfg.Enabled
+	}
+
+	for key, bounds := range cfg.Ranges {
+		r := valueRange{Min: bounds[0], Max: bounds[1]}
+		if r.Min <= 0 || r.Max <= r.Min {
+			return fmt.Errorf("invalid plausibility range for '%s': need 0 < min < max", key)
+		}
+		if strings.EqualFold(key, "resistor") {
+			settings.ResistorRange = r
+			continue
+		}
+		capType, ok := decoder.ParseCapacitorType(key)
+		if !ok {
+			return fmt.Errorf("unknown part '%s' in plausibility ranges", key)
+		}
+		settings.CapacitorRanges[capType] = r
+	}
+
+	plausibility = settings
+	return nil
+}
+
+// PlausibilityCheck returns advisory warnings for a decoded value that is
+// unlikely to be a real part, usually a sign of a misread band.
+// Colors are named in palette's language. Returns nil when the checks are
+// turned off.
+func PlausibilityCheck(entry ComponentEntry, palette decoder.Palette) []string {
+	if !plausibility.Enabled {
+		return nil
+	}
+
+	switch entry.ComponentType {
+	case decoder.ComponentCapacitor:
+		if entry.CapacitorResult != nil {
+			return capacitorPlausibility(entry.CapacitorResult, palette)
+		}
+	case decoder.ComponentResistor:
+		if entry.ResistorResult != nil {
+			return resistorPlausibility(entry.ResistorResult)
+		}
+	}
+	return nil
+}
+
+// capacitorPlausibility checks a capacitor against its type's usual range,
+// the E24 series and for sub-picofarad Gold / Silver multipliers
+func capacitorPlausibility(result *decoder.CalculationResult, palette decoder.Palette) []string {
+	var warnings []string
+	reading := result.Reading
+
+	digits := decoder.GetColorInfo(reading.Band1).Digit*10 + decoder.GetColorInfo(reading.Band2).Digit
+	if digits < 10 {
+		warnings = append(warnings, "first band is Black, which is unusual; the part may be read from the wrong end")
+	} else if result.PlausibilityNote != "" {
+		warnings = append(warnings, result.PlausibilityNote)
+	}
+
+	// Gold and Silver multipliers are rare on capacitors; a sub-picofarad
+	// result from one is more often a misread tolerance band
+	if (reading.Band3 == decoder.ColorGold || reading.Band3 == decoder.ColorSilver) && result.CapacitancePF < 1 {
+		warnings = append(warnings, fmt.Sprintf("unusual %s multiplier for a capacitor — did you read the bands correctly?",
+			palette.ColorName(reading.Band3)))
+	}
+
+	if r, ok := plausibility.CapacitorRanges[reading.CapType]; ok &&
+		(result.CapacitancePF < r.Min || result.CapacitancePF > r.Max) {
+		info, _ := decoder.GetTypeInfo(reading.CapType)
+		warnings = append(warnings, fmt.Sprintf("%s is outside the usual range for %s (%s – %s); check the multiplier band",
+			decoder.FormatCapacitanceValue(result.CapacitancePF), info.Name,
+			decoder.FormatCapacitanceValue(r.Min), decoder.FormatCapacitanceValue(r.Max)))
+	}
+
+	return warnings
+}
+
+// resistorPlausibility checks a resistor against the usual resistance range
+// and the E24 / E96 series
+func resistorPlausibility(result *decoder.ResistorResult) []string {
+	// A jumper's Black bands and 0 Ω value are what it should be
+	if result.Jumper {
+		return nil
+	}
+
+	var warnings []string
+	reading := result.Reading
+
+	switch {
+	case reading.BandCount > 0 && decoder.GetColorInfo(reading.Band1).Digit == 0:
+		warnings = append(warnings, "first band is Black, which is unusual; the part may be read from the wrong end")
+	case result.PlausibilityNote != "":
+		warnings = append(warnings, result.PlausibilityNote)
+	}
+
+	r := plausibility.ResistorRange
+	if result.ResistanceOhms < r.Min || result.ResistanceOhms > r.Max {
+		warnings = append(warnings, fmt.Sprintf("%s is outside the usual range for resistors (%s – %s); check the multiplier band",
+			decoder.FormatResistanceValue(result.ResistanceOhms), decoder.FormatResistanceValue(r.Min), decoder.FormatResistanceValue(r.Max)))
+	}
+
+	return warnings
+}
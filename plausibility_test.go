@@ -0,0 +1,113 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"tropical-fish/decoder"
+)
+
+// TestPlausibilityCheck tests advisory warnings for unlikely decoded values
+func TestPlausibilityCheck(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     cliOptions
+		expected []string // Substrings, one per expected warning
+	}{
+		{"Standard 4-band resistor", cliOptions{resistor: true, bands: "brown,black,red,gold"}, nil},
+		{"Non-E24 digits", cliOptions{resistor: true, bands: "brown,yellow,red,gold"}, []string{"1.4 kΩ is not a standard E24"}},
+		{"Near but off E24", cliOptions{resistor: true, bands: "grey,orange,black,gold"}, []string{"Unusual value — 83 Ω is not a standard E24 value (nearest 82 Ω)"}},
+		{"Leading black band", cliOptions{resistor: true, bands: "black,brown,red,gold"}, []string{"wrong end"}},
+		{"E96 5-band resistor", cliOptions{resistor: true, bands: "brown,black,red,brown,brown"}, nil},
+		{"E24 value on 5 bands", cliOptions{resistor: true, bands: "yellow,violet,black,brown,brown"}, nil},
+		{"Non-E96 5-band resistor", cliOptions{resistor: true, bands: "brown,black,brown,brown,brown"}, []string{"1.01 kΩ is not a standard E24 or E96"}},
+		{"Precision parts skip E-series", cliOptions{resistor: true, bands: "brown,black,brown,brown,violet"}, nil},
+		{"Resistor out of range", cliOptions{resistor: true, bands: "brown,black,white,gold"}, []string{"outside the usual range for resistors"}},
+		{"Standard mica", cliOptions{capacitor: true, capType: "K", bands: "red,violet,brown,brown,orange"}, nil},
+		{"Gold multiplier above 1 pF", cliOptions{capacitor: true, capType: "K", bands: "red,violet,gold,brown,orange"}, nil},
+		{"Silver multiplier below 1 pF", cliOptions{capacitor: true, capType: "K", bands: "red,violet,silver,brown,orange"},
+			[]string{"unusual Silver multiplier", "outside the usual range for Mica"}},
+		{"Tantalum far too small", cliOptions{capacitor: true, capType: "J", bands: "red,violet,black,brown,brown"}, []string{"outside the usual range for Dipped Tantalum"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warnings := PlausibilityCheck(mustDecode(t, tt.opts, ""), decoder.Palette{})
+			if len(warnings) != len(tt.expected) {
+				t.Fatalf("PlausibilityCheck() = %q, want %d warning(s)", warnings, len(tt.expected))
+			}
+			for i, want := range tt.expected {
+				if !strings.Contains(warnings[i], want) {
+					t.Errorf("warning %d = %q, want it to contain %q", i, warnings[i], want)
+				}
+			}
+		})
+	}
+}
+
+// TestSetPlausibility tests the config toggle and range overrides
+func TestSetPlausibility(t *testing.T) {
+	t.Cleanup(func() { plausibility = defaultPlausibility() })
+	offRange := mustDecode(t, cliOptions{resistor: true, bands: "brown,black,white,gold"}, "")
+
+	disabled := false
+	if err := SetPlausibility(PlausibilityConfig{Enabled: &disabled}); err != nil {
+		t.Fatalf("SetPlausibility() error = %v", err)
+	}
+	if warnings := PlausibilityCheck(offRange, decoder.Palette{}); warnings != nil {
+		t.Errorf("PlausibilityCheck() with checks disabled = %q, want nil", warnings)
+	}
+
+	if err := SetPlausibility(PlausibilityConfig{Ranges: map[string][2]float64{"resistor": {1, 100e9}}}); err != nil {
+		t.Fatalf("SetPlausibility() error = %v", err)
+	}
+	if warnings := PlausibilityCheck(offRange, decoder.Palette{}); warnings != nil {
+		t.Errorf("PlausibilityCheck() with widened range = %q, want nil", warnings)
+	}
+
+	for _, ranges := range []map[string][2]float64{
+		{"Z": {1, 10}},
+		{"K": {10, 1}},
+		{"resistor": {0, 10}},
+	} {
+		if err := SetPlausibility(PlausibilityConfig{Ranges: ranges}); err == nil {
+			t.Errorf("SetPlausibility(%v) expected error", ranges)
+		}
+	}
+}
+
+// TestPreferredValueNote tests the Plausible flag and note set on results,
+// and that 0 Ω is never flagged
+func TestPreferredValueNote(t *testing.T) {
+	tests := []struct {
+		name          string
+		opts          cliOptions
+		wantPlausible bool
+	}{
+		{"E24 resistor", cliOptions{resistor: true, bands: "red,red,red,gold"}, true},
+		{"Off-series resistor", cliOptions{resistor: true, bands: "grey,orange,black,gold"}, false},
+		{"E12 capacitor", cliOptions{capacitor: true, capType: "K", bands: "red,violet,orange"}, true},
+		{"Off-series capacitor", cliOptions{capacitor: true, capType: "K", bands: "red,green,orange"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry := mustDecode(t, tt.opts, "")
+			var plausible bool
+			var note string
+			if entry.ResistorResult != nil {
+				plausible, note = entry.ResistorResult.Plausible, entry.ResistorResult.PlausibilityNote
+			} else {
+				plausible, note = entry.CapacitorResult.Plausible, entry.CapacitorResult.PlausibilityNote
+			}
+			if plausible != tt.wantPlausible || (note == "") != tt.wantPlausible {
+				t.Errorf("Plausible = %v, note %q, want plausible %v", plausible, note, tt.wantPlausible)
+			}
+		})
+	}
+
+	zero := mustDecode(t, cliOptions{resistor: true, bands: "black,black,black,gold"}, "")
+	if note := zero.ResistorResult.PlausibilityNote; note != "" {
+		t.Errorf("PlausibilityNote of 0 Ω = %q, want no note for a zero-ohm part", note)
+	}
+}
@@ -0,0 +1,348 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"html"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"tropical-fish/decoder"
+)
+
+// referenceChartColumns names the columns of the band reference chart after
+// the color, with the unit row shown under them on screen
+var referenceChartColumns = []string{"Digit", "Mult (C)", "Mult (R)", "Tol (C)", "Tol (R)", "TC (C)", "TC (R)"}
+
+// referenceChartUnits are the units shown under referenceChartColumns
+var referenceChartUnits = []string{"", "", "", "", "", "ppm/°C", "ppm/°C"}
+
+// referenceRow is one color's entries in a reference table
+type referenceRow struct {
+	Color decoder.Color
+	Cells []string
+}
+
+// referenceChartRows builds the band reference chart, one row per color in
+// palette, from the exported table accessors
+func referenceChartRows(palette decoder.Palette) []referenceRow {
+	capTolerances := map[decoder.Color]decoder.ToleranceInfo{}
+	for _, e := range decoder.AllTolerances() {
+		capTolerances[e.Color] = e.Tolerance
+	}
+	resTolerances := map[decoder.Color]decoder.ResistorToleranceInfo{}
+	for _, e := range decoder.AllResistorTolerances() {
+		resTolerances[e.Color] = e.Tolerance
+	}
+	capMultipliers := map[decoder.Color]float64{}
+	for _, e := range decoder.AllMultipliers() {
+		capMultipliers[e.Color] = e.Multiplier
+	}
+	resMultipliers := map[decoder.Color]float64{}
+	for _, e := range decoder.AllResistorMultipliers() {
+		resMultipliers[e.Color] = e.Multiplier
+	}
+	capTempCoeffs := map[decoder.Color]int{}
+	for _, e := range decoder.AllTempCoefficients() {
+		capTempCoeffs[e.Color] = e.Coefficient
+	}
+	resTempCoeffs := map[decoder.Color]int{}
+	for _, e := range decoder.AllResistorTempCoefficients() {
+		resTempCoeffs[e.Color] = e.Coefficient
+	}
+
+	var rows []referenceRow
+	for _, c := range palette.Colors() {
+		info := decoder.GetColorInfo(c)
+
+		digit := "—"
+		if info.ValidDigit {
+			digit = strconv.Itoa(info.Digit)
+		}
+
+		capMult := "—"
+		if m, ok := capMultipliers[c]; ok {
+			capMult = "×" + formatAnyMultiplier(m)
+		}
+
+		resMult := "—"
+		if m, ok := resMultipliers[c]; ok {
+			resMult = "×" + formatAnyMultiplier(m)
+		}
+
+		capTol := "—"
+		if tol, ok := capTolerances[c]; ok {
+			if tol.Symmetric {
+				capTol = "±" + strconv.FormatFloat(tol.PercentHigh, 'f', -1, 64) + "%"
+			} else {
+				capTol = "+" + strconv.FormatFloat(tol.PercentHigh, 'f', -1, 64) +
+					"/-" + strconv.FormatFloat(tol.PercentLow, 'f', -1, 64) + "%"
+			}
+		}
+
+		resTol := "—"
+		if tol, ok := resTolerances[c]; ok {
+			resTol = "±" + strconv.FormatFloat(tol.Percent, 'f', -1, 64) + "%"
+		}
+
+		capTC := "—"
+		if tc, ok := capTempCoeffs[c]; ok {
+			capTC = strconv.Itoa(tc)
+		}
+
+		resTC := "—"
+		if tc, ok := resTempCoeffs[c]; ok {
+			resTC = strconv.Itoa(tc)
+		}
+
+		rows = append(rows, referenceRow{
+			Color: c,
+			Cells: []string{digit, capMult, resMult, capTol, resTol, capTC, resTC},
+		})
+	}
+
+	return rows
+}
+
+// referenceVoltageColumns names the columns of the voltage chart after the
+// color, one per capacitor type
+func referenceVoltageColumns() []string {
+	var columns []string
+	for _, code := range decoder.AllCapacitorTypes() {
+		columns = append(columns, "Type "+code)
+	}
+	return columns
+}
+
+// referenceVoltageRows builds the capacitor voltage chart, one row per color
+// in palette with the rating for each type, from VoltageTable
+func referenceVoltageRows(palette decoder.Palette) []referenceRow {
+	types := decoder.AllCapacitorTypes()
+	voltages := make([]map[decoder.Color]float64, len(types))
+	for i, code := range types {
+		voltages[i] = map[decoder.Color]float64{}
+		for _, e := range decoder.VoltageTable(decoder.CapacitorType(code)) {
+			voltages[i][e.Color] = e.Voltage
+		}
+	}
+
+	var rows []referenceRow
+	for _, c := range palette.Colors() {
+		row := referenceRow{Color: c}
+		for i := range types {
+			cell := "—"
+			if v, ok := voltages[i][c]; ok {
+				cell = strconv.FormatFloat(v, 'f', -1, 64) + " V"
+			}
+			row.Cells = append(row.Cells, cell)
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// ReferenceChartLines builds the color-code reference chart for the colors in
+// palette, one line per row, from the exported table accessors
+func ReferenceChartLines(palette decoder.Palette) []string {
+	const rowFormat = "%-6s %-12s %-15s %-9s %-7s %-7s %-7s"
+	cells := func(values []string) []any {
+		args := make([]any, len(values))
+		for i, v := range values {
+			args[i] = v
+		}
+		return args
+	}
+
+	lines := []string{
+		labelStyle.Render(fmt.Sprintf("%-10s ", "Color") + fmt.Sprintf(rowFormat, cells(referenceChartColumns)...)),
+		mutedStyle.Render(fmt.Sprintf("%-10s ", "") + fmt.Sprintf(rowFormat, cells(referenceChartUnits)...)),
+	}
+
+	for _, row := range referenceChartRows(palette) {
+		swatch := GetColorStyle(row.Color).Width(10).Render(palette.ColorName(row.Color))
+		lines = append(lines, swatch+" "+valueStyle.Render(fmt.Sprintf(rowFormat, cells(row.Cells)...)))
+	}
+
+	return lines
+}
+
+// formatAnyMultiplier formats whole and fractional multipliers
+func formatAnyMultiplier(m float64) string {
+	if m >= 1 {
+		return formatMultiplier(m)
+	}
+	return formatMultiplierDecimal(m)
+}
+
+// ReferenceFormat is a file format for ExportReferenceChart
+type ReferenceFormat string
+
+const (
+	ReferenceCSV  ReferenceFormat = "csv"
+	ReferenceHTML ReferenceFormat = "html"
+	ReferenceText ReferenceFormat = "text"
+)
+
+// ReferenceFormatFromPath picks the chart format from a file extension,
+// defaulting to plain text
+func ReferenceFormatFromPath(path string) ReferenceFormat {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return ReferenceCSV
+	case ".html", ".htm":
+		return ReferenceHTML
+	default:
+		return ReferenceText
+	}
+}
+
+// ExportReferenceChart writes the band reference chart and the capacitor
+// voltage chart for the colors in palette to a file, for printing
+func ExportReferenceChart(format ReferenceFormat, filename string, palette decoder.Palette) error {
+	var data []byte
+	switch format {
+	case ReferenceCSV:
+		var b strings.Builder
+		if err := writeReferenceCSV(&b, palette); err != nil {
+			return err
+		}
+		data = []byte(b.String())
+	case ReferenceHTML:
+		data = []byte(referenceChartHTML(palette))
+	case ReferenceText:
+		data = []byte(referenceChartText(palette))
+	default:
+		return fmt.Errorf("unknown chart format '%s' (must be csv, html, or text)", format)
+	}
+
+	if err := os.WriteFile(filename, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write chart: %w", err)
+	}
+	return nil
+}
+
+// referenceTable is a titled reference table for export
+type referenceTable struct {
+	Title   string
+	Columns []string // After the color column
+	Rows    []referenceRow
+}
+
+// referenceTables returns the tables written by ExportReferenceChart
+func referenceTables(palette decoder.Palette) []referenceTable {
+	bandColumns := make([]string, len(referenceChartColumns))
+	for i, name := range referenceChartColumns {
+		bandColumns[i] = strings.TrimSpace(name + " " + referenceChartUnits[i])
+	}
+	return []referenceTable{
+		{"Band Values", bandColumns, referenceChartRows(palette)},
+		{"Capacitor Voltage Ratings", referenceVoltageColumns(), referenceVoltageRows(palette)},
+	}
+}
+
+// writeReferenceCSV writes each table with its header, separated by a blank line
+func writeReferenceCSV(b *strings.Builder, palette decoder.Palette) error {
+	writer := csv.NewWriter(b)
+	for i, table := range referenceTables(palette) {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		if err := writer.Write(append([]string{"Color"}, table.Columns...)); err != nil {
+			return fmt.Errorf("failed to write header: %w", err)
+		}
+		for _, row := range table.Rows {
+			if err := writer.Write(append([]string{palette.ColorName(row.Color)}, row.Cells...)); err != nil {
+				return fmt.Errorf("failed to write record: %w", err)
+			}
+		}
+		writer.Flush()
+	}
+	return writer.Error()
+}
+
+// referenceChartText renders the tables as aligned plain text
+func referenceChartText(palette decoder.Palette) string {
+	var b strings.Builder
+	b.WriteString("TROPICAL FISH COLOR CODE REFERENCE\n")
+	b.WriteString("(C) = capacitor, (R) = resistor\n")
+
+	for _, table := range referenceTables(palette) {
+		widths := []int{len("Color")}
+		for _, name := range table.Columns {
+			widths = append(widths, len([]rune(name)))
+		}
+		for _, row := range table.Rows {
+			widths[0] = max(widths[0], len([]rune(palette.ColorName(row.Color))))
+			for i, cell := range row.Cells {
+				widths[i+1] = max(widths[i+1], len([]rune(cell)))
+			}
+		}
+
+		writeRow := func(cells []string) {
+			for i, cell := range cells {
+				if i > 0 {
+					b.WriteString("  ")
+				}
+				b.WriteString(cell)
+				if i < len(cells)-1 {
+					b.WriteString(strings.Repeat(" ", widths[i]-len([]rune(cell))))
+				}
+			}
+			b.WriteString("\n")
+		}
+
+		b.WriteString("\n" + table.Title + "\n\n")
+		writeRow(append([]string{"Color"}, table.Columns...))
+		for _, row := range table.Rows {
+			writeRow(append([]string{palette.ColorName(row.Color)}, row.Cells...))
+		}
+	}
+
+	return b.String()
+}
+
+// referenceChartHTML renders the tables as a standalone HTML page with
+// colored swatch cells
+func referenceChartHTML(palette decoder.Palette) string {
+	var b strings.Builder
+	b.WriteString(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Tropical Fish Color Code Reference</title>
+<style>
+body { font-family: sans-serif; }
+table { border-collapse: collapse; margin-bottom: 1.5em; }
+th, td { border: 1px solid #999; padding: 0.3em 0.8em; text-align: center; }
+td.swatch { font-weight: bold; text-align: left; }
+</style>
+</head>
+<body>
+<h1>Color Code Reference</h1>
+<p>(C) = capacitor, (R) = resistor</p>
+`)
+
+	for _, table := range referenceTables(palette) {
+		b.WriteString("<h2>" + html.EscapeString(table.Title) + "</h2>\n<table>\n<tr><th>Color</th>")
+		for _, name := range table.Columns {
+			b.WriteString("<th>" + html.EscapeString(name) + "</th>")
+		}
+		b.WriteString("</tr>\n")
+
+		for _, row := range table.Rows {
+			background, text := colorSwatchHex(row.Color)
+			fmt.Fprintf(&b, `<tr><td class="swatch" style="background:%s;color:%s">%s</td>`,
+				background, text, html.EscapeString(palette.ColorName(row.Color)))
+			for _, cell := range row.Cells {
+				b.WriteString("<td>" + html.EscapeString(cell) + "</td>")
+			}
+			b.WriteString("</tr>\n")
+		}
+		b.WriteString("</table>\n")
+	}
+
+	b.WriteString("</body>\n</html>\n")
+	return b.String()
+}
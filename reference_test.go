@@ -0,0 +1,69 @@
+package main
+
+import (
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"tropical-fish/decoder"
+)
+
+// TestExportReferenceChart tests that each chart format carries the table values
+func TestExportReferenceChart(t *testing.T) {
+	tests := []struct {
+		file     string
+		format   ReferenceFormat
+		expected []string // Substrings the chart must contain
+	}{
+		{"chart.csv", ReferenceCSV, []string{"Color,Digit,Mult (C)", "Red,2,×100", "Color,Type J,Type K", "Type N"}},
+		{"chart.html", ReferenceHTML, []string{"<table>", `style="background:#FF0000;color:#FFFFFF">Red</td>`, "<td>6.3 V</td>"}},
+		{"chart.txt", ReferenceText, []string{"Band Values", "Capacitor Voltage Ratings", "TC (C) ppm/°C", "Silver"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.file)
+			if got := ReferenceFormatFromPath(path); got != tt.format {
+				t.Fatalf("ReferenceFormatFromPath(%q) = %q, want %q", tt.file, got, tt.format)
+			}
+			if err := ExportReferenceChart(tt.format, path, decoder.Palette{}); err != nil {
+				t.Fatalf("ExportReferenceChart() error = %v", err)
+			}
+
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, want := range tt.expected {
+				if !strings.Contains(string(data), want) {
+					t.Errorf("chart does not contain %q", want)
+				}
+			}
+		})
+	}
+
+	if err := ExportReferenceChart("pdf", filepath.Join(t.TempDir(), "chart.pdf"), decoder.Palette{}); err == nil {
+		t.Error("ExportReferenceChart(pdf) error = nil, want error")
+	}
+}
+
+// TestReferenceChartCSVRows tests that the CSV chart has one row per color in each table
+func TestReferenceChartCSVRows(t *testing.T) {
+	var b strings.Builder
+	if err := writeReferenceCSV(&b, decoder.Palette{}); err != nil {
+		t.Fatalf("writeReferenceCSV() error = %v", err)
+	}
+
+	reader := csv.NewReader(strings.NewReader(b.String()))
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("chart is not valid CSV: %v", err)
+	}
+	// Two header rows plus each color twice (blank lines are skipped)
+	if want := 2 + 2*len(decoder.Palette{}.Colors()); len(records) != want {
+		t.Errorf("chart has %d records, want %d", len(records), want)
+	}
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"tropical-fish/decoder"
+)
+
+// TestFormatMultiplier tests thousands separators for multiplier values
+func TestFormatMultiplier(t *testing.T) {
+	tests := []struct {
+		multiplier float64
+		expected   string
+	}{
+		{1, "1"},
+		{10, "10"},
+		{1000, "1,000"},
+		{100000, "100,000"},
+		{10000000, "10,000,000"},
+		{100000000, "100,000,000"},
+		{1000000000, "1,000,000,000"},
+		{0.1, "?"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			if got := formatMultiplier(tt.multiplier); got != tt.expected {
+				t.Errorf("formatMultiplier(%v) = %q, want %q", tt.multiplier, got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestRenderResistorColorBand tests that resistor bands render resistor-specific values
+func TestRenderResistorColorBand(t *testing.T) {
+	tests := []struct {
+		name      string
+		color     decoder.Color
+		bandNum   int
+		bandCount int
+		expected  string
+	}{
+		{"4-band White multiplier", decoder.ColorWhite, 3, 4, "×1,000,000,000"},
+		{"5-band White multiplier", decoder.ColorWhite, 4, 5, "×1,000,000,000"},
+		{"5-band Grey multiplier", decoder.ColorGrey, 4, 5, "×100,000,000"},
+		{"4-band Gold multiplier", decoder.ColorGold, 3, 4, "×0.1"},
+		{"5-band third digit", decoder.ColorWhite, 3, 5, "White (9)"},
+		{"5-band Green tolerance", decoder.ColorGreen, 5, 5, "±0.5%"},
+		{"6-band Red temp coefficient", decoder.ColorRed, 6, 6, "50 ppm/°C"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := RenderResistorColorBand(tt.color, tt.bandNum, tt.bandCount, decoder.Palette{})
+			if !strings.Contains(got, tt.expected) {
+				t.Errorf("RenderResistorColorBand() = %q, want it to contain %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestExtendedColorPink tests the Pink ×0.001 multiplier, which can only be
+// entered with the extended color set
+func TestExtendedColorPink(t *testing.T) {
+	// Yellow Violet Pink Gold: 47 × 0.001 = 0.047 Ω
+	reading := decoder.ResistorReading{Band1: decoder.ColorYellow, Band2: decoder.ColorViolet, Band3: decoder.ColorPink, Band4: decoder.ColorGold, BandCount: 4}
+
+	t.Run("standard", func(t *testing.T) {
+		palette := decoder.Palette{}
+		if _, ok := palette.ParseColor("pink"); ok {
+			t.Error("ParseColor(\"pink\") ok, want rejected")
+		}
+		if _, ok := palette.ParseColorAbbrev("PK"); ok {
+			t.Error("ParseColorAbbrev(\"PK\") ok, want rejected")
+		}
+		if names := palette.ColorNames(); len(names) != 12 {
+			t.Errorf("ColorNames() has %d names, want 12", len(names))
+		}
+		if got := GetColorSuggestion("pi", 3, palette); got != "" {
+			t.Errorf("GetColorSuggestion(\"pi\", 3) = %q, want no suggestion", got)
+		}
+		keys := []string{"enter", "r", "4"}
+		for _, name := range []string{"yellow", "violet", "pink"} {
+			keys = append(append(keys, strings.Split(name, "")...), "enter")
+		}
+		if m := pressKeys(initialModel(), keys...); m.currentBand != 3 || m.err == nil {
+			t.Errorf("entering pink moved to band %d with error %v, want it rejected on band 3", m.currentBand, m.err)
+		}
+
+		m := initialModel()
+		m.input, m.reverseBandCount = "0.047 5%", 4
+		if m = m.solveBandsFromValue(); m.err == nil || !strings.Contains(m.err.Error(), "-extended-colors") {
+			t.Errorf("bands for 0.047 Ω error = %v, want a hint to use -extended-colors", m.err)
+		}
+	})
+
+	t.Run("extended", func(t *testing.T) {
+		palette := decoder.Palette{Extended: true}
+		if color, ok := palette.ParseColor("pink"); !ok || color != decoder.ColorPink {
+			t.Errorf("ParseColor(\"pink\") = %v, %v, want Pink", color, ok)
+		}
+		if err := decoder.ValidateResistorReading(&reading); err != nil {
+			t.Fatalf("ValidateResistorReading() error = %v", err)
+		}
+		result, err := decoder.CalculateResistor(reading)
+		if err != nil {
+			t.Fatalf("CalculateResistor() error = %v", err)
+		}
+		if !decoder.ApproxEqual(result.ResistanceOhms, 0.047) {
+			t.Errorf("ResistanceOhms = %v, want 0.047", result.ResistanceOhms)
+		}
+		if names := palette.ColorNames(); names[len(names)-1] != "Pink" {
+			t.Errorf("ColorNames() ends with %q, want Pink", names[len(names)-1])
+		}
+		if got := GetColorSuggestion("pi", 3, palette); got != "nk" {
+			t.Errorf("GetColorSuggestion(\"pi\", 3) = %q, want %q", got, "nk")
+		}
+		if got := GetColorSuggestion("pi", 1, palette); got != "" {
+			t.Errorf("GetColorSuggestion(\"pi\", 1) = %q, want no suggestion on a digit band", got)
+		}
+
+		m := initialModel()
+		m.palette = palette
+		m.input, m.reverseBandCount = "0.047 5%", 4
+		if m = m.solveBandsFromValue(); m.err != nil || m.bandsFromValue == nil || !m.bandsFromValue.Equal(reading) {
+			t.Errorf("bands for 0.047 Ω = %v, %v, want Yellow Violet Pink Gold", m.bandsFromValue, m.err)
+		}
+	})
+}
@@ -0,0 +1,440 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"tropical-fish/decoder"
+)
+
+// ResultsView holds display options for the results box
+type ResultsView struct {
+	ShowBaseUnit    bool    // Show value in base unit (pF / Ω) alongside scaled value
+	CapacitanceUnit string  // Fixed unit for capacitance, e.g. "nF" ("" = auto-scale)
+	ResistanceUnit  string  // Fixed unit for resistance, e.g. "kΩ" ("" = auto-scale)
+	FrequencyHz     float64 // Design frequency for reactance (0 = unset)
+	Note            string  // User note shown under the results
+	Width           int     // Header width (0 = default)
+}
+
+// nextDisplayUnit returns the fixed unit after current in units, going from
+// auto-scaling ("") to the first unit and from the last back to auto-scaling
+func nextDisplayUnit(units []string, current string) string {
+	i := slices.Index(units, current)
+	if i == len(units)-1 {
+		return ""
+	}
+	return units[i+1]
+}
+
+// RenderResultsBox renders the results box for a capacitor or resistor result,
+// independent of the interactive model
+func RenderResultsBox(capResult *decoder.CalculationResult, resResult *decoder.ResistorResult, view ResultsView) string {
+	var b strings.Builder
+	width := view.Width
+	if width <= 0 {
+		width = defaultContentWidth
+	}
+	header := resultHeaderStyle.Width(width)
+	// The rule fills the header inside its padding
+	rule := strings.Repeat("═", width-header.GetHorizontalPadding())
+
+	b.WriteString("\n")
+	b.WriteString(header.Render("RESULTS"))
+	b.WriteString("\n")
+	b.WriteString(header.Render(rule))
+	b.WriteString("\n\n")
+
+	if capResult != nil {
+		result := capResult
+		typeInfo, _ := decoder.GetTypeInfo(result.Reading.CapType)
+
+		// Type and configuration
+		b.WriteString(resultLabelStyle.Render("Component Type:"))
+		b.WriteString("  ")
+		b.WriteString(resultValueStyle.Render("Capacitor"))
+		b.WriteString("\n")
+
+		b.WriteString(resultLabelStyle.Render("Capacitor Type:"))
+		b.WriteString("  ")
+		b.WriteString(resultValueStyle.Render(string(result.Reading.CapType) + " (" + typeInfo.Name + ")"))
+		b.WriteString("\n")
+
+		b.WriteString(resultLabelStyle.Render("Dielectric:"))
+		b.WriteString("  ")
+		b.WriteString(valueStyle.Render(typeInfo.Dielectric + " — " + typeInfo.TypicalUse))
+		b.WriteString("\n")
+
+		b.WriteString(resultLabelStyle.Render("Temp Stability:"))
+		b.WriteString("  ")
+		b.WriteString(valueStyle.Render(typeInfo.TempStability))
+		b.WriteString("\n")
+
+		b.WriteString(resultLabelStyle.Render("Configuration:"))
+		b.WriteString("  ")
+		b.WriteString(resultValueStyle.Render(fmt.Sprintf("%d-band", result.Reading.BandCount)))
+		b.WriteString("\n\n")
+
+		// Capacitance value
+		b.WriteString(labelStyle.Render("CAPACITANCE VALUE:"))
+		b.WriteString("\n")
+		b.WriteString(resultLabelStyle.Render("Value:"))
+		b.WriteString("  ")
+		if view.CapacitanceUnit != "" {
+			b.WriteString(resultValueStyle.Render(decoder.FormatCapacitanceInUnit(result.CapacitancePF, view.CapacitanceUnit)))
+		} else if view.ShowBaseUnit {
+			b.WriteString(resultValueStyle.Render(decoder.FormatCapacitanceWithPF(result.CapacitanceValue, result.CapacitanceUnit, result.CapacitancePF)))
+		} else {
+			b.WriteString(resultValueStyle.Render(decoder.FormatCapacitanceWithUF(result.CapacitanceValue, result.CapacitanceUnit, result.CapacitancePF)))
+		}
+		b.WriteString("\n\n")
+
+		// Tolerance
+		b.WriteString(labelStyle.Render("TOLERANCE:"))
+		b.WriteString("\n")
+		b.WriteString(resultLabelStyle.Render("Specification:"))
+		b.WriteString("  ")
+		tolStr := decoder.FormatTolerance(result)
+		if result.ToleranceImplied {
+			tolStr += " (implied, no tolerance band)"
+		} else if result.ToleranceType == "absolute" {
+			tolStr += " (absolute, value ≤ 10pF)"
+		} else {
+			tolStr += " (percentage-based, value > 10pF)"
+		}
+		b.WriteString(resultValueStyle.Render(tolStr))
+		b.WriteString("\n")
+
+		b.WriteString(resultLabelStyle.Render("Range:"))
+		b.WriteString("  ")
+		if view.CapacitanceUnit != "" {
+			b.WriteString(resultValueStyle.Render(
+				decoder.FormatCapacitanceInUnit(result.MinValue*decoder.CapacitanceUnitPF[result.MinUnit], view.CapacitanceUnit) + " ──► " +
+					decoder.FormatCapacitanceInUnit(result.MaxValue*decoder.CapacitanceUnitPF[result.MaxUnit], view.CapacitanceUnit)))
+		} else {
+			b.WriteString(resultValueStyle.Render(decoder.FormatToleranceRange(result)))
+		}
+		b.WriteString("\n\n")
+
+		// Voltage rating
+		if result.VoltageValid {
+			b.WriteString(labelStyle.Render("VOLTAGE RATING:"))
+			b.WriteString("\n")
+			b.WriteString(resultLabelStyle.Render("Voltage:"))
+			b.WriteString("  ")
+			b.WriteString(resultValueStyle.Render(decoder.FormatVoltage(result) + " (Type " + string(result.Reading.CapType) + " " + typeInfo.Name + ")"))
+			b.WriteString("\n\n")
+		} else if result.Reading.BandCount == 5 {
+			b.WriteString(labelStyle.Render("VOLTAGE RATING:"))
+			b.WriteString("\n")
+			b.WriteString(resultLabelStyle.Render("Voltage:"))
+			b.WriteString("  ")
+			b.WriteString(errorStyle.Render(fmt.Sprintf("%s is a voltage code invalid for Type %s (%s)",
+				decoder.GetColorInfo(result.Reading.Band5).Name, result.Reading.CapType, typeInfo.Name)))
+			b.WriteString("\n\n")
+		}
+
+		// Temperature coefficient
+		if result.TempCoeffValid {
+			b.WriteString(labelStyle.Render("TEMPERATURE COEFFICIENT:"))
+			b.WriteString("\n")
+			b.WriteString(resultLabelStyle.Render("Coefficient:"))
+			b.WriteString("  ")
+			b.WriteString(resultValueStyle.Render(decoder.FormatTempCoefficient(result)))
+			b.WriteString("\n\n")
+		}
+
+		// Reactance at the session design frequency
+		if xc, ok := decoder.CapacitiveReactance(result.CapacitancePF, view.FrequencyHz); ok {
+			b.WriteString(labelStyle.Render("REACTANCE:"))
+			b.WriteString("\n")
+			b.WriteString(resultLabelStyle.Render("Xc @ " + decoder.FormatFrequency(view.FrequencyHz) + ":"))
+			b.WriteString("  ")
+			b.WriteString(resultValueStyle.Render(decoder.FormatReactance(xc)))
+			b.WriteString("\n\n")
+		}
+	} else if resResult != nil {
+		result := resResult
+
+		// Type and configuration
+		b.WriteString(resultLabelStyle.Render("Component Type:"))
+		b.WriteString("  ")
+		b.WriteString(resultValueStyle.Render("Resistor"))
+		b.WriteString("\n")
+
+		b.WriteString(resultLabelStyle.Render("Configuration:"))
+		b.WriteString("  ")
+		b.WriteString(resultValueStyle.Render(result.Reading.Configuration()))
+		b.WriteString("\n\n")
+
+		// Resistance value
+		b.WriteString(labelStyle.Render("RESISTANCE VALUE:"))
+		b.WriteString("\n")
+		b.WriteString(resultLabelStyle.Render("Value:"))
+		b.WriteString("  ")
+		if result.Jumper {
+			b.WriteString(resultValueStyle.Render("0 Ω (jumper / link)"))
+		} else if view.ResistanceUnit != "" {
+			b.WriteString(resultValueStyle.Render(decoder.FormatResistanceInUnit(result.ResistanceOhms, view.ResistanceUnit)))
+		} else if view.ShowBaseUnit {
+			b.WriteString(resultValueStyle.Render(decoder.FormatResistanceWithOhms(result.ResistanceValue, result.ResistanceUnit, result.ResistanceOhms)))
+		} else {
+			b.WriteString(resultValueStyle.Render(decoder.FormatResistance(result.ResistanceValue, result.ResistanceUnit)))
+		}
+		b.WriteString("\n\n")
+
+		// Tolerance (a jumper has no tolerance range to show)
+		if !result.Jumper {
+			b.WriteString(labelStyle.Render("TOLERANCE:"))
+			b.WriteString("\n")
+			b.WriteString(resultLabelStyle.Render("Specification:"))
+			b.WriteString("  ")
+			b.WriteString(resultValueStyle.Render(decoder.FormatResistorTolerance(result)))
+			b.WriteString("\n")
+
+			b.WriteString(resultLabelStyle.Render("Range:"))
+			b.WriteString("  ")
+			if view.ResistanceUnit != "" {
+				b.WriteString(resultValueStyle.Render(
+					decoder.FormatResistanceInUnit(result.MinValue*decoder.ResistanceUnitOhms[result.MinUnit], view.ResistanceUnit) + " ──► " +
+						decoder.FormatResistanceInUnit(result.MaxValue*decoder.ResistanceUnitOhms[result.MaxUnit], view.ResistanceUnit)))
+			} else {
+				b.WriteString(resultValueStyle.Render(decoder.FormatResistorToleranceRange(result)))
+			}
+			b.WriteString("\n\n")
+		}
+
+		// Temperature coefficient (6-band only)
+		if result.TempCoeffValid {
+			b.WriteString(labelStyle.Render("TEMPERATURE COEFFICIENT:"))
+			b.WriteString("\n")
+			b.WriteString(resultLabelStyle.Render("Coefficient:"))
+			b.WriteString("  ")
+			b.WriteString(resultValueStyle.Render(decoder.FormatResistorTempCoefficient(result)))
+			b.WriteString("\n\n")
+		}
+
+		// Reliability (5-band military only)
+		if result.FailureRateValid {
+			b.WriteString(labelStyle.Render("RELIABILITY:"))
+			b.WriteString("\n")
+			b.WriteString(resultLabelStyle.Render("Failure Rate:"))
+			b.WriteString("  ")
+			b.WriteString(resultValueStyle.Render(decoder.FormatFailureRate(result)))
+			b.WriteString("\n\n")
+		}
+	}
+
+	// Current note
+	if view.Note != "" {
+		b.WriteString(labelStyle.Render("NOTE:"))
+		b.WriteString("\n")
+		b.WriteString(resultLabelStyle.Render("User Note:"))
+		b.WriteString("  ")
+		b.WriteString(valueStyle.Render(view.Note))
+		b.WriteString("\n\n")
+	}
+
+	b.WriteString(header.Render(rule))
+	b.WriteString("\n")
+
+	return b.String()
+}
+
+// compactNumber formats a value to three significant digits without trailing zeros
+func compactNumber(v float64) string {
+	if math.Abs(v) >= 1000 {
+		// 'g' would switch to exponent form
+		return strconv.FormatFloat(v, 'f', 0, 64)
+	}
+	return strconv.FormatFloat(v, 'g', 3, 64)
+}
+
+// ReversedReadingWarning returns a warning with the value the bands give when
+// read from the other end, e.g. "Reading reversed would be 120Ω ±1% — check
+// orientation", or "" if the reversed bands are not a valid reading
+func ReversedReadingWarning(reading decoder.ResistorReading) string {
+	reversed, ok := decoder.ReversedReading(reading)
+	if !ok {
+		return ""
+	}
+	result, err := decoder.CalculateResistor(reversed)
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf("Reading reversed would be %s%s ±%s%% — check orientation",
+		compactNumber(result.ResistanceValue), result.ResistanceUnit, compactNumber(result.TolerancePercent))
+}
+
+// compactResistorTolerance formats a resistor tolerance, e.g. "±5%" or
+// "+20/-10%"
+func compactResistorTolerance(result *decoder.ResistorResult) string {
+	if result.ToleranceHigh != result.ToleranceLow {
+		return "+" + compactNumber(result.ToleranceHigh) + "/-" + compactNumber(result.ToleranceLow) + "%"
+	}
+	return "±" + compactNumber(result.TolerancePercent) + "%"
+}
+
+// plainResistorTolerance formats a resistor tolerance for the plain result,
+// e.g. "±0.5%" or "+20% / -10%"
+func plainResistorTolerance(result *decoder.ResistorResult) string {
+	format := func(v float64) string { return strconv.FormatFloat(v, 'f', -1, 64) }
+	if result.ToleranceHigh != result.ToleranceLow {
+		return "+" + format(result.ToleranceHigh) + "% / -" + format(result.ToleranceLow) + "%"
+	}
+	return "±" + format(result.TolerancePercent) + "%"
+}
+
+// compactTolerance formats a capacitor tolerance, e.g. "±5%", "±0.5pF" or "+80/-20%"
+func compactTolerance(result *decoder.CalculationResult) string {
+	switch {
+	case result.ToleranceType == "absolute":
+		return "±" + compactNumber(result.ToleranceAbsolutePF) + "pF"
+	case result.ToleranceSymmetric:
+		return "±" + compactNumber(result.TolerancePercent) + "%"
+	default:
+		return "+" + compactNumber(result.ToleranceHigh) + "/-" + compactNumber(result.ToleranceLow) + "%"
+	}
+}
+
+// ResultSummaryLine returns the value, tolerance and range of a result as
+// plain text for the clipboard and Markdown export, e.g.
+// "4.7kΩ ±5%, range 4.465–4.935 kΩ"
+// Returns "" for an entry without a result
+func ResultSummaryLine(entry ComponentEntry) string {
+	switch {
+	case entry.ComponentType == decoder.ComponentCapacitor && entry.CapacitorResult != nil:
+		result := entry.CapacitorResult
+		return compactNumber(result.CapacitanceValue) + result.CapacitanceUnit + " " + compactTolerance(result) +
+			", range " + decoder.ExplainRange(result.MinValue, result.MinUnit, result.MaxValue, result.MaxUnit)
+	case entry.ComponentType == decoder.ComponentResistor && entry.ResistorResult != nil:
+		result := entry.ResistorResult
+		if result.Jumper {
+			return "0Ω zero-ohm link"
+		}
+		return compactNumber(result.ResistanceValue) + result.ResistanceUnit + " " + compactResistorTolerance(result) +
+			", range " + decoder.ExplainRange(result.MinValue, result.MinUnit, result.MaxValue, result.MaxUnit)
+	}
+	return ""
+}
+
+// RenderCompactResult renders a result on a single line, e.g.
+// "R 4.7kΩ ±5% [4.47k–4.94k]" or "C 100nF ±10% 250V"
+func RenderCompactResult(entry ComponentEntry) string {
+	var parts []string
+
+	switch {
+	case entry.ComponentType == decoder.ComponentCapacitor && entry.CapacitorResult != nil:
+		result := entry.CapacitorResult
+		parts = append(parts, "C", compactNumber(result.CapacitanceValue)+result.CapacitanceUnit)
+
+		parts = append(parts, compactTolerance(result))
+
+		if result.VoltageValid {
+			parts = append(parts, compactNumber(result.VoltageRating)+"V")
+		}
+		if result.TempCoeffValid {
+			parts = append(parts, strconv.Itoa(result.TempCoefficient)+"ppm")
+		}
+
+	case entry.ComponentType == decoder.ComponentResistor && entry.ResistorResult != nil && entry.ResistorResult.Jumper:
+		parts = append(parts, "R", "0Ω", "jumper")
+
+	case entry.ComponentType == decoder.ComponentResistor && entry.ResistorResult != nil:
+		result := entry.ResistorResult
+		parts = append(parts,
+			"R",
+			compactNumber(result.ResistanceValue)+result.ResistanceUnit,
+			compactResistorTolerance(result),
+			"["+compactNumber(result.MinValue)+strings.TrimSuffix(result.MinUnit, "Ω")+
+				"–"+compactNumber(result.MaxValue)+strings.TrimSuffix(result.MaxUnit, "Ω")+"]",
+		)
+		if result.TempCoeffValid {
+			parts = append(parts, strconv.Itoa(result.TempCoefficient)+"ppm")
+		}
+
+	default:
+		return ""
+	}
+
+	if entry.Note != "" {
+		parts = append(parts, "— "+entry.Note)
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// RenderPlainResult renders a result as unstyled "Label: value" lines for
+// scripts, e.g. "Resistance: 4.700 kΩ", naming bands in palette's language
+func RenderPlainResult(entry ComponentEntry, palette decoder.Palette) string {
+	var b strings.Builder
+	line := func(label, value string) {
+		b.WriteString(label + ": " + value + "\n")
+	}
+	bandNames := func(colors []decoder.Color) string {
+		names := make([]string, len(colors))
+		for i, c := range colors {
+			names[i] = palette.ColorName(c)
+		}
+		return strings.Join(names, ", ")
+	}
+
+	switch {
+	case entry.ComponentType == decoder.ComponentCapacitor && entry.CapacitorResult != nil:
+		result := entry.CapacitorResult
+		reading := result.Reading
+		typeInfo, _ := decoder.GetTypeInfo(reading.CapType)
+		colors := []decoder.Color{reading.Band1, reading.Band2, reading.Band3, reading.Band4, reading.Band5}
+
+		line("Component", fmt.Sprintf("Capacitor (%d-band, Type %s %s)", reading.BandCount, reading.CapType, typeInfo.Name))
+		line("Bands", bandNames(colors[:reading.BandCount]))
+		line("Capacitance", decoder.FormatCapacitance(result.CapacitanceValue, result.CapacitanceUnit))
+		tolerance := decoder.FormatTolerance(result)
+		if result.ToleranceImplied {
+			tolerance += " (implied)"
+		}
+		line("Tolerance", tolerance)
+		line("Range", decoder.FormatCapacitance(result.MinValue, result.MinUnit)+" to "+decoder.FormatCapacitance(result.MaxValue, result.MaxUnit))
+		if result.VoltageValid {
+			line("Voltage", decoder.FormatVoltage(result))
+		}
+		if result.TempCoeffValid {
+			line("Temp coefficient", decoder.FormatTempCoefficient(result))
+		}
+
+	case entry.ComponentType == decoder.ComponentResistor && entry.ResistorResult != nil:
+		result := entry.ResistorResult
+		reading := result.Reading
+		colors := []decoder.Color{reading.Band1, reading.Band2, reading.Band3, reading.Band4, reading.Band5, reading.Band6}
+
+		line("Component", "Resistor ("+reading.Configuration()+")")
+		line("Bands", bandNames(colors[:reading.BandCount]))
+		line("Resistance", decoder.FormatResistance(result.ResistanceValue, result.ResistanceUnit))
+		if result.Jumper {
+			// A jumper has no tolerance, even with a tolerance band
+			line("Tolerance", "none (jumper / link)")
+			line("Range", "none (zero-ohm link)")
+		} else {
+			line("Tolerance", plainResistorTolerance(result))
+			line("Range", decoder.FormatResistance(result.MinValue, result.MinUnit)+" to "+decoder.FormatResistance(result.MaxValue, result.MaxUnit))
+		}
+		if result.TempCoeffValid {
+			line("Temp coefficient", decoder.FormatResistorTempCoefficient(result))
+		}
+		if result.FailureRateValid {
+			line("Failure rate", decoder.FormatFailureRate(result))
+		}
+
+	default:
+		return ""
+	}
+
+	if entry.Note != "" {
+		line("Note", entry.Note)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"tropical-fish/decoder"
+)
+
+// TestRenderCompactResult tests the one-line result format
+func TestRenderCompactResult(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     cliOptions
+		note     string
+		expected string
+	}{
+		{
+			name:     "4-band resistor",
+			opts:     cliOptions{resistor: true, bands: "yellow,violet,red,gold"},
+			expected: "R 4.7kΩ ±5% [4.46k–4.93k]",
+		},
+		{
+			name:     "Resistor range spanning units",
+			opts:     cliOptions{resistor: true, bands: "brown,black,red,silver"},
+			expected: "R 1kΩ ±10% [900–1.1k]",
+		},
+		{
+			name:     "Capacitor with voltage",
+			opts:     cliOptions{capacitor: true, capType: "K", bands: "red,violet,orange,brown,orange"},
+			expected: "C 27nF ±1% 400V -150ppm",
+		},
+		{
+			name:     "Note appended",
+			opts:     cliOptions{resistor: true, bands: "brown,black,red,gold"},
+			note:     "R7",
+			expected: "R 1kΩ ±5% [950–1.05k] — R7",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry := mustDecode(t, tt.opts, tt.note)
+			if got := RenderCompactResult(entry); got != tt.expected {
+				t.Errorf("RenderCompactResult() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestResultSummaryLine tests the one-line summary copied to the clipboard
+func TestResultSummaryLine(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     cliOptions
+		expected string
+	}{
+		{
+			name:     "4-band resistor",
+			opts:     cliOptions{resistor: true, bands: "yellow,violet,red,gold"},
+			expected: "4.7kΩ ±5%, range 4.465–4.935 kΩ",
+		},
+		{
+			name:     "Resistor range spanning units",
+			opts:     cliOptions{resistor: true, bands: "brown,black,red,gold"},
+			expected: "1kΩ ±5%, range 950 Ω–1.05 kΩ",
+		},
+		{
+			name:     "Capacitor",
+			opts:     cliOptions{capacitor: true, capType: "K", bands: "red,violet,orange,brown,orange"},
+			expected: "27nF ±1%, range 26.73–27.27 nF",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry := mustDecode(t, tt.opts, "")
+			if got := ResultSummaryLine(entry); got != tt.expected {
+				t.Errorf("ResultSummaryLine() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+
+	if got := ResultSummaryLine(ComponentEntry{}); got != "" {
+		t.Errorf("ResultSummaryLine() without a result = %q, want \"\"", got)
+	}
+}
+
+// TestRenderPlainResult tests the unstyled result printed by flag decoding
+func TestRenderPlainResult(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     cliOptions
+		expected string
+	}{
+		{
+			name: "4-band resistor",
+			opts: cliOptions{resistor: true, bands: "brown,black,red,gold"},
+			expected: "Component: Resistor (4-band)\n" +
+				"Bands: Brown, Black, Red, Gold\n" +
+				"Resistance: 1.000 kΩ\n" +
+				"Tolerance: ±5%\n" +
+				"Range: 950.0 Ω to 1.050 kΩ\n",
+		},
+		{
+			name: "5-band capacitor",
+			opts: cliOptions{capacitor: true, capType: "K", bands: "red,violet,orange,brown,orange"},
+			expected: "Component: Capacitor (5-band, Type K Mica)\n" +
+				"Bands: Red, Violet, Orange, Brown, Orange\n" +
+				"Capacitance: 27.00 nF\n" +
+				"Tolerance: ±1%\n" +
+				"Range: 26.73 nF to 27.27 nF\n" +
+				"Voltage: 400 V\n" +
+				"Temp coefficient: -150 × 10⁻⁶ /°C\n",
+		},
+		{
+			name: "3-band capacitor",
+			opts: cliOptions{capacitor: true, capType: "N", bands: "red,violet,orange"},
+			expected: "Component: Capacitor (3-band, Type N Electrolytic (3-band style))\n" +
+				"Bands: Red, Violet, Orange\n" +
+				"Capacitance: 27.00 nF\n" +
+				"Tolerance: ±20% (implied)\n" +
+				"Range: 21.60 nF to 32.40 nF\n",
+		},
+		{
+			name: "zero-ohm resistor",
+			opts: cliOptions{resistor: true, bands: "black,black,black,gold"},
+			expected: "Component: Resistor (4-band)\n" +
+				"Bands: Black, Black, Black, Gold\n" +
+				"Resistance: 0 Ω\n" +
+				"Tolerance: none (jumper / link)\n" +
+				"Range: none (zero-ohm link)\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry := mustDecode(t, tt.opts, "")
+			if got := RenderPlainResult(entry, decoder.Palette{}); got != tt.expected {
+				t.Errorf("RenderPlainResult() =\n%s\nwant\n%s", got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestReversedReadingWarning tests the orientation warning text
+func TestReversedReadingWarning(t *testing.T) {
+	tests := []struct {
+		name   string
+		colors []decoder.Color
+		want   string
+	}{
+		{"1k reads as 12 ohms reversed", []decoder.Color{decoder.ColorBrown, decoder.ColorBlack, decoder.ColorRed, decoder.ColorBrown},
+			"Reading reversed would be 12Ω ±1% — check orientation"},
+		{"5-band 1k reads as 110 ohms reversed", []decoder.Color{decoder.ColorBrown, decoder.ColorBlack, decoder.ColorBlack, decoder.ColorBrown, decoder.ColorBrown},
+			"Reading reversed would be 110Ω ±1% — check orientation"},
+		{"gold tolerance is unambiguous", []decoder.Color{decoder.ColorOrange, decoder.ColorOrange, decoder.ColorBrown, decoder.ColorGold}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reading, err := decoder.ResistorReadingFromColors(tt.colors)
+			if err != nil {
+				t.Fatalf("ResistorReadingFromColors() error = %v", err)
+			}
+			if got := ReversedReadingWarning(reading); got != tt.want {
+				t.Errorf("ReversedReadingWarning() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestAsymmetricResistorTolerance tests that each renderer shows both bounds
+// of an asymmetric resistor tolerance
+func TestAsymmetricResistorTolerance(t *testing.T) {
+	entry := mustDecode(t, cliOptions{resistor: true, bands: "brown,black,red,gold"}, "")
+	result := *entry.ResistorResult
+	result.TolerancePercent, result.ToleranceHigh, result.ToleranceLow = 20, 20, 10
+	result.MinValue, result.MinUnit, result.MaxValue, result.MaxUnit = 900, "Ω", 1.2, "kΩ"
+	entry.ResistorResult = &result
+
+	if got, want := ResultSummaryLine(entry), "1kΩ +20/-10%, range 900 Ω–1.2 kΩ"; got != want {
+		t.Errorf("ResultSummaryLine() = %q, want %q", got, want)
+	}
+	if got, want := RenderCompactResult(entry), "R 1kΩ +20/-10% [900–1.2k]"; got != want {
+		t.Errorf("RenderCompactResult() = %q, want %q", got, want)
+	}
+	if got := RenderPlainResult(entry, decoder.Palette{}); !strings.Contains(got, "Tolerance: +20% / -10%\n") {
+		t.Errorf("RenderPlainResult() = %q, want both tolerance bounds", got)
+	}
+	if got := BOMRow(entry); got[1] != "+20/-10%" {
+		t.Errorf("BOMRow() tolerance = %q, want %q", got[1], "+20/-10%")
+	}
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"tropical-fish/decoder"
+)
+
+// ValueSummary aggregates the decoded values of one component kind, in pF for
+// capacitors or Ω for resistors
+type ValueSummary struct {
+	Count           int
+	Min             float64
+	Max             float64
+	MostCommon      float64 // The first value to reach the highest count
+	MostCommonCount int
+}
+
+// add counts a value, keeping the most common value seen first on a tie
+func (s *ValueSummary) add(value float64, counts map[string]int, firstSeen map[string]float64) {
+	if s.Count == 0 || value < s.Min {
+		s.Min = value
+	}
+	if s.Count == 0 || value > s.Max {
+		s.Max = value
+	}
+	s.Count++
+
+	// Key on the rounded value so floating point noise doesn't split a value
+	key := decoder.ExplainNumber(value)
+	if _, ok := firstSeen[key]; !ok {
+		firstSeen[key] = value
+	}
+	counts[key]++
+	if counts[key] > s.MostCommonCount {
+		s.MostCommon = firstSeen[key]
+		s.MostCommonCount = counts[key]
+	}
+}
+
+// HistorySummary holds aggregates over the decoded history
+type HistorySummary struct {
+	Total      int                           // Entries with a decoded result
+	Capacitors ValueSummary                  // Capacitances in pF
+	Resistors  ValueSummary                  // Resistances in Ω
+	ByCapType  map[decoder.CapacitorType]int // Capacitors by type letter
+}
+
+// SummarizeHistory counts the decoded entries by component and capacitor
+// type, with the smallest, largest and most common value of each component.
+// Entries without a result are skipped.
+func SummarizeHistory(history []ComponentEntry) HistorySummary {
+	summary := HistorySummary{ByCapType: map[decoder.CapacitorType]int{}}
+	capCounts, capFirst := map[string]int{}, map[string]float64{}
+	resCounts, resFirst := map[string]int{}, map[string]float64{}
+
+	for _, entry := range history {
+		switch {
+		case entry.ComponentType == decoder.ComponentCapacitor && entry.CapacitorResult != nil:
+			result := entry.CapacitorResult
+			summary.Capacitors.add(result.CapacitancePF, capCounts, capFirst)
+			summary.ByCapType[result.Reading.CapType]++
+		case entry.ComponentType == decoder.ComponentResistor && entry.ResistorResult != nil:
+			summary.Resistors.add(entry.ResistorResult.ResistanceOhms, resCounts, resFirst)
+		default:
+			continue
+		}
+		summary.Total++
+	}
+
+	return summary
+}
+
+// HistorySummaryLines returns the summary as lines for the history screen, e.g.
+//
+//	5 decoded: 3 capacitors, 2 resistors
+//	Capacitors by type: K 2, N 1
+//	Capacitors: 10 pF to 100 nF, most common 100 nF (×2)
+//	Resistors: 1 kΩ to 10 kΩ, most common 1 kΩ (×1)
+func HistorySummaryLines(summary HistorySummary) []string {
+	if summary.Total == 0 {
+		return []string{"Nothing decoded yet."}
+	}
+
+	lines := []string{fmt.Sprintf("%d decoded: %d capacitor%s, %d resistor%s", summary.Total,
+		summary.Capacitors.Count, map[bool]string{true: "", false: "s"}[summary.Capacitors.Count == 1],
+		summary.Resistors.Count, map[bool]string{true: "", false: "s"}[summary.Resistors.Count == 1])}
+
+	if summary.Capacitors.Count > 0 {
+		var types []string
+		for _, capType := range decoder.AllCapacitorTypes() {
+			if n := summary.ByCapType[decoder.CapacitorType(capType)]; n > 0 {
+				types = append(types, fmt.Sprintf("%s %d", capType, n))
+			}
+		}
+		lines = append(lines, "Capacitors by type: "+strings.Join(types, ", "))
+		lines = append(lines, valueSummaryLine("Capacitors", summary.Capacitors, decoder.FormatCapacitanceValue))
+	}
+	if summary.Resistors.Count > 0 {
+		lines = append(lines, valueSummaryLine("Resistors", summary.Resistors, decoder.FormatResistanceValue))
+	}
+
+	return lines
+}
+
+// valueSummaryLine formats the range and most common value of one component kind
+func valueSummaryLine(label string, s ValueSummary, format func(float64) string) string {
+	return fmt.Sprintf("%s: %s to %s, most common %s (×%d)",
+		label, format(s.Min), format(s.Max), format(s.MostCommon), s.MostCommonCount)
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	"tropical-fish/decoder"
+)
+
+// TestSummarizeHistory tests the history counts, value ranges and most
+// common values, including empty and mixed histories
+func TestSummarizeHistory(t *testing.T) {
+	capacitor := func(bands, capType string) ComponentEntry {
+		return mustDecode(t, cliOptions{bands: bands, capType: capType}, "")
+	}
+	resistor := func(bands string) ComponentEntry {
+		return mustDecode(t, cliOptions{resistor: true, bands: bands}, "")
+	}
+
+	tests := []struct {
+		name      string
+		history   []ComponentEntry
+		wantLines []string
+	}{
+		{"Empty", nil, []string{"Nothing decoded yet."}},
+		{
+			"Capacitors only",
+			[]ComponentEntry{
+				capacitor("brown,black,yellow,white", "K"),
+				capacitor("red,red,red,white", "N"),
+				capacitor("brown,black,yellow,white", "K"),
+			},
+			[]string{
+				"3 decoded: 3 capacitors, 0 resistors",
+				"Capacitors by type: K 2, N 1",
+				"Capacitors: 2.2 nF to 100 nF, most common 100 nF (×2)",
+			},
+		},
+		{
+			"Mixed, ties go to the first value",
+			[]ComponentEntry{
+				resistor("yellow,violet,red,gold"),
+				capacitor("red,red,red,white", "J"),
+				resistor("brown,black,red,gold"),
+				{ComponentType: decoder.ComponentResistor}, // No result
+			},
+			[]string{
+				"3 decoded: 1 capacitor, 2 resistors",
+				"Capacitors by type: J 1",
+				"Capacitors: 2.2 nF to 2.2 nF, most common 2.2 nF (×1)",
+				"Resistors: 1 kΩ to 4.7 kΩ, most common 4.7 kΩ (×1)",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := HistorySummaryLines(SummarizeHistory(tt.history))
+			if !slices.Equal(got, tt.wantLines) {
+				t.Errorf("HistorySummaryLines() =\n%q\nwant\n%q", got, tt.wantLines)
+			}
+		})
+	}
+}
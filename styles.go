@@ -1,7 +1,15 @@
 package main
 
 import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
 	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+
+	"tropical-fish/decoder"
 )
 
 // Color palette
@@ -72,6 +80,11 @@ var (
 			Background(lipgloss.Color("#1C1C1C")).
 			Padding(0, 1)
 
+	invalidInputStyle = lipgloss.NewStyle().
+				Foreground(colorWhite).
+				Background(colorError).
+				Padding(0, 1)
+
 	errorStyle = lipgloss.NewStyle().
 			Foreground(colorError).
 			Bold(true).
@@ -81,6 +94,10 @@ var (
 			Foreground(colorSuccess).
 			Bold(true)
 
+	warningStyle = lipgloss.NewStyle().
+			Foreground(colorWarning).
+			Bold(true)
+
 	mutedStyle = lipgloss.NewStyle().
 			Foreground(colorMuted).
 			Italic(true)
@@ -135,62 +152,126 @@ var (
 			MarginBottom(1)
 )
 
+// plainColors reports whether colors are off (-no-color, NO_COLOR or output
+// that isn't a terminal), so bands are named rather than drawn as swatches
+func plainColors() bool {
+	return lipgloss.ColorProfile() == termenv.Ascii
+}
+
+// renderSwatch renders text on the swatch of a band color, or as "[text]"
+// without color support so the band still stands out, e.g. "[Red (2)]"
+func renderSwatch(color decoder.Color, text string) string {
+	if plainColors() {
+		return "[" + strings.TrimSpace(text) + "]"
+	}
+	return GetColorStyle(color).Render(text)
+}
+
 // GetColorStyle returns a lipgloss style for a capacitor color
-func GetColorStyle(color Color) lipgloss.Style {
-	// Map capacitor colors to terminal colors
-	var termColor lipgloss.Color
+func GetColorStyle(color decoder.Color) lipgloss.Style {
+	background, text := colorSwatchHex(color)
+	return lipgloss.NewStyle().
+		Foreground(lipgloss.Color(text)).
+		Background(lipgloss.Color(background)).
+		Bold(true).
+		Padding(0, 2)
+}
+
+// colorSwatchHex returns the background and readable text colors of a band
+// color swatch as hex codes
+func colorSwatchHex(color decoder.Color) (background, text string) {
 	switch color {
-	case ColorBlack:
-		termColor = lipgloss.Color("#000000")
-	case ColorBrown:
-		termColor = lipgloss.Color("#8B4513")
-	case ColorRed:
-		termColor = lipgloss.Color("#FF0000")
-	case ColorOrange:
-		termColor = lipgloss.Color("#FF8C00")
-	case ColorYellow:
-		termColor = lipgloss.Color("#FFFF00")
-	case ColorGreen:
-		termColor = lipgloss.Color("#00FF00")
-	case ColorBlue:
-		termColor = lipgloss.Color("#0000FF")
-	case ColorViolet:
-		termColor = lipgloss.Color("#9400D3")
-	case ColorGrey:
-		termColor = lipgloss.Color("#808080")
-	case ColorWhite:
-		termColor = lipgloss.Color("#FFFFFF")
-	case ColorGold:
-		termColor = lipgloss.Color("#FFD700")
-	case ColorSilver:
-		termColor = lipgloss.Color("#C0C0C0")
+	case decoder.ColorBlack:
+		background = "#000000"
+	case decoder.ColorBrown:
+		background = "#8B4513"
+	case decoder.ColorRed:
+		background = "#FF0000"
+	case decoder.ColorOrange:
+		background = "#FF8C00"
+	case decoder.ColorYellow:
+		background = "#FFFF00"
+	case decoder.ColorGreen:
+		background = "#00FF00"
+	case decoder.ColorBlue:
+		background = "#0000FF"
+	case decoder.ColorViolet:
+		background = "#9400D3"
+	case decoder.ColorGrey:
+		background = "#808080"
+	case decoder.ColorWhite:
+		background = "#FFFFFF"
+	case decoder.ColorGold:
+		background = "#FFD700"
+	case decoder.ColorSilver:
+		background = "#C0C0C0"
+	case decoder.ColorPink:
+		background = "#FFC0CB"
 	default:
-		termColor = lipgloss.Color("#FFFFFF")
+		background = "#FFFFFF"
 	}
 
 	// For light colors, use dark text; for dark colors, use light text
-	textColor := lipgloss.Color("#000000")
-	if color == ColorBlack || color == ColorBrown || color == ColorRed ||
-		color == ColorBlue || color == ColorViolet || color == ColorGrey {
-		textColor = lipgloss.Color("#FFFFFF")
+	text = "#000000"
+	if color == decoder.ColorBlack || color == decoder.ColorBrown || color == decoder.ColorRed ||
+		color == decoder.ColorBlue || color == decoder.ColorViolet || color == decoder.ColorGrey {
+		text = "#FFFFFF"
 	}
 
-	return lipgloss.NewStyle().
-		Foreground(textColor).
-		Background(termColor).
-		Bold(true).
-		Padding(0, 2)
+	return background, text
 }
 
-// RenderColorBand renders a color band with its name and value
-func RenderColorBand(color Color, bandNum int) string {
-	info := GetColorInfo(color)
-	style := GetColorStyle(color)
+// bandStripBodyStyle is the part body behind the bands in RenderBandStrip
+var bandStripBodyStyle = lipgloss.NewStyle().Background(lipgloss.Color("#D2B48C"))
+
+// RenderBandStrip draws the bands on a part body as they appear on the
+// component, e.g. "──┤ ██ ██ ██   ██ ├──". Every band is set off by body color
+// so adjacent bands of the same color stay distinct, and a trailing Gold or
+// Silver tolerance band sits apart from the rest as on a real resistor.
+// Without color support each band shows its two-letter abbreviation instead.
+func RenderBandStrip(colors []decoder.Color) string {
+	if len(colors) == 0 {
+		return ""
+	}
+	plain := plainColors()
+
+	var b strings.Builder
+	body := func(width int) {
+		b.WriteString(bandStripBodyStyle.Render(strings.Repeat(" ", width)))
+	}
+
+	b.WriteString(mutedStyle.Render("──┤"))
+	body(1)
+	for i, color := range colors {
+		if i > 0 {
+			gap := 1
+			if i == len(colors)-1 && (color == decoder.ColorGold || color == decoder.ColorSilver) {
+				gap = 3
+			}
+			body(gap)
+		}
+		if plain {
+			b.WriteString(decoder.ColorAbbrev(color))
+		} else {
+			b.WriteString(GetColorStyle(color).UnsetPadding().Render("  "))
+		}
+	}
+	body(1)
+	b.WriteString(mutedStyle.Render("├──"))
+
+	return b.String()
+}
+
+// RenderColorBand renders a color band with its name in palette's language
+// and its value
+func RenderColorBand(color decoder.Color, bandNum int, palette decoder.Palette) string {
+	info := decoder.GetColorInfo(color)
+	info.Name = palette.ColorName(color)
 
 	var value string
 	switch bandNum {
 	case 1, 2:
-		value = info.Name + " (" + string(rune('0'+info.Digit)) + ")"
+		value = formatDigitBand(info)
 	case 3:
 		if info.Multiplier >= 1 {
 			value = info.Name + " (×" + formatMultiplier(info.Multiplier) + ")"
@@ -198,7 +279,7 @@ func RenderColorBand(color Color, bandNum int) string {
 			value = info.Name + " (×" + formatMultiplierDecimal(info.Multiplier) + ")"
 		}
 	case 4:
-		tolInfo, _ := GetToleranceInfo(color)
+		tolInfo, _ := decoder.GetToleranceInfo(color)
 		if tolInfo.Symmetric {
 			value = info.Name + " (±" + formatFloat(tolInfo.PercentHigh) + "%)"
 		} else {
@@ -208,33 +289,128 @@ func RenderColorBand(color Color, bandNum int) string {
 		value = info.Name + " (voltage code)"
 	}
 
-	return style.Render(" " + value + " ")
+	return renderSwatch(color, " "+value+" ")
 }
 
-// Helper functions for formatting
-func formatMultiplier(m float64) string {
-	if m == 1 {
-		return "1"
+// RenderResistorColorBand renders a resistor color band with its name and value
+// Band roles depend on the band count, and multipliers come from resistorMultiplierMap
+func RenderResistorColorBand(color decoder.Color, bandNum int, bandCount int, palette decoder.Palette) string {
+	info := decoder.GetColorInfo(color)
+	info.Name = palette.ColorName(color)
+
+	multiplierBand := 4
+	if bandCount == 4 {
+		multiplierBand = 3
 	}
-	if m == 10 {
-		return "10"
+
+	var value string
+	switch {
+	case bandNum < multiplierBand:
+		value = formatDigitBand(info)
+	case bandNum == multiplierBand:
+		mult, _ := decoder.GetResistorMultiplier(color)
+		if mult >= 1 {
+			value = info.Name + " (×" + formatMultiplier(mult) + ")"
+		} else {
+			value = info.Name + " (×" + formatMultiplierDecimal(mult) + ")"
+		}
+	case bandNum == multiplierBand+1:
+		tolInfo, _ := decoder.GetResistorTolerance(color)
+		value = info.Name + " (±" + strconv.FormatFloat(tolInfo.Percent, 'f', -1, 64) + "%)"
+	default:
+		coeff, _ := decoder.GetResistorTempCoefficient(color)
+		value = info.Name + " (" + strconv.Itoa(coeff) + " ppm/°C)"
 	}
-	if m == 100 {
-		return "100"
+
+	return renderSwatch(color, " "+value+" ")
+}
+
+// RenderResistorReadingBand renders a band of a resistor reading with its
+// name and value, following the reading's layout
+func RenderResistorReadingBand(reading decoder.ResistorReading, bandNum int, palette decoder.Palette) string {
+	colors := []decoder.Color{reading.Band1, reading.Band2, reading.Band3, reading.Band4, reading.Band5, reading.Band6}
+	if bandNum < 1 || bandNum > len(colors) {
+		return ""
 	}
-	if m == 1000 {
-		return "1,000"
+	color := colors[bandNum-1]
+
+	if !reading.Military {
+		return RenderResistorColorBand(color, bandNum, reading.BandCount, palette)
 	}
-	if m == 10000 {
-		return "10,000"
+	if bandNum < 5 {
+		return RenderResistorColorBand(color, bandNum, 4, palette)
 	}
-	if m == 100000 {
-		return "100,000"
+	rate, _ := decoder.GetReliabilityBand(color)
+	value := palette.ColorName(color) + " (" + strconv.FormatFloat(rate, 'f', -1, 64) + "%/1000 h)"
+	return renderSwatch(color, " "+value+" ")
+}
+
+// RenderVoltageCodeTable renders the band 5 voltage codes of a capacitor
+// type as color swatches, four per line
+func RenderVoltageCodeTable(capType decoder.CapacitorType, palette decoder.Palette) string {
+	const perLine = 4
+
+	var b strings.Builder
+	for i, code := range decoder.VoltageCodes(capType) {
+		if i > 0 && i%perLine == 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(GetColorStyle(code.Color).Width(10).Render(palette.ColorName(code.Color)))
+		b.WriteString(valueStyle.Render(fmt.Sprintf(" %-6s", strconv.FormatFloat(code.Volts, 'f', -1, 64)+"V")))
 	}
-	if m == 1000000 {
-		return "1,000,000"
+	return b.String()
+}
+
+// RenderVoltageTable renders the band 5 voltage codes of a capacitor type as
+// an aligned color, digit and voltage table, marking the code rated exactly
+// volts (0 marks none)
+func RenderVoltageTable(capType decoder.CapacitorType, volts float64, palette decoder.Palette) string {
+	var b strings.Builder
+	b.WriteString(labelStyle.Render(fmt.Sprintf("  %-10s %-6s %s", "Color", "Digit", "Voltage")))
+	for _, code := range decoder.VoltageCodes(capType) {
+		b.WriteString("\n")
+		row := fmt.Sprintf(" %-6d %s", decoder.GetColorInfo(code.Color).Digit, strconv.FormatFloat(code.Volts, 'f', -1, 64)+" V")
+		if volts > 0 && code.Volts == volts {
+			b.WriteString(successStyle.Render("▶ "))
+			b.WriteString(GetColorStyle(code.Color).Width(10).Render(palette.ColorName(code.Color)))
+			b.WriteString(successStyle.Render(row))
+			continue
+		}
+		b.WriteString("  ")
+		b.WriteString(GetColorStyle(code.Color).Width(10).Render(palette.ColorName(code.Color)))
+		b.WriteString(valueStyle.Render(row))
 	}
-	return "?"
+	return b.String()
+}
+
+// Helper functions for formatting
+
+// formatDigitBand renders a digit band as "Name (d)", or "Name (n/a as digit)"
+// for colors that have no valid digit value (Gold, Silver) so a mistyped band
+// shown before re-validation is clearly marked
+func formatDigitBand(info decoder.ColorInfo) string {
+	if !info.ValidDigit || info.Digit < 0 || info.Digit > 9 {
+		return info.Name + " (n/a as digit)"
+	}
+	return info.Name + " (" + strconv.Itoa(info.Digit) + ")"
+}
+
+// formatMultiplier renders a whole-number multiplier with thousands separators
+// (e.g. 1000000000 → "1,000,000,000")
+func formatMultiplier(m float64) string {
+	if m < 1 || m != math.Trunc(m) {
+		return "?"
+	}
+
+	digits := strconv.FormatFloat(m, 'f', 0, 64)
+	var b strings.Builder
+	for i, d := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(d)
+	}
+	return b.String()
 }
 
 func formatMultiplierDecimal(m float64) string {
@@ -244,20 +420,39 @@ func formatMultiplierDecimal(m float64) string {
 	if m == 0.01 {
 		return "0.01"
 	}
+	if m == 0.001 {
+		return "0.001"
+	}
 	return "?"
 }
 
+// formatFloat formats a tolerance percentage without trailing zeros
+// (e.g. 80 → "80", 0.5 → "0.5")
 func formatFloat(f float64) string {
-	if f == float64(int(f)) {
-		return lipgloss.NewStyle().Render(string(rune('0' + int(f))))
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// Widths of separators and result headers
+const (
+	defaultContentWidth = 64 // Until the terminal reports its size
+	minContentWidth     = 32
+	maxContentWidth     = 80
+)
+
+// contentWidth returns the separator and header width for a terminal of the
+// given width, clamped so narrow terminals don't wrap them and wide ones
+// don't stretch them past the results
+func contentWidth(terminalWidth int) int {
+	if terminalWidth <= 0 {
+		return defaultContentWidth
 	}
-	return lipgloss.NewStyle().Render("?")
+	return min(max(terminalWidth, minContentWidth), maxContentWidth)
 }
 
 // RenderSeparator renders a visual separator
 func RenderSeparator(width int) string {
 	if width <= 0 {
-		width = 64
+		width = defaultContentWidth
 	}
 	return mutedStyle.Render(lipgloss.NewStyle().Width(width).Render("─" + lipgloss.NewStyle().Render(repeatString("─", width-2)) + "─"))
 }
@@ -0,0 +1,235 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+
+	"tropical-fish/decoder"
+)
+
+// TestRenderColorBandDigitGuard tests that non-digit colors in a digit band
+// render as "n/a as digit" without a bogus digit or control character
+func TestRenderColorBandDigitGuard(t *testing.T) {
+	tests := []struct {
+		name     string
+		rendered string
+		expected string
+	}{
+		{"Capacitor Gold band 1", RenderColorBand(decoder.ColorGold, 1, decoder.Palette{}), "Gold (n/a as digit)"},
+		{"Capacitor Silver band 2", RenderColorBand(decoder.ColorSilver, 2, decoder.Palette{}), "Silver (n/a as digit)"},
+		{"Resistor Gold band 1", RenderResistorColorBand(decoder.ColorGold, 1, 4, decoder.Palette{}), "Gold (n/a as digit)"},
+		{"Resistor Silver band 3 of 5", RenderResistorColorBand(decoder.ColorSilver, 3, 5, decoder.Palette{}), "Silver (n/a as digit)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !strings.Contains(tt.rendered, tt.expected) {
+				t.Errorf("rendered %q, want it to contain %q", tt.rendered, tt.expected)
+			}
+			if !utf8.ValidString(tt.rendered) {
+				t.Errorf("rendered %q, want valid UTF-8", tt.rendered)
+			}
+			for _, r := range tt.rendered {
+				// Styling escapes start with ESC; anything else is a bogus rune
+				if unicode.IsControl(r) && r != '\x1b' {
+					t.Errorf("rendered %q contains control character %U", tt.rendered, r)
+				}
+			}
+		})
+	}
+
+	if got := RenderColorBand(decoder.ColorRed, 1, decoder.Palette{}); !strings.Contains(got, "Red (2)") {
+		t.Errorf("RenderColorBand(Red, 1) = %q, want it to contain %q", got, "Red (2)")
+	}
+}
+
+// TestRenderVoltageCodeTable tests the type-specific band 5 voltage legend
+func TestRenderVoltageCodeTable(t *testing.T) {
+	tests := []struct {
+		name       string
+		capType    decoder.CapacitorType
+		expected   []string
+		unexpected []string
+	}{
+		{"Type M fractional voltages", decoder.TypeM, []string{"Brown", "1.6V", "6.3V", "40V"}, []string{"Black"}},
+		{"Type N 6.3V on Red", decoder.TypeN, []string{"Red", "6.3V", "35V"}, nil},
+		{"Type K mica voltages", decoder.TypeK, []string{"Brown", "100V", "1000V"}, []string{"1.6V"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := RenderVoltageCodeTable(tt.capType, decoder.Palette{})
+			for _, want := range tt.expected {
+				if !strings.Contains(table, want) {
+					t.Errorf("table missing %q:\n%s", want, table)
+				}
+			}
+			for _, unwanted := range tt.unexpected {
+				if strings.Contains(table, unwanted) {
+					t.Errorf("table contains %q:\n%s", unwanted, table)
+				}
+			}
+		})
+	}
+
+	// Every listed code must decode to the voltage shown
+	for _, code := range decoder.VoltageCodes(decoder.TypeM) {
+		if volts, ok := decoder.GetVoltageRatingFractional(decoder.TypeM, code.Color); !ok || volts != code.Volts {
+			t.Errorf("VoltageCodes(M) lists %v as %vV, GetVoltageRatingFractional = %v, %v", code.Color, code.Volts, volts, ok)
+		}
+	}
+}
+
+// TestVoltageTableScreen tests opening the voltage code table from type
+// selection, switching type, searching for a voltage and decoding that type
+func TestVoltageTableScreen(t *testing.T) {
+	m := initialModel()
+	m.screen = screenTypeSelection
+	m = pressKeys(m, "v", "m", "6", ".", "3")
+	if m.screen != screenVoltageTable || m.voltageTableType != decoder.TypeM {
+		t.Fatalf("screen %v, type %s, want voltage table for Type M", m.screen, m.voltageTableType)
+	}
+	if view := m.View(); !strings.Contains(view, "Yellow marks 6.3 V on Type M") {
+		t.Errorf("search for 6.3 V missing its code:\n%s", view)
+	}
+
+	m = pressKeys(m, "backspace", "backspace", "backspace", "7")
+	if view := m.View(); !strings.Contains(view, "No Type M code for 7 V; the next rating up is 10 V (Green)") {
+		t.Errorf("search for 7 V missing the next rating up:\n%s", view)
+	}
+
+	m = pressKeys(m, "enter")
+	if m.screen != screenBandCountSelection || m.capacitorReading.CapType != decoder.TypeM || m.capacitorReading.BandCount != 4 {
+		t.Errorf("Enter gave screen %v, reading %+v, want Type M band count", m.screen, m.capacitorReading)
+	}
+	if m = m.goBack(); m.screen != screenTypeSelection {
+		t.Errorf("back from band count = screen %v, want type selection", m.screen)
+	}
+}
+
+// TestFormatFloat tests tolerance percentages with and without decimals
+func TestFormatFloat(t *testing.T) {
+	tests := []struct {
+		value float64
+		want  string
+	}{
+		{80, "80"},
+		{20, "20"},
+		{0.5, "0.5"},
+		{10, "10"},
+		{5, "5"},
+		{0.25, "0.25"},
+	}
+
+	for _, tt := range tests {
+		if got := formatFloat(tt.value); got != tt.want {
+			t.Errorf("formatFloat(%v) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+
+	// The Grey band renders its asymmetric tolerance in full
+	if got := RenderColorBand(decoder.ColorGrey, 4, decoder.Palette{}); !strings.Contains(got, "+80% / -20%") {
+		t.Errorf("RenderColorBand(Grey, 4) = %q, want it to contain %q", got, "+80% / -20%")
+	}
+}
+
+// TestPlainBands tests that without color support bands are named in
+// brackets, with no ANSI escapes
+func TestPlainBands(t *testing.T) {
+	profile := lipgloss.ColorProfile()
+	lipgloss.SetColorProfile(termenv.Ascii)
+	defer lipgloss.SetColorProfile(profile)
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"capacitor digit", RenderColorBand(decoder.ColorRed, 1, decoder.Palette{}), "[Red (2)]"},
+		{"capacitor tolerance", RenderColorBand(decoder.ColorGrey, 4, decoder.Palette{}), "[Grey (+80% / -20%)]"},
+		{"resistor multiplier", RenderResistorColorBand(decoder.ColorOrange, 3, 4, decoder.Palette{}), "[Orange (×1,000)]"},
+		{"military reliability", RenderResistorReadingBand(decoder.ResistorReading{Band5: decoder.ColorRed, BandCount: 5, Military: true}, 5, decoder.Palette{}),
+			"[Red (0.1%/1000 h)]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("got %q, want %q", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+// TestRenderBandStrip tests the band strip layout without color, where each
+// band shows its abbreviation and a trailing Gold or Silver band sits apart
+func TestRenderBandStrip(t *testing.T) {
+	profile := lipgloss.ColorProfile()
+	lipgloss.SetColorProfile(termenv.Ascii)
+	defer lipgloss.SetColorProfile(profile)
+
+	tests := []struct {
+		name   string
+		colors []decoder.Color
+		want   string
+	}{
+		{"4-band resistor", []decoder.Color{decoder.ColorBrown, decoder.ColorBlack, decoder.ColorRed, decoder.ColorGold}, "──┤ BN BK RD   GD ├──"},
+		{"adjacent same colors", []decoder.Color{decoder.ColorRed, decoder.ColorRed, decoder.ColorRed, decoder.ColorSilver}, "──┤ RD RD RD   SI ├──"},
+		{"capacitor", []decoder.Color{decoder.ColorRed, decoder.ColorViolet, decoder.ColorOrange, decoder.ColorBrown, decoder.ColorRed}, "──┤ RD VT OR BN RD ├──"},
+		{"gold not last", []decoder.Color{decoder.ColorGold, decoder.ColorBlack, decoder.ColorRed}, "──┤ GD BK RD ├──"},
+		{"no bands", nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RenderBandStrip(tt.colors); got != tt.want {
+				t.Errorf("RenderBandStrip() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestContentWidth tests that separators and result headers follow the
+// terminal width within the clamped range
+func TestContentWidth(t *testing.T) {
+	tests := []struct {
+		name          string
+		terminalWidth int
+		want          int
+	}{
+		{"size not yet known", 0, defaultContentWidth},
+		{"narrow terminal", 20, minContentWidth},
+		{"medium terminal", 50, 50},
+		{"wide terminal", 200, maxContentWidth},
+	}
+
+	entry := mustDecode(t, cliOptions{resistor: true, bands: "brown,black,red,gold"}, "")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			width := contentWidth(tt.terminalWidth)
+			if width != tt.want {
+				t.Fatalf("contentWidth(%d) = %d, want %d", tt.terminalWidth, width, tt.want)
+			}
+			if got := lipgloss.Width(RenderSeparator(width)); got != width {
+				t.Errorf("RenderSeparator(%d) is %d columns wide", width, got)
+			}
+
+			// The header lines fit the width instead of wrapping
+			box := RenderResultsBox(nil, entry.ResistorResult, ResultsView{Width: width})
+			lines := strings.Split(box, "\n")
+			for _, line := range lines[1:3] {
+				if got := lipgloss.Width(line); got != width {
+					t.Errorf("header line %q is %d columns wide, want %d", line, got, width)
+				}
+			}
+			if strings.Contains(lines[3], "═") {
+				t.Errorf("header rule wrapped onto line %q", lines[3])
+			}
+		})
+	}
+}
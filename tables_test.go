@@ -0,0 +1,58 @@
+package main
+
+import (
+	"testing"
+
+	"tropical-fish/decoder"
+)
+
+// TestCapacitorBandCount tests that selecting a capacitor type presets its conventional band count
+func TestCapacitorBandCount(t *testing.T) {
+	tests := []struct {
+		capType decoder.CapacitorType
+		want    int
+	}{
+		{decoder.TypeJ, 5},
+		{decoder.TypeK, 5},
+		{decoder.TypeL, 5},
+		{decoder.TypeM, 4},
+		{decoder.TypeN, 3},
+	}
+
+	for _, tt := range tests {
+		m := initialModel()
+		m.componentType = decoder.ComponentCapacitor
+		m.screen = screenTypeSelection
+		m.input = string(tt.capType)
+
+		updated, _ := m.handleTypeSelectionInput("enter")
+		got := updated.(model)
+		if got.capacitorReading.BandCount != tt.want {
+			t.Errorf("type %s preselected %d bands, want %d", tt.capType, got.capacitorReading.BandCount, tt.want)
+		}
+
+		// Enter accepts the preselection
+		updated, _ = got.handleBandCountInput("enter")
+		if s := updated.(model).screen; s != screenBandInput {
+			t.Errorf("type %s: enter on band count left screen %d, want band input", tt.capType, s)
+		}
+	}
+}
+
+// TestFourBandTypeMDecodes tests that a Type M part entered with its
+// preselected 4 bands gets past review to results
+func TestFourBandTypeMDecodes(t *testing.T) {
+	m := pressKeys(initialModel(), "enter", "c", "m", "enter", "enter",
+		"r", "d", "enter", "v", "t", "enter", "o", "r", "enter", "b", "n", "enter")
+	if m.screen != screenReview || m.capacitorReading.BandCount != 4 {
+		t.Fatalf("screen %v, band count %d, want review of 4 bands", m.screen, m.capacitorReading.BandCount)
+	}
+
+	m = pressKeys(m, "enter")
+	if m.screen != screenResults || len(m.reviewProblems) != 0 {
+		t.Fatalf("screen %v, problems %v, want results", m.screen, m.reviewProblems)
+	}
+	if result := m.capacitorResult; result == nil || result.CapacitancePF != 27000 || result.VoltageValid {
+		t.Errorf("result = %+v, want 27 nF with no voltage", result)
+	}
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+)
+
+// Build info, set at build time with
+// -ldflags "-X main.version=v1.2.0 -X main.buildDate=2025-01-31"
+var (
+	version   = "dev"
+	buildDate = "unknown"
+)
+
+// credits is the one-line attribution shown on the about screen
+const credits = "Tropical Fish by Johanness Nilson · built with Bubble Tea and Lip Gloss"
+
+// BuildInfo describes the running binary
+type BuildInfo struct {
+	Version   string
+	BuildDate string
+	GoVersion string
+}
+
+// CurrentBuildInfo returns the ldflags build info, falling back to the
+// module version recorded by `go install` when no version was set
+func CurrentBuildInfo() BuildInfo {
+	info := BuildInfo{Version: version, BuildDate: buildDate, GoVersion: runtime.Version()}
+	if info.Version == "dev" {
+		if bi, ok := debug.ReadBuildInfo(); ok && bi.Main.Version != "" && bi.Main.Version != "(devel)" {
+			info.Version = bi.Main.Version
+		}
+	}
+	return info
+}
+
+// String returns the build info as the -version output
+func (b BuildInfo) String() string {
+	return fmt.Sprintf("tropical-fish %s\nBuilt:   %s\nGo:      %s\n", b.Version, b.BuildDate, b.GoVersion)
+}